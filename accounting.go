@@ -0,0 +1,35 @@
+package cron
+
+import (
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+)
+
+// entryLabel is the pprof label that identifies the entry of a run. The
+// goroutines a job starts inherit it, so that goroutine and heap profiles can
+// attribute them to their entry, e.g. when they leak.
+const entryLabel = "cron_entry"
+
+// runAccounted runs the job of the given entry, counting it as in progress in
+// the entry's statistics and in Status and, if it is sampled, measuring its
+// allocations.
+// The run is labelled with the entry's ID for pprof.
+func (c *Cron) runAccounted(ctx context.Context, id EntryID, job Job) {
+	defer c.stats.recordStart(id)()
+	defer c.inflight.begin(ExecutingJob{Entry: id, Scheduled: scheduledFrom(ctx), Start: c.now()})()
+	sampled := c.allocRate > 0 && c.rand.Float64() < c.allocRate
+	var before runtime.MemStats
+	if sampled {
+		runtime.ReadMemStats(&before)
+	}
+	pprof.Do(ctx, pprof.Labels(entryLabel, strconv.Itoa(int(id))), func(ctx context.Context) {
+		RunJob(ctx, job)
+	})
+	if sampled {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		c.stats.recordAlloc(id, after.TotalAlloc-before.TotalAlloc)
+	}
+}
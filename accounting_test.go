@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestRunningCountsRunsInProgress(t *testing.T) {
+	var (
+		started = make(chan string, 1)
+		release = make(chan struct{})
+	)
+	cron := New(WithChain(), WithAllocSampling(1))
+	id, _ := cron.AddFuncCtx("@every 1h", func(ctx context.Context) {
+		label, _ := pprof.Label(ctx, entryLabel)
+		started <- label
+		<-release
+		_ = make([]byte, 1<<20)
+	})
+
+	cron.startJob(cron.store.Get(id), time.Now())
+	select {
+	case label := <-started:
+		if label != "1" {
+			t.Errorf("expected the run to be labelled with its entry, got %q", label)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to start")
+	}
+	if s := cron.StatsSnapshot()[id]; s.Running != 1 {
+		t.Errorf("expected 1 run in progress, got %d", s.Running)
+	}
+
+	// Resetting the counters keeps the runs in progress.
+	cron.ResetStats(id)
+	close(release)
+	cron.jobWaiter.Wait()
+	s := cron.StatsSnapshot()[id]
+	if s.Running != 0 {
+		t.Errorf("expected no run in progress, got %d", s.Running)
+	}
+	if s.AllocSamples != 1 || s.AllocBytes < 1<<20 {
+		t.Errorf("expected a sampled run of at least 1MiB, got %d runs and %d bytes", s.AllocSamples, s.AllocBytes)
+	}
+}
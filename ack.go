@@ -0,0 +1,218 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AckConfig configures WithManualAck.
+type AckConfig struct {
+	// Window is how long after its job returns a run may still be
+	// acknowledged with Ack.
+	Window time.Duration
+	// Redispatch is how many times a run that is not acknowledged in time is
+	// dispatched again, through the same path as RunNow. If zero, it is only
+	// reported with RunUnacked.
+	Redispatch int
+}
+
+// RunID identifies a run of an entry that requires acknowledgement. It stays
+// the same when the run is dispatched again. See RunIDFromContext.
+type RunID uint64
+
+// WithManualAck requires runs of the entry to be acknowledged with Ack, e.g.
+// when the job only enqueues work elsewhere and the run should be considered
+// complete once the real work is. A run that returns without failing, and is
+// not acknowledged within the configured window, is counted as unacked in the
+// entry's statistics, logged and reported with RunUnacked, and dispatched
+// again if configured. Runs that fail need no acknowledgement.
+//
+// The job obtains the ID to acknowledge with RunIDFromContext, and may pass it
+// on to the system doing the work.
+func WithManualAck(config AckConfig) EntryOption {
+	return func(e *Entry) {
+		e.ack = &config
+	}
+}
+
+// ErrUnknownRun is returned by Ack for a run that is not awaiting
+// acknowledgement, e.g. because it was acknowledged already or its window
+// expired.
+var ErrUnknownRun = errors.New("cron: no such run awaiting acknowledgement")
+
+// PendingAck describes a run awaiting acknowledgement. See PendingAcks.
+type PendingAck struct {
+	Run       RunID
+	Entry     EntryID
+	Scheduled time.Time
+	// Attempt is the number of times the run was dispatched again.
+	Attempt int
+	// Deadline is when the acknowledgement window expires, or the zero time
+	// while the job is still running.
+	Deadline time.Time
+}
+
+// RunUnacked is reported when a run was not acknowledged within its window.
+// See WithManualAck.
+type RunUnacked struct {
+	Entry     EntryID
+	Run       RunID
+	Scheduled time.Time
+	Time      time.Time
+	// Redispatched is true if the run is dispatched again.
+	Redispatched bool
+}
+
+func (RunUnacked) lifecycleEvent() {}
+
+// ackKey is the context key of the run of a job requiring acknowledgement.
+type ackKey struct{}
+
+// RunIDFromContext returns the ID of the run of ctx, or 0 if its entry does
+// not require acknowledgement.
+func RunIDFromContext(ctx context.Context) RunID {
+	if r, ok := ctx.Value(ackKey{}).(*ackRun); ok {
+		return r.id
+	}
+	return 0
+}
+
+// ackRun is a run awaiting acknowledgement.
+type ackRun struct {
+	id        RunID
+	entry     EntryID
+	scheduled time.Time
+	config    AckConfig
+	attempt   int
+	deadline  time.Time
+	timer     *time.Timer
+
+	// trigger is inherited by the run when it is dispatched again.
+	trigger triggerConfig
+}
+
+// ackTracker holds the runs of a Cron awaiting acknowledgement.
+type ackTracker struct {
+	mu   sync.Mutex
+	last RunID
+	runs map[RunID]*ackRun
+}
+
+// begin registers a new run of the entry, which awaits acknowledgement from
+// the time it is dispatched, so that it may be acknowledged before the job
+// returns.
+func (t *ackTracker) begin(id EntryID, scheduled time.Time, config AckConfig, trigger triggerConfig) *ackRun {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.runs == nil {
+		t.runs = make(map[RunID]*ackRun)
+	}
+	t.last++
+	r := &ackRun{id: t.last, entry: id, scheduled: scheduled, config: config, trigger: trigger}
+	t.runs[r.id] = r
+	return r
+}
+
+// Ack acknowledges the run with the given ID, completing it. It returns
+// ErrUnknownRun if the run is not awaiting acknowledgement.
+func (c *Cron) Ack(id RunID) error {
+	c.acks.mu.Lock()
+	r, ok := c.acks.runs[id]
+	if ok {
+		delete(c.acks.runs, id)
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+	}
+	c.acks.mu.Unlock()
+	if !ok {
+		return ErrUnknownRun
+	}
+	c.logger.Info("acked", "entry", r.entry, "run", id, "scheduled", r.scheduled)
+	return nil
+}
+
+// PendingAcks returns the runs awaiting acknowledgement, ordered by ID.
+func (c *Cron) PendingAcks() []PendingAck {
+	c.acks.mu.Lock()
+	defer c.acks.mu.Unlock()
+	pending := make([]PendingAck, 0, len(c.acks.runs))
+	for _, r := range c.acks.runs {
+		pending = append(pending, PendingAck{Run: r.id, Entry: r.entry, Scheduled: r.scheduled,
+			Attempt: r.attempt, Deadline: r.deadline})
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Run < pending[j].Run })
+	return pending
+}
+
+// awaitAck starts the acknowledgement window of the run of ctx, if it requires
+// acknowledgement, once its job has returned. A failed run is dropped.
+func (c *Cron) awaitAck(ctx context.Context, failed bool) {
+	r, ok := ctx.Value(ackKey{}).(*ackRun)
+	if !ok {
+		return
+	}
+	c.acks.mu.Lock()
+	defer c.acks.mu.Unlock()
+	if c.acks.runs[r.id] != r {
+		return // acknowledged while running
+	}
+	if failed {
+		delete(c.acks.runs, r.id)
+		return
+	}
+	r.deadline = c.now().Add(r.config.Window)
+	r.timer = time.AfterFunc(r.config.Window, func() { c.ackExpired(r) })
+}
+
+// ackExpired reports a run that was not acknowledged in time, and dispatches it
+// again if configured.
+func (c *Cron) ackExpired(r *ackRun) {
+	c.acks.mu.Lock()
+	if c.acks.runs[r.id] != r {
+		c.acks.mu.Unlock()
+		return
+	}
+	redispatch := r.attempt < r.config.Redispatch
+	if redispatch {
+		r.attempt++
+		r.deadline, r.timer = time.Time{}, nil
+	} else {
+		delete(c.acks.runs, r.id)
+	}
+	attempt := r.attempt
+	c.acks.mu.Unlock()
+
+	c.logger.Info("unacked", "entry", r.entry, "run", r.id, "scheduled", r.scheduled,
+		"redispatch", redispatch, "attempt", attempt)
+	c.stats.update(r.entry, func(s *EntryStats) { s.Unacked++ })
+	c.notify(RunUnacked{Entry: r.entry, Run: r.id, Scheduled: r.scheduled, Time: c.now(),
+		Redispatched: redispatch})
+	if redispatch {
+		c.runDone(runOutcome{id: r.entry, redispatch: r})
+	}
+}
+
+// redispatch runs the job of an unacknowledged run again, as RunNow would,
+// keeping its ID and the options it was triggered with.
+func (c *Cron) redispatch(r *ackRun, now time.Time) {
+	e := c.store.Get(r.entry)
+	if e == nil || e.Archived {
+		c.acks.mu.Lock()
+		delete(c.acks.runs, r.id)
+		c.acks.mu.Unlock()
+		return
+	}
+	if err := c.quota.acquireRun(now); err != nil {
+		c.acks.mu.Lock()
+		delete(c.acks.runs, r.id)
+		c.acks.mu.Unlock()
+		c.logger.Error(err, "skip", "entry", e.ID)
+		c.skipped(e.ID, r.scheduled, SkipOverloaded, "quota exceeded", false)
+		return
+	}
+	c.dispatch(e, r.scheduled, r, r.trigger)
+}
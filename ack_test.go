@@ -0,0 +1,121 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// unacked returns the RunUnacked events among the recorded ones.
+func (r *eventRecorder) unacked() []RunUnacked {
+	var unacked []RunUnacked
+	for _, ev := range r.recorded() {
+		if u, ok := ev.(RunUnacked); ok {
+			unacked = append(unacked, u)
+		}
+	}
+	return unacked
+}
+
+func TestManualAckBySelf(t *testing.T) {
+	rec := &eventRecorder{}
+	cron := New(WithEventListener(rec))
+	var ackErr error
+	id, _ := cron.AddFuncCtx("@hourly", func(ctx context.Context) {
+		ackErr = cron.Ack(RunIDFromContext(ctx))
+	}, WithManualAck(AckConfig{Window: 10 * time.Millisecond}))
+
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+	if ackErr != nil {
+		t.Fatalf("expected the run to be acknowledged, got %v", ackErr)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if pending := cron.PendingAcks(); len(pending) != 0 {
+		t.Errorf("expected no pending runs, got %+v", pending)
+	}
+	if unacked := rec.unacked(); len(unacked) != 0 {
+		t.Errorf("expected no unacked runs, got %+v", unacked)
+	}
+}
+
+func TestManualAckExternal(t *testing.T) {
+	rec := &eventRecorder{}
+	cron := New(WithEventListener(rec))
+	runs := make(chan RunID, 1)
+	id, _ := cron.AddFuncCtx("@hourly", func(ctx context.Context) {
+		runs <- RunIDFromContext(ctx)
+	}, WithManualAck(AckConfig{Window: 50 * time.Millisecond}))
+
+	scheduled := time.Now()
+	cron.startJob(cron.store.Get(id), scheduled)
+	cron.jobWaiter.Wait()
+	run := <-runs
+	pending := cron.PendingAcks()
+	if len(pending) != 1 || pending[0].Run != run || pending[0].Entry != id || pending[0].Deadline.IsZero() {
+		t.Fatalf("expected the run to await acknowledgement, got %+v", pending)
+	}
+	if err := cron.Ack(run); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.Ack(run); err != ErrUnknownRun {
+		t.Errorf("expected ErrUnknownRun acknowledging twice, got %v", err)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if unacked := rec.unacked(); len(unacked) != 0 {
+		t.Errorf("expected no unacked runs, got %+v", unacked)
+	}
+}
+
+func TestManualAckExpired(t *testing.T) {
+	rec := &eventRecorder{}
+	cron := New(WithEventListener(rec))
+	var (
+		mu   sync.Mutex
+		runs []RunID
+	)
+	id, _ := cron.AddFuncCtx("@hourly", func(ctx context.Context) {
+		mu.Lock()
+		runs = append(runs, RunIDFromContext(ctx))
+		mu.Unlock()
+	}, WithManualAck(AckConfig{Window: 10 * time.Millisecond, Redispatch: 1}))
+
+	cron.startJob(cron.store.Get(id), time.Now())
+	deadline := time.Now().Add(time.Second)
+	for len(rec.unacked()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cron.jobWaiter.Wait()
+
+	unacked := rec.unacked()
+	if len(unacked) != 2 || !unacked[0].Redispatched || unacked[1].Redispatched {
+		t.Fatalf("expected the run to be redispatched once, got %+v", unacked)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(runs) != 2 || runs[0] != runs[1] || runs[0] != unacked[0].Run {
+		t.Errorf("expected two runs with the same ID, got %v", runs)
+	}
+	if n := cron.StatsSnapshot()[id].Unacked; n != 2 {
+		t.Errorf("expected 2 unacked runs counted, got %d", n)
+	}
+	if err := cron.Ack(runs[0]); err != ErrUnknownRun {
+		t.Errorf("expected ErrUnknownRun after the window, got %v", err)
+	}
+}
+
+func TestManualAckFailedRun(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddErrFunc("@hourly", func() error { return errors.New("failed") },
+		WithManualAck(AckConfig{Window: time.Hour}))
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+	if pending := cron.PendingAcks(); len(pending) != 0 {
+		t.Errorf("expected a failed run not to await acknowledgement, got %+v", pending)
+	}
+	if RunIDFromContext(context.Background()) != 0 {
+		t.Error("expected no run ID outside of a run")
+	}
+}
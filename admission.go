@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdmissionAction is the outcome of an admission decision.
+type AdmissionAction int
+
+const (
+	// AdmissionAllow lets the run start.
+	AdmissionAllow AdmissionAction = iota
+	// AdmissionSkip drops the run; the entry is rescheduled as usual.
+	AdmissionSkip
+	// AdmissionDefer postpones the run to a later time.
+	AdmissionDefer
+)
+
+// Decision is returned by an admission function to allow, skip or defer a
+// run. The zero Decision allows the run.
+type Decision struct {
+	Action AdmissionAction
+	// Until is the time a deferred run is postponed to. It must be after the
+	// time the decision is made.
+	Until time.Time
+	// Reason is logged when a run is skipped or deferred.
+	Reason string
+}
+
+// AllowRun returns a Decision that lets the run start.
+func AllowRun() Decision {
+	return Decision{}
+}
+
+// SkipRun returns a Decision that drops the run for the given reason.
+func SkipRun(reason string) Decision {
+	return Decision{Action: AdmissionSkip, Reason: reason}
+}
+
+// DeferRun returns a Decision that postpones the run to the given time. The
+// admission function is consulted again when the deferred run is due.
+func DeferRun(until time.Time, reason string) Decision {
+	return Decision{Action: AdmissionDefer, Until: until, Reason: reason}
+}
+
+// admit consults the admission function, if any, about the run of the given
+// entry that is due at now. It returns true if the run may start. Otherwise
+// the entry has either been deferred, in which case deferred is true and its
+// Next time updated, or skipped.
+func (c *Cron) admit(e *Entry, now time.Time) (ok, deferred bool) {
+	if c.admission == nil {
+		return true, false
+	}
+	entry := *e
+	entry.Metadata = copyMetadata(e.Metadata)
+	d := c.admission(entry, e.Next)
+	switch d.Action {
+	case AdmissionAllow:
+		return true, false
+	case AdmissionDefer:
+		if !d.Until.After(now) {
+			c.logger.Error(fmt.Errorf("cron: cannot defer run to %v, which is not after %v", d.Until, now),
+				"skip", "entry", e.ID, "scheduled", e.Next, "reason", d.Reason)
+			break
+		}
+		c.logger.Info("defer", "entry", e.ID, "scheduled", e.Next, "until", d.Until, "reason", d.Reason)
+		e.Next = d.Until
+		return false, true
+	default:
+		c.logger.Info("skip", "entry", e.ID, "scheduled", e.Next, "reason", d.Reason)
+	}
+	c.skipped(e.ID, e.Next, SkipPredicate, d.Reason, false)
+	return false, false
+}
@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithAdmission(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		scheduled []time.Time
+		ran       = make(chan EntryID, 10)
+	)
+	admit := func(e Entry, at time.Time) Decision {
+		if e.Metadata["freeze"] == "true" {
+			return SkipRun("billing freeze")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		scheduled = append(scheduled, at)
+		if len(scheduled) == 1 {
+			return DeferRun(at.Add(300*time.Millisecond), "regulatory window")
+		}
+		return AllowRun()
+	}
+
+	cron := New(WithParser(secondParser), WithChain(), WithAdmission(admit))
+	frozen, _ := cron.AddFunc("* * * * * ?", func() { ran <- 1 },
+		WithMetadata(map[string]string{"freeze": "true"}))
+	cron.Schedule(EveryImmediate(time.Hour), FuncJob(func() { ran <- 2 }))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case id := <-ran:
+		if id != 2 {
+			t.Fatalf("expected only the deferred entry to run, got %d", id)
+		}
+	case <-time.After(OneSecond):
+		t.Fatal("expected the deferred run to happen")
+	}
+
+	mu.Lock()
+	if len(scheduled) != 2 || scheduled[1].Sub(scheduled[0]) != 300*time.Millisecond {
+		t.Errorf("expected the run to be deferred by 300ms, got %v", scheduled)
+	}
+	mu.Unlock()
+
+	time.Sleep(OneSecond)
+	if stats := cron.StatsSnapshot()[frozen]; stats.Skipped == 0 || stats.Runs != 0 {
+		t.Errorf("expected the frozen entry to be skipped, got %+v", stats)
+	}
+}
+
+func TestAdmissionDeferIntoPast(t *testing.T) {
+	var buf syncWriter
+	now := time.Now()
+	cron := New(WithLogger(newBufLogger(&buf)), WithAdmission(func(e Entry, at time.Time) Decision {
+		return DeferRun(at, "")
+	}))
+	e := &Entry{ID: 1, Next: now}
+	if ok, deferred := cron.admit(e, now); ok || deferred {
+		t.Errorf("expected the run to be skipped, got ok=%v deferred=%v", ok, deferred)
+	}
+	if !strings.Contains(buf.String(), "cannot defer run") {
+		t.Errorf("expected the invalid decision to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"context"
+	"runtime"
+)
+
+// LockOSThread runs the wrapped job with its goroutine locked to an OS thread
+// for the duration of the run. It is intended for jobs calling into C or GPU
+// libraries that keep thread-local state.
+func LockOSThread() JobWrapper {
+	return func(j Job) Job {
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			return RunJobE(ctx, j)
+		})
+	}
+}
+
+// Budget is a pool of tokens limiting how many heavy jobs may run at once,
+// similar to GOMAXPROCS for goroutines. A Budget may be shared by any number
+// of entries, and across Cron instances.
+type Budget struct {
+	tokens chan struct{}
+}
+
+// NewBudget returns a Budget allowing at most n concurrent runs.
+// A budget of less than 1 allows a single run at a time.
+func NewBudget(n int) *Budget {
+	if n < 1 {
+		n = 1
+	}
+	return &Budget{tokens: make(chan struct{}, n)}
+}
+
+// InUse returns the number of tokens currently held by running jobs.
+func (b *Budget) InUse() int {
+	return len(b.tokens)
+}
+
+// WithinBudget delays each run of the wrapped job until a token of the given
+// budget is available, and releases it once the run completes.
+func WithinBudget(b *Budget) JobWrapper {
+	return func(j Job) Job {
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			b.tokens <- struct{}{}
+			defer func() { <-b.tokens }()
+			return RunJobE(ctx, j)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockOSThread(t *testing.T) {
+	var ran bool
+	LockOSThread()(FuncJob(func() { ran = true })).Run()
+	if !ran {
+		t.Error("expected the wrapped job to run")
+	}
+}
+
+func TestWithinBudget(t *testing.T) {
+	var (
+		budget  = NewBudget(2)
+		running int64
+		max     int64
+		wg      sync.WaitGroup
+	)
+	job := WithinBudget(budget)(FuncJob(func() {
+		n := atomic.AddInt64(&running, 1)
+		for {
+			m := atomic.LoadInt64(&max)
+			if n <= m || atomic.CompareAndSwapInt64(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt64(&running, -1)
+	}))
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.Run()
+		}()
+	}
+	wg.Wait()
+
+	if max != 2 {
+		t.Errorf("expected at most 2 concurrent runs, got %d", max)
+	}
+	if budget.InUse() != 0 {
+		t.Errorf("expected all tokens to be released, got %d in use", budget.InUse())
+	}
+}
+
+func TestWithWrappers(t *testing.T) {
+	var nums []int
+	cron := New(WithChain(appendingWrapper(&nums, 1)))
+	id := cron.Schedule(Every(time.Hour), appendingJob(&nums, 4),
+		WithWrappers(appendingWrapper(&nums, 2), appendingWrapper(&nums, 3)))
+	cron.Entry(id).WrappedJob.Run()
+	if len(nums) != 4 || nums[0] != 1 || nums[1] != 2 || nums[2] != 3 || nums[3] != 4 {
+		t.Error("unexpected order of calls:", nums)
+	}
+}
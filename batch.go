@@ -0,0 +1,284 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// batchOp is an operation applied to several entries at once.
+type batchOp int
+
+const (
+	opTrigger batchOp = iota
+	opPause
+	opResume
+	opArchive
+	opRestore
+	opRotate
+	opUpdate
+)
+
+func (op batchOp) String() string {
+	switch op {
+	case opTrigger:
+		return "trigger"
+	case opPause:
+		return "pause"
+	case opResume:
+		return "resume"
+	case opArchive:
+		return "archive"
+	case opRotate:
+		return "rotate"
+	case opUpdate:
+		return "update"
+	default:
+		return "restore"
+	}
+}
+
+// batchEvents maps the operations that change entries to the events they
+// emit, one for all the entries of a batch.
+var batchEvents = map[batchOp]EventType{
+	opTrigger: EventTriggered,
+	opPause:   EventPaused,
+	opResume:  EventResumed,
+	opArchive: EventArchived,
+	opRestore: EventRestored,
+	opUpdate:  EventUpdated,
+}
+
+// batchRequest asks the scheduler to apply an operation to the given entries,
+// and to reply with the IDs of the entries it applied to.
+type batchRequest struct {
+	op    batchOp
+	ids   []EntryID
+	reply chan []EntryID
+
+	// errs, if set, receives the reason why the operation did not apply to
+	// each entry left out of the reply.
+	errs map[EntryID]error
+
+	// trigger configures the runs started by opTrigger.
+	trigger triggerConfig
+
+	// update is the change made by opUpdate.
+	update entryUpdate
+}
+
+// TriggerOption configures the runs started by RunNow and TriggerMany, e.g. so
+// that operator-initiated reruns do not compete with scheduled runs.
+type TriggerOption func(*triggerConfig)
+
+// triggerConfig overrides how a run is dispatched.
+type triggerConfig struct {
+	priority    int
+	hasPriority bool
+	group       *Group
+}
+
+// TriggerPriority queues the runs in the worker pool (see WithWorkerPool) with
+// the given priority instead of the priority of their entries.
+func TriggerPriority(priority int) TriggerOption {
+	return func(t *triggerConfig) {
+		t.priority, t.hasPriority = priority, true
+	}
+}
+
+// TriggerInGroup queues the runs on the given group instead of the group of
+// their entries or the worker pool, e.g. so that bulk reruns run one at a time
+// on a group of their own, out of the way of scheduled runs.
+func TriggerInGroup(group *Group) TriggerOption {
+	return func(t *triggerConfig) {
+		t.group = group
+	}
+}
+
+// newTriggerConfig applies the options.
+func newTriggerConfig(opts []TriggerOption) triggerConfig {
+	var t triggerConfig
+	for _, opt := range opts {
+		opt(&t)
+	}
+	return t
+}
+
+// fail records why the request did not apply to the given entry.
+func (req batchRequest) fail(id EntryID, err error) {
+	if req.errs != nil {
+		req.errs[id] = err
+	}
+}
+
+// TriggerMany runs the jobs of the given entries now, in addition to their
+// scheduled runs, whether or not they are paused. The runs count against the
+// configured Quota. Archived entries are not run. It returns the IDs of the
+// entries that were run. The runs may be configured with options, e.g. to
+// lower their priority.
+func (c *Cron) TriggerMany(ids []EntryID, opts ...TriggerOption) []EntryID {
+	req := batchRequest{op: opTrigger, ids: ids, trigger: newTriggerConfig(opts)}
+	return c.sendBatch(req)
+}
+
+// ErrNoSuchEntry is returned by RunNow for an unknown entry ID.
+var ErrNoSuchEntry = errors.New("cron: no such entry")
+
+// ErrEntryArchived is returned by RunNow for an archived entry.
+var ErrEntryArchived = errors.New("cron: entry is archived")
+
+// RunNow runs the job of the given entry now, through the same path as its
+// scheduled runs: the run goes through the entry's wrappers and the Cron's
+// Chain (so that e.g. SkipIfStillRunning and Recover apply), its group or the
+// worker pool, and is waited for by Stop. Calling Entry.Job.Run directly
+// would bypass all of these. It returns ErrNoSuchEntry, ErrEntryArchived or
+// a *QuotaError if the run could not be started. The run may be configured
+// with options, e.g. to lower its priority.
+func (c *Cron) RunNow(id EntryID, opts ...TriggerOption) error {
+	req := batchRequest{op: opTrigger, ids: []EntryID{id}, errs: make(map[EntryID]error),
+		trigger: newTriggerConfig(opts)}
+	if len(c.sendBatch(req)) == 0 {
+		return req.errs[id]
+	}
+	return nil
+}
+
+// PauseMany stops scheduling the given entries until they are resumed. Runs
+// already in progress are not affected. It returns the IDs of the entries that
+// were paused.
+func (c *Cron) PauseMany(ids []EntryID) []EntryID {
+	return c.batch(opPause, ids)
+}
+
+// ResumeMany resumes scheduling the given paused entries, from their next
+// activation after now. It returns the IDs of the entries that were resumed.
+func (c *Cron) ResumeMany(ids []EntryID) []EntryID {
+	return c.batch(opResume, ids)
+}
+
+// Archive stops scheduling the given entry and hides it from Entries, while
+// keeping its configuration and statistics so that it may be inspected with
+// ArchivedEntries and re-enabled with Restore. Unlike PauseMany, archiving is
+// meant to be long-lived; unlike Remove, it can be undone. It returns false if
+// the entry does not exist or is already archived.
+func (c *Cron) Archive(id EntryID) bool {
+	return len(c.batch(opArchive, []EntryID{id})) > 0
+}
+
+// Restore re-enables an archived entry. Unless it is also paused, it is
+// scheduled again from its next activation after now. It returns false if the
+// entry does not exist or is not archived.
+func (c *Cron) Restore(id EntryID) bool {
+	return len(c.batch(opRestore, []EntryID{id})) > 0
+}
+
+// batch applies the operation to all the given entries at once: in a single
+// step of the run loop if the cron is running, or directly otherwise.
+func (c *Cron) batch(op batchOp, ids []EntryID) []EntryID {
+	return c.batchErrs(op, ids, nil)
+}
+
+// batchErrs is batch, recording in errs why the operation did not apply to
+// each entry it skipped.
+func (c *Cron) batchErrs(op batchOp, ids []EntryID, errs map[EntryID]error) []EntryID {
+	return c.sendBatch(batchRequest{op: op, ids: ids, errs: errs})
+}
+
+// sendBatch applies the request in a single step of the run loop if the cron
+// is running, or directly otherwise.
+func (c *Cron) sendBatch(req batchRequest) []EntryID {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		req.reply = make(chan []EntryID, 1)
+		c.batches <- req
+		return <-req.reply
+	}
+	return c.applyBatch(req, c.now())
+}
+
+// applyBatch applies the request, and logs and emits a single event for it.
+func (c *Cron) applyBatch(req batchRequest, now time.Time) []EntryID {
+	var (
+		applied       []EntryID
+		spec, oldSpec string
+	)
+	for _, id := range req.ids {
+		e := c.store.Get(id)
+		if e == nil {
+			req.fail(id, ErrNoSuchEntry)
+			continue
+		}
+		switch req.op {
+		case opTrigger:
+			if e.Archived {
+				req.fail(id, ErrEntryArchived)
+				continue
+			}
+			if err := c.quota.acquireRun(now); err != nil {
+				c.logger.Error(err, "skip", "entry", e.ID)
+				c.skipped(e.ID, now, SkipOverloaded, "quota exceeded", false)
+				req.fail(id, err)
+				continue
+			}
+			c.dispatch(e, now, nil, req.trigger)
+		case opPause:
+			if e.Paused {
+				continue
+			}
+			e.Paused = true
+			e.Next = time.Time{}
+		case opResume:
+			if !e.Paused {
+				continue
+			}
+			e.Paused = false
+			if c.running && !e.Archived {
+				e.Next = c.nextActivation(e, now)
+			}
+		case opArchive:
+			if e.Archived {
+				continue
+			}
+			e.Archived = true
+			e.Next = time.Time{}
+		case opRestore:
+			if !e.Archived {
+				continue
+			}
+			e.Archived = false
+			if c.running && !e.Paused {
+				e.Next = c.nextActivation(e, now)
+			}
+		case opRotate:
+			rotated, err := c.rotatePayload(e)
+			if err != nil {
+				req.fail(id, err)
+				continue
+			}
+			if !rotated {
+				continue
+			}
+		case opUpdate:
+			oldSpec = e.Spec
+			c.applyUpdate(e, req.update, now)
+		}
+		c.store.Update(e)
+		applied = append(applied, id)
+		spec = e.Spec
+	}
+	c.logger.Info(req.op.String(), "now", now, "entries", applied)
+	if typ, ok := batchEvents[req.op]; ok && len(applied) > 0 {
+		ev := Event{Type: typ, Entries: applied, Initiator: InitiatorAPI}
+		if len(applied) == 1 {
+			ev.Entry, ev.Spec = applied[0], spec
+		}
+		switch typ {
+		case EventPaused:
+			ev.Reason = "requested"
+		case EventUpdated:
+			ev.OldSpec = oldSpec
+		}
+		c.emit(ev)
+	}
+	return applied
+}
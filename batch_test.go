@@ -0,0 +1,216 @@
+package cron
+
+import (
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTriggerMany(t *testing.T) {
+	var buf syncWriter
+	var runs int32
+	cron := New(WithLogger(VerbosePrintfLogger(log.New(&buf, "", 0))))
+	a, _ := cron.AddFunc("@yearly", func() { atomic.AddInt32(&runs, 1) })
+	b, _ := cron.AddFunc("@yearly", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	if applied := cron.TriggerMany([]EntryID{a, b, 99}); !reflect.DeepEqual(applied, []EntryID{a, b}) {
+		t.Errorf("expected %v to be triggered, got %v", []EntryID{a, b}, applied)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := atomic.LoadInt32(&runs); n != 2 {
+		t.Errorf("expected 2 runs, got %d", n)
+	}
+	if n := strings.Count(buf.String(), "trigger, now="); n != 1 {
+		t.Errorf("expected a single trigger event, got %d", n)
+	}
+}
+
+func TestPauseManyResumeMany(t *testing.T) {
+	var runs int32
+	cron := newWithSeconds()
+	id, _ := cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	if applied := cron.PauseMany([]EntryID{id}); len(applied) != 1 {
+		t.Fatalf("expected the entry to be paused, got %v", applied)
+	}
+	if applied := cron.PauseMany([]EntryID{id}); len(applied) != 0 {
+		t.Errorf("expected pausing twice to do nothing, got %v", applied)
+	}
+	if e := cron.Entry(id); !e.Paused || !e.Next.IsZero() {
+		t.Errorf("expected a paused entry without a next time, got %+v", e)
+	}
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Errorf("expected no runs while paused, got %d", n)
+	}
+
+	if applied := cron.ResumeMany([]EntryID{id}); len(applied) != 1 {
+		t.Fatalf("expected the entry to be resumed, got %v", applied)
+	}
+	if e := cron.Entry(id); e.Paused || e.Next.IsZero() {
+		t.Errorf("expected a scheduled entry, got %+v", e)
+	}
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n == 0 {
+		t.Error("expected the entry to run after resuming")
+	}
+}
+
+func TestPauseManyBeforeStart(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("@every 1s", func() {})
+	cron.PauseMany([]EntryID{id})
+	cron.Start()
+	defer cron.Stop()
+	if e := cron.Entry(id); !e.Paused || !e.Next.IsZero() {
+		t.Errorf("expected the entry to stay paused across Start, got %+v", e)
+	}
+}
+
+func TestArchiveRestore(t *testing.T) {
+	var runs int32
+	cron := newWithSeconds()
+	id, _ := cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	if !cron.Archive(id) {
+		t.Fatal("expected the entry to be archived")
+	}
+	if cron.Archive(id) {
+		t.Error("expected archiving twice to fail")
+	}
+	if len(cron.Entries()) != 0 {
+		t.Errorf("expected archived entries to be hidden, got %v", cron.Entries())
+	}
+	if archived := cron.ArchivedEntries(); len(archived) != 1 || archived[0].ID != id || !archived[0].Next.IsZero() {
+		t.Errorf("expected the entry to be listed as archived, got %+v", archived)
+	}
+	if e := cron.Entry(id); !e.Archived {
+		t.Errorf("expected Entry to find the archived entry, got %+v", e)
+	}
+	if applied := cron.TriggerMany([]EntryID{id}); len(applied) != 0 {
+		t.Errorf("expected archived entries not to be triggered, got %v", applied)
+	}
+	if _, ok := cron.StatsSnapshot()[id]; !ok {
+		t.Error("expected the archived entry to keep its statistics")
+	}
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Errorf("expected no runs while archived, got %d", n)
+	}
+
+	if !cron.Restore(id) {
+		t.Fatal("expected the entry to be restored")
+	}
+	if e := cron.Entry(id); e.Archived || e.Next.IsZero() {
+		t.Errorf("expected a scheduled entry, got %+v", e)
+	}
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n == 0 {
+		t.Error("expected the entry to run after restoring")
+	}
+}
+
+func TestRunNow(t *testing.T) {
+	var (
+		runs    int32
+		release = make(chan struct{})
+	)
+	cron := New(WithChain(SkipIfStillRunning(DiscardLogger)), WithQuota(NewQuota(0, 2)),
+		WithLogger(DiscardLogger))
+	id, _ := cron.AddFunc("@daily", func() {
+		atomic.AddInt32(&runs, 1)
+		<-release
+	})
+	archived, _ := cron.AddFunc("@daily", func() {})
+	cron.Archive(archived)
+	cron.Start()
+
+	if err := cron.RunNow(id); err != nil {
+		t.Fatal(err)
+	}
+	// The second run is skipped by the chain, since the first is still going.
+	if err := cron.RunNow(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.RunNow(id); err == nil {
+		t.Error("expected the quota to be exceeded")
+	} else if _, ok := err.(*QuotaError); !ok {
+		t.Errorf("expected a *QuotaError, got %v", err)
+	}
+	if err := cron.RunNow(archived); err != ErrEntryArchived {
+		t.Errorf("expected ErrEntryArchived, got %v", err)
+	}
+	if err := cron.RunNow(42); err != ErrNoSuchEntry {
+		t.Errorf("expected ErrNoSuchEntry, got %v", err)
+	}
+
+	close(release)
+	select {
+	case <-cron.Stop().Done():
+	case <-time.After(OneSecond):
+		t.Fatal("expected Stop to wait for the run")
+	}
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("expected 1 run, got %d", n)
+	}
+}
+
+func TestTriggerOptions(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		order   []string
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	cron := New(WithWorkerPool(1), WithLogger(DiscardLogger))
+	blocker, _ := cron.AddFunc("@daily", func() {
+		close(started)
+		<-release
+	})
+	live, _ := cron.AddFunc("@daily", record("live"), WithPriority(1))
+	rerun, _ := cron.AddFunc("@daily", record("rerun"), WithPriority(10))
+
+	// Occupy the only worker while the other runs are queued.
+	cron.RunNow(blocker)
+	<-started
+	if err := cron.RunNow(rerun, TriggerPriority(-5)); err != nil {
+		t.Fatal(err)
+	}
+	cron.RunNow(live)
+	close(release)
+	cron.jobWaiter.Wait()
+	mu.Lock()
+	if !reflect.DeepEqual(order, []string{"live", "rerun"}) {
+		t.Errorf("expected the rerun to yield to the live run, got %v", order)
+	}
+	mu.Unlock()
+
+	backfills := NewGroup("backfills", 0)
+	if applied := cron.TriggerMany([]EntryID{live, rerun}, TriggerInGroup(backfills)); len(applied) != 2 {
+		t.Fatalf("expected 2 runs, got %v", applied)
+	}
+	cron.jobWaiter.Wait()
+	if stats := backfills.Stats(); stats.Runs != 2 {
+		t.Errorf("expected the runs to go through the group, got %+v", stats)
+	}
+	if stats := cron.PoolStats(); stats.Dispatched != 3 {
+		t.Errorf("expected the grouped runs to bypass the pool, got %+v", stats)
+	}
+}
@@ -0,0 +1,233 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calendar tells business days apart from weekends and holidays.
+type Calendar interface {
+	// IsBusinessDay reports whether the day of t, in t's location, is a
+	// business day.
+	IsBusinessDay(t time.Time) bool
+}
+
+// BusinessCalendar is a Calendar whose business days are Monday to Friday,
+// except for a set of holidays.
+type BusinessCalendar struct {
+	holidays map[civilDate]bool
+}
+
+// civilDate is a day in the calendar, independent of any location.
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+// NewBusinessCalendar returns a BusinessCalendar with the given holidays. Only
+// the date of each holiday, in its own location, is significant.
+func NewBusinessCalendar(holidays ...time.Time) *BusinessCalendar {
+	c := &BusinessCalendar{holidays: make(map[civilDate]bool, len(holidays))}
+	for _, h := range holidays {
+		c.holidays[dateOf(h)] = true
+	}
+	return c
+}
+
+// IsBusinessDay reports whether t falls on a weekday that is not a holiday.
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !c.holidays[dateOf(t)]
+}
+
+// ShiftDirection is the way ShiftToBusinessDay moves activations that fall on
+// days that are not business days.
+type ShiftDirection int
+
+const (
+	// Forward moves activations to the following business day.
+	Forward ShiftDirection = iota
+	// Backward moves activations to the preceding business day.
+	Backward
+)
+
+// maxBusinessDaySearch bounds the days searched for a business day, so that
+// a calendar without any does not loop forever.
+const maxBusinessDaySearch = 366
+
+// businessDaySchedule moves the activations of a schedule to business days.
+type businessDaySchedule struct {
+	schedule  Schedule
+	calendar  Calendar
+	direction ShiftDirection
+}
+
+// ShiftToBusinessDay returns a Schedule whose activations are those of s,
+// except that activations falling on days that are not business days in cal
+// are moved, at the same time of day, to the nearest business day in the
+// given direction rather than skipped. For example, "run on the 1st, or the
+// next business day":
+//
+//	ShiftToBusinessDay(monthly, cal, Forward)
+//
+// Activations moved onto the same time as another activation are merged.
+func ShiftToBusinessDay(s Schedule, cal Calendar, direction ShiftDirection) Schedule {
+	return businessDaySchedule{s, cal, direction}
+}
+
+// shift moves t to the nearest business day in the schedule's direction, or
+// returns the zero time if there is none.
+func (s businessDaySchedule) shift(t time.Time) time.Time {
+	step := 1
+	if s.direction == Backward {
+		step = -1
+	}
+	for i := 0; i <= maxBusinessDaySearch; i++ {
+		if d := t.AddDate(0, 0, i*step); s.calendar.IsBusinessDay(d) {
+			return d
+		}
+	}
+	return time.Time{}
+}
+
+// Next returns the next activation time, later than the given time. Shifting
+// preserves the order of activations, so the first shifted activation later
+// than t is the next one.
+func (s businessDaySchedule) Next(t time.Time) time.Time {
+	if s.direction == Forward {
+		// Activations up to t on the days that are not business days
+		// immediately before the next business day are moved past t.
+		if from, ok := s.afterLastBusinessDay(t); ok {
+			for o := s.schedule.Next(from.Add(-time.Nanosecond)); !o.IsZero() && !o.After(t); o = s.schedule.Next(o) {
+				if next := s.shift(o); next.After(t) {
+					return next
+				}
+			}
+		}
+		next := s.schedule.Next(t)
+		if next.IsZero() {
+			return next
+		}
+		return s.shift(next)
+	}
+	for o := s.schedule.Next(t); !o.IsZero(); o = s.schedule.Next(o) {
+		if next := s.shift(o); next.After(t) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// afterLastBusinessDay returns the start of the day following the last
+// business day before the day of t.
+func (s businessDaySchedule) afterLastBusinessDay(t time.Time) (time.Time, bool) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 1; i <= maxBusinessDaySearch; i++ {
+		if s.calendar.IsBusinessDay(day.AddDate(0, 0, -i)) {
+			return day.AddDate(0, 0, -i+1), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// MonthlyBusinessDaySchedule activates once a month, on the nth business day of
+// the month counted from its start or end, e.g. for finance jobs that run "on
+// the 3rd business day".
+type MonthlyBusinessDaySchedule struct {
+	// N is the business day of the month, counting from 1 at the start of the
+	// month, or from -1 at its end. Months with fewer business days are
+	// skipped.
+	N int
+
+	// Calendar tells business days apart. If nil, they are Monday to Friday.
+	Calendar Calendar
+
+	// TimeOfDay is the wall clock time of the activation, measured from
+	// midnight.
+	TimeOfDay time.Duration
+
+	Location *time.Location
+}
+
+// NthBusinessDayOfMonth returns a Schedule that activates at midnight on the
+// nth business day of each month in the given location, or time.Local if nil.
+// Set the Calendar and TimeOfDay fields of the result to honor holidays and
+// run at another time, e.g.:
+//
+//	s := NthBusinessDayOfMonth(3, nil)
+//	s.Calendar = NewBusinessCalendar(holidays...)
+//	s.TimeOfDay = 9 * time.Hour
+//
+// It panics if n is not positive.
+func NthBusinessDayOfMonth(n int, loc *time.Location) MonthlyBusinessDaySchedule {
+	if n <= 0 {
+		panic(fmt.Sprintf("cron: invalid business day of month %d", n))
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return MonthlyBusinessDaySchedule{N: n, Location: loc}
+}
+
+// LastBusinessDayOfMonth returns a Schedule that activates at midnight on the
+// last business day of each month in the given location, or time.Local if nil.
+// See NthBusinessDayOfMonth.
+func LastBusinessDayOfMonth(loc *time.Location) MonthlyBusinessDaySchedule {
+	if loc == nil {
+		loc = time.Local
+	}
+	return MonthlyBusinessDaySchedule{N: -1, Location: loc}
+}
+
+// maxMonthlyBusinessDaySearch bounds the months searched for an activation,
+// as SpecSchedule does.
+const maxMonthlyBusinessDaySearch = 5 * 12
+
+// Next returns the next activation time, later than the given time.
+func (s MonthlyBusinessDaySchedule) Next(t time.Time) time.Time {
+	local := t.In(s.Location)
+	for i := 0; i <= maxMonthlyBusinessDaySearch; i++ {
+		first := time.Date(local.Year(), local.Month()+time.Month(i), 1, 0, 0, 0, 0, s.Location)
+		day, ok := s.day(first.Year(), first.Month())
+		if !ok {
+			continue
+		}
+		at := time.Date(first.Year(), first.Month(), day,
+			int(s.TimeOfDay/time.Hour), int(s.TimeOfDay%time.Hour/time.Minute), 0, 0, s.Location)
+		if at.After(t) {
+			return at.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// day returns the day of the given month that is its Nth business day, and
+// false if the month has fewer business days.
+func (s MonthlyBusinessDaySchedule) day(year int, month time.Month) (int, bool) {
+	cal := s.Calendar
+	if cal == nil {
+		cal = NewBusinessCalendar()
+	}
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, s.Location).Day()
+	count, day, step := 0, 1, 1
+	if s.N < 0 {
+		day, step = days, -1
+	}
+	for ; day >= 1 && day <= days; day += step {
+		// Noon is on the same day in every location and DST transition.
+		if cal.IsBusinessDay(time.Date(year, month, day, 12, 0, 0, 0, s.Location)) {
+			if count++; count == s.N*step {
+				return day, true
+			}
+		}
+	}
+	return 0, false
+}
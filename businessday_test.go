@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftToBusinessDay(t *testing.T) {
+	newYear := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cal := NewBusinessCalendar(newYear)
+	first, err := ParseStandard("TZ=UTC 0 9 1 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name      string
+		direction ShiftDirection
+		from      time.Time
+		expected  time.Time
+	}{
+		// Jan 1 is a holiday; Feb 1 and Mar 1 are Sundays; Aug 1 is a Saturday.
+		{"holiday forward", Forward, at(time.January, 1, 0).Add(-time.Hour), at(time.January, 2, 9)},
+		{"weekend forward", Forward, at(time.January, 31, 10), at(time.February, 2, 9)},
+		{"skipped day forward", Forward, at(time.February, 1, 10), at(time.February, 2, 9)},
+		{"after shifted run", Forward, at(time.February, 2, 9), at(time.March, 2, 9)},
+		{"business day", Forward, at(time.June, 15, 0), at(time.July, 1, 9)},
+		{"weekend backward", Backward, at(time.July, 30, 0), at(time.July, 31, 9)},
+		{"after shifted run backward", Backward, at(time.July, 31, 9), at(time.September, 1, 9)},
+		{"holiday backward", Backward, time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ShiftToBusinessDay(first, cal, tt.direction)
+			if next := s.Next(tt.from); !next.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	cal := NewBusinessCalendar(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		day      time.Time
+		business bool
+	}{
+		{time.Date(2026, 12, 24, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 12, 26, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 12, 28, 12, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		if got := cal.IsBusinessDay(tt.day); got != tt.business {
+			t.Errorf("%v: expected business day %v, got %v", tt.day, tt.business, got)
+		}
+	}
+}
+
+func TestMonthlyBusinessDay(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	third := NthBusinessDayOfMonth(3, time.UTC)
+	third.TimeOfDay = 9 * time.Hour
+	thirdHolidays := third
+	// With Jan 1 a holiday, the 3rd business day is Tuesday Jan 6.
+	thirdHolidays.Calendar = NewBusinessCalendar(at(time.January, 1, 0))
+	last := LastBusinessDayOfMonth(time.UTC)
+	tests := []struct {
+		name     string
+		schedule Schedule
+		from     time.Time
+		expected time.Time
+	}{
+		{"third", third, at(time.January, 1, 0), at(time.January, 5, 9)},
+		{"third with holiday", thirdHolidays, at(time.January, 1, 0), at(time.January, 6, 9)},
+		{"third after run", third, at(time.January, 5, 9), at(time.February, 4, 9)},
+		// Jan 31 is a Saturday.
+		{"last", last, at(time.January, 10, 0), at(time.January, 30, 0)},
+		{"last after run", last, at(time.January, 30, 0), at(time.February, 27, 0)},
+		{"more than in any month", NthBusinessDayOfMonth(24, time.UTC), at(time.January, 1, 0), time.Time{}},
+		{"no business day", MonthlyBusinessDaySchedule{N: 1, Calendar: WeekdayCalendar{}, Location: time.UTC},
+			at(time.January, 1, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if next := tt.schedule.Next(tt.from); !next.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, next)
+			}
+		})
+	}
+}
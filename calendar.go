@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// WeekdayCalendar is a Calendar whose business days are the listed days of the
+// week, e.g. Sunday to Thursday.
+type WeekdayCalendar []time.Weekday
+
+// IsBusinessDay reports whether t falls on one of the listed days.
+func (c WeekdayCalendar) IsBusinessDay(t time.Time) bool {
+	for _, wd := range c {
+		if t.Weekday() == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// DateListCalendar is a Calendar whose business days are all days except for a
+// fixed list of dates, e.g. the holidays of an exchange. Unlike
+// BusinessCalendar, it does not exclude weekends.
+type DateListCalendar struct {
+	excluded map[civilDate]bool
+}
+
+// NewDateListCalendar returns a DateListCalendar excluding the given dates.
+// Only the date of each, in its own location, is significant.
+func NewDateListCalendar(dates ...time.Time) *DateListCalendar {
+	c := &DateListCalendar{excluded: make(map[civilDate]bool, len(dates))}
+	for _, d := range dates {
+		c.excluded[dateOf(d)] = true
+	}
+	return c
+}
+
+// ParseDateListCalendar returns a DateListCalendar excluding the given dates,
+// in "2006-01-02" format, e.g. as read from a configuration file.
+func ParseDateListCalendar(dates ...string) (*DateListCalendar, error) {
+	c := &DateListCalendar{excluded: make(map[civilDate]bool, len(dates))}
+	for _, s := range dates {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return nil, fmt.Errorf("cron: invalid date %q: %v", s, err)
+		}
+		c.excluded[dateOf(d)] = true
+	}
+	return c, nil
+}
+
+// IsBusinessDay reports whether t falls on a date that is not excluded.
+func (c *DateListCalendar) IsBusinessDay(t time.Time) bool {
+	return !c.excluded[dateOf(t)]
+}
+
+// calendarSchedule skips the activations of a schedule that fall on days that
+// are not business days.
+type calendarSchedule struct {
+	schedule Schedule
+	calendar Calendar
+}
+
+// SkipNonBusinessDays returns a Schedule whose activations are those of s that
+// fall on business days in cal; the others are skipped. To move them to a
+// business day instead, use ShiftToBusinessDay.
+func SkipNonBusinessDays(s Schedule, cal Calendar) Schedule {
+	return calendarSchedule{s, cal}
+}
+
+// WithCalendar skips the activations of the entry that fall on days that are
+// not business days in cal, e.g. to suppress a job on exchange holidays
+// without changing its spec. The entry's next activation is then the first one
+// on a business day.
+func WithCalendar(cal Calendar) EntryOption {
+	return func(e *Entry) {
+		e.calendar = cal
+	}
+}
+
+// Next returns the next activation time on a business day, later than the
+// given time, or the zero time if none is found within a year of days skipped.
+// As in WindowSchedule, an interval schedule starts over at the beginning of
+// the next business day.
+func (s calendarSchedule) Next(t time.Time) time.Time {
+	interval := isInterval(s.schedule)
+	next := s.schedule.Next(t)
+	for i := 0; i <= maxBusinessDaySearch; i++ {
+		if next.IsZero() || s.calendar.IsBusinessDay(next) {
+			return next
+		}
+		// Skip the rest of the day, rather than each activation on it.
+		y, m, d := next.Date()
+		day := time.Date(y, m, d+1, 0, 0, 0, 0, next.Location())
+		if interval {
+			next = day
+		} else {
+			next = s.schedule.Next(day.Add(-time.Nanosecond))
+		}
+	}
+	return time.Time{}
+}
+
+// first returns the first activation of the underlying schedule if it falls on
+// a business day, or the next activation on a business day otherwise.
+func (s calendarSchedule) first(now time.Time) time.Time {
+	if first := firstActivation(s.schedule, now); first.IsZero() || s.calendar.IsBusinessDay(first) {
+		return first
+	}
+	return s.Next(now)
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkipNonBusinessDays(t *testing.T) {
+	holidays, err := ParseDateListCalendar("2026-12-25", "2026-12-28")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily, _ := ParseStandard("TZ=UTC 0 9 * * *")
+	every, _ := ParseStandard("@every 1h")
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.December, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		cal      Calendar
+		from     time.Time
+		expected time.Time
+	}{
+		{"business day", daily, holidays, at(23, 10), at(24, 9)},
+		{"holiday", daily, holidays, at(24, 10), at(26, 9)},
+		{"consecutive holidays", daily, holidays, at(27, 10), at(29, 9)},
+		{"weekdays", daily, WeekdayCalendar{time.Monday, time.Tuesday}, at(22, 10), at(28, 9)},
+		{"interval", every, holidays, at(24, 23).Add(30 * time.Minute), at(26, 0)},
+		{"no business day", daily, WeekdayCalendar{}, at(1, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if next := SkipNonBusinessDays(tt.schedule, tt.cal).Next(tt.from); !next.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestParseDateListCalendar(t *testing.T) {
+	if _, err := ParseDateListCalendar("2026-12-25", "Dec 26"); err == nil {
+		t.Error("expected an error")
+	}
+	cal := NewDateListCalendar(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))
+	if cal.IsBusinessDay(time.Date(2026, 12, 25, 18, 0, 0, 0, time.UTC)) {
+		t.Error("expected the date to be excluded")
+	}
+	if !cal.IsBusinessDay(time.Date(2026, 12, 26, 18, 0, 0, 0, time.UTC)) {
+		t.Error("expected a Saturday to be a business day")
+	}
+}
+
+func TestWithCalendar(t *testing.T) {
+	cron := New(WithLocation(time.UTC))
+	today := time.Now().UTC()
+	cal := NewDateListCalendar(today, today.AddDate(0, 0, 1))
+	id, err := cron.AddFunc("0 0 * * *", func() {}, WithCalendar(cal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron.Start()
+	defer cron.Stop()
+	y, m, d := today.Date()
+	expected := time.Date(y, m, d+2, 0, 0, 0, 0, time.UTC)
+	if next := cron.Entry(id).Next; !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+}
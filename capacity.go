@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// CapacityReport compares the load each entry is expected to put on the
+// scheduler over a window of time with what it has actually done so far.
+type CapacityReport struct {
+	// Window is the period the report covers, starting at Start.
+	Window time.Duration
+	Start  time.Time
+	// Entries holds one line per entry, heaviest expected load first.
+	Entries []CapacityEntry
+	// ExpectedBusy is the sum of the entries' ExpectedBusy.
+	ExpectedBusy time.Duration
+}
+
+// CapacityEntry is the line of a CapacityReport for a single entry.
+type CapacityEntry struct {
+	ID          EntryID
+	Description string
+	// ExpectedRuns is the number of activations of the entry's schedule
+	// within the window. It is zero for paused entries.
+	ExpectedRuns int
+	// ObservedRuns is the number of runs per window the entry has actually
+	// completed, averaged since its statistics started.
+	ObservedRuns float64
+	// AverageDuration is the mean duration of the entry's completed runs.
+	AverageDuration time.Duration
+	// ExpectedBusy is the runtime the entry is expected to use within the
+	// window: ExpectedRuns times AverageDuration.
+	ExpectedBusy time.Duration
+}
+
+// maxCapacityRuns bounds the number of activations counted per entry, so that
+// a report over a long window remains cheap.
+const maxCapacityRuns = 1 << 20
+
+// CapacityReport returns, for each entry, the number of runs expected within
+// the given window from now according to its schedule, compared with the
+// runs and runtime recorded in its statistics. Teams can use it to find the
+// schedules that dominate load, e.g. before moving them to a worker pool.
+func (c *Cron) CapacityReport(window time.Duration) CapacityReport {
+	now := c.now()
+	stats := c.StatsSnapshot()
+	report := CapacityReport{Window: window, Start: now}
+	for _, e := range c.Entries() {
+		line := CapacityEntry{ID: e.ID, Description: e.Description}
+		if !e.Paused {
+			line.ExpectedRuns = countActivations(e.activeSchedule(), now, now.Add(window))
+		}
+		if s, ok := stats[e.ID]; ok && s.Runs > 0 {
+			line.AverageDuration = s.TotalDuration / time.Duration(s.Runs)
+			if elapsed := now.Sub(s.Since); elapsed > 0 {
+				line.ObservedRuns = float64(s.Runs) * float64(window) / float64(elapsed)
+			}
+		}
+		line.ExpectedBusy = time.Duration(line.ExpectedRuns) * line.AverageDuration
+		report.ExpectedBusy += line.ExpectedBusy
+		report.Entries = append(report.Entries, line)
+	}
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.ExpectedBusy != b.ExpectedBusy {
+			return a.ExpectedBusy > b.ExpectedBusy
+		}
+		return a.ExpectedRuns > b.ExpectedRuns
+	})
+	return report
+}
+
+// countActivations returns the number of activations of the schedule after
+// from and not after to, up to maxCapacityRuns.
+func countActivations(s Schedule, from, to time.Time) int {
+	n := 0
+	for t := s.Next(from); !t.IsZero() && !t.After(to) && n < maxCapacityRuns; t = s.Next(t) {
+		n++
+	}
+	return n
+}
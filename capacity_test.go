@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountActivations(t *testing.T) {
+	from := getTime("Mon Jul 9 00:00 2012")
+	hourly, _ := ParseStandard("@hourly")
+	never, _ := ParseStandard("0 0 30 2 *")
+	tests := []struct {
+		schedule Schedule
+		expected int
+	}{
+		{hourly, 24},
+		{Every(time.Minute), 1440},
+		{never, 0},
+	}
+	for _, test := range tests {
+		if n := countActivations(test.schedule, from, from.Add(24*time.Hour)); n != test.expected {
+			t.Errorf("%v: expected %d activations, got %d", test.schedule, test.expected, n)
+		}
+	}
+}
+
+func TestCapacityReport(t *testing.T) {
+	cron := New()
+	daily, _ := cron.AddFunc("@daily", func() {}, WithDescription("daily"))
+	minutely, _ := cron.AddFunc("@every 1m", func() {}, WithDescription("minutely"))
+	paused, _ := cron.AddFunc("@every 1m", func() {})
+	cron.PauseMany([]EntryID{paused})
+
+	since := cron.now().Add(-12 * time.Hour)
+	cron.stats.update(daily, func(s *EntryStats) {
+		s.Since = since
+		s.Runs = 1
+		s.TotalDuration = time.Hour
+	})
+	cron.stats.update(minutely, func(s *EntryStats) {
+		s.Since = since
+		s.Runs = 720
+		s.TotalDuration = 720 * time.Second
+	})
+
+	report := cron.CapacityReport(24 * time.Hour)
+	if len(report.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", report.Entries)
+	}
+	// The daily entry dominates: one hour-long run beats 1440 one-second runs.
+	d, m, p := report.Entries[0], report.Entries[1], report.Entries[2]
+	if d.ID != daily || d.ExpectedRuns != 1 || d.ExpectedBusy != time.Hour {
+		t.Errorf("unexpected daily line: %+v", d)
+	}
+	if m.ID != minutely || m.ExpectedRuns != 1440 || m.ExpectedBusy != 1440*time.Second {
+		t.Errorf("unexpected minutely line: %+v", m)
+	}
+	if m.ObservedRuns < 1439 || m.ObservedRuns > 1441 {
+		t.Errorf("expected about 1440 observed runs per day, got %v", m.ObservedRuns)
+	}
+	if p.ID != paused || p.ExpectedRuns != 0 {
+		t.Errorf("expected the paused entry last with no runs, got %+v", p)
+	}
+	if report.ExpectedBusy != time.Hour+1440*time.Second {
+		t.Errorf("unexpected total: %v", report.ExpectedBusy)
+	}
+}
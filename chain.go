@@ -1,6 +1,8 @@
 package cron
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -37,7 +39,7 @@ func (c Chain) Then(j Job) Job {
 // Recover panics in wrapped jobs and log them with the provided logger.
 func Recover(logger Logger) JobWrapper {
 	return func(j Job) Job {
-		return FuncJob(func() {
+		return ContextErrFuncJob(func(ctx context.Context) error {
 			defer func() {
 				if r := recover(); r != nil {
 					const size = 64 << 10
@@ -50,7 +52,7 @@ func Recover(logger Logger) JobWrapper {
 					logger.Error(err, "panic", "stack", "...\n"+string(buf))
 				}
 			}()
-			j.Run()
+			return RunJobE(ctx, j)
 		})
 	}
 }
@@ -61,14 +63,14 @@ func Recover(logger Logger) JobWrapper {
 func DelayIfStillRunning(logger Logger) JobWrapper {
 	return func(j Job) Job {
 		var mu sync.Mutex
-		return FuncJob(func() {
+		return ContextErrFuncJob(func(ctx context.Context) error {
 			start := time.Now()
 			mu.Lock()
 			defer mu.Unlock()
 			if dur := time.Since(start); dur > time.Minute {
 				logger.Info("delay", "duration", dur)
 			}
-			j.Run()
+			return RunJobE(ctx, j)
 		})
 	}
 }
@@ -79,14 +81,105 @@ func SkipIfStillRunning(logger Logger) JobWrapper {
 	return func(j Job) Job {
 		var ch = make(chan struct{}, 1)
 		ch <- struct{}{}
-		return FuncJob(func() {
+		return ContextErrFuncJob(func(ctx context.Context) error {
 			select {
 			case v := <-ch:
 				defer func() { ch <- v }()
-				j.Run()
+				return RunJobE(ctx, j)
 			default:
 				logger.Info("skip")
+				ReportSkip(ctx, SkipStillRunning, "")
 			}
+			return nil
+		})
+	}
+}
+
+// QueueIfStillRunning serializes jobs like DelayIfStillRunning, but holds at
+// most maxQueued invocations waiting for a previous one to complete. Further
+// invocations are dropped and logged to the given logger at Info level, as are
+// queued invocations whose context is cancelled, e.g. because the Cron was
+// stopped, before they could start. With maxQueued 0, it behaves like
+// SkipIfStillRunning; with a negative maxQueued, the queue is unbounded.
+func QueueIfStillRunning(maxQueued int, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		var (
+			admitted chan struct{}
+			running  = make(chan struct{}, 1)
+		)
+		if maxQueued >= 0 {
+			admitted = make(chan struct{}, maxQueued+1)
+		}
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			if admitted != nil {
+				select {
+				case admitted <- struct{}{}:
+				default:
+					logger.Info("skip", "reason", "queue full", "queued", maxQueued)
+					ReportSkip(ctx, SkipStillRunning, "queue full")
+					return nil
+				}
+				defer func() { <-admitted }()
+			}
+			start := time.Now()
+			select {
+			case running <- struct{}{}:
+			case <-ctx.Done():
+				logger.Info("skip", "reason", "cancelled while queued", "duration", time.Since(start))
+				ReportSkip(ctx, SkipStillRunning, "cancelled while queued")
+				return nil
+			}
+			defer func() { <-running }()
+			if dur := time.Since(start); dur > time.Minute {
+				logger.Info("delay", "duration", dur)
+			}
+			return RunJobE(ctx, j)
+		})
+	}
+}
+
+// SkipIfRunPastNext serializes runs like DelayIfStillRunning, but skips a run
+// that could only start once its entry's following activation is due, or
+// within window of it, because the previous run overran. This prevents
+// back-to-back runs after a long one. The time is read from the Cron's clock
+// (see WithNowFunc). Skips are logged to the given logger at Info level. Jobs
+// run outside of a Cron are never skipped.
+func SkipIfRunPastNext(window time.Duration, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		var mu sync.Mutex
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if next, ok := nextFrom(ctx); ok && !next.IsZero() {
+				if now := nowFrom(ctx); !now.Add(window).Before(next) {
+					logger.Info("skip", "reason", "next run imminent",
+						"scheduled", scheduledFrom(ctx), "next", next)
+					ReportSkip(ctx, SkipStillRunning, "next run imminent")
+					return nil
+				}
+			}
+			return RunJobE(ctx, j)
+		})
+	}
+}
+
+// ErrRunTimeout is logged when a run exceeds the timeout set with WithTimeout.
+var ErrRunTimeout = errors.New("cron: run exceeded its timeout")
+
+// WithTimeout runs the wrapped job with a context that expires after the given
+// duration, and logs ErrRunTimeout to the given logger once the run returns
+// if its context expired. Jobs that honor their context (see JobWithContext)
+// are thereby aborted; others run to completion.
+func WithTimeout(d time.Duration, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return ContextErrFuncJob(func(parent context.Context) error {
+			ctx, cancel := context.WithTimeout(parent, d)
+			defer cancel()
+			err := RunJobE(ctx, j)
+			if ctx.Err() == context.DeadlineExceeded && parent.Err() == nil {
+				logger.Error(ErrRunTimeout, "timeout", "timeout", d)
+			}
+			return err
 		})
 	}
 }
@@ -1,10 +1,13 @@
 package cron
 
 import (
+	"context"
 	"io/ioutil"
 	"log"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -240,3 +243,201 @@ func TestChainSkipIfStillRunning(t *testing.T) {
 	})
 
 }
+
+func TestChainWithTimeout(t *testing.T) {
+	var buf syncWriter
+	var err error
+	job := NewChain(WithTimeout(10*time.Millisecond, newBufLogger(&buf))).Then(
+		ContextFuncJob(func(ctx context.Context) {
+			<-ctx.Done()
+			err = ctx.Err()
+		}))
+	job.Run()
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected the job's context to expire, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "timeout") {
+		t.Errorf("expected the timeout to be logged, got %q", buf.String())
+	}
+
+	var quiet syncWriter
+	NewChain(WithTimeout(time.Second, newBufLogger(&quiet))).Then(FuncJob(func() {})).Run()
+	if quiet.String() != "" {
+		t.Errorf("expected nothing to be logged for a quick run, got %q", quiet.String())
+	}
+}
+
+func TestWithRunTimeout(t *testing.T) {
+	var buf syncWriter
+	errs := make(chan error, 1)
+	cron := New(WithParser(secondParser), WithChain(), WithLogger(newBufLogger(&buf)))
+	cron.AddJobCtx("* * * * * ?", ctxJob(errs), WithRunTimeout(50*time.Millisecond))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case err := <-errs:
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected the run to time out, got %v", err)
+		}
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the run to be aborted")
+	}
+}
+
+// fixedSchedule is a Schedule whose next activation is always the same.
+type fixedSchedule struct{ next time.Time }
+
+func (s fixedSchedule) Next(time.Time) time.Time { return s.next }
+
+func TestQueueIfStillRunning(t *testing.T) {
+	t.Run("bounded queue", func(t *testing.T) {
+		var (
+			buf     syncWriter
+			runs    int32
+			started = make(chan struct{}, 5)
+			release = make(chan struct{})
+			wg      sync.WaitGroup
+		)
+		wrappedJob := NewChain(QueueIfStillRunning(1, VerbosePrintfLogger(log.New(&buf, "", 0)))).
+			Then(FuncJob(func() {
+				atomic.AddInt32(&runs, 1)
+				started <- struct{}{}
+				<-release
+			}))
+		wg.Add(4)
+		go func() { defer wg.Done(); wrappedJob.Run() }()
+		<-started
+		for i := 0; i < 3; i++ {
+			go func() { defer wg.Done(); wrappedJob.Run() }()
+		}
+
+		// One of the three is queued behind the first run, the others dropped.
+		deadline := time.Now().Add(time.Second)
+		for strings.Count(buf.String(), "queue full") < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if n := strings.Count(buf.String(), "queue full"); n != 2 {
+			t.Fatalf("expected 2 runs dropped, got %d: %q", n, buf.String())
+		}
+		close(release)
+		wg.Wait()
+		if n := atomic.LoadInt32(&runs); n != 2 {
+			t.Errorf("expected 2 runs, got %d", n)
+		}
+	})
+
+	t.Run("queued run cancelled", func(t *testing.T) {
+		var (
+			runs    int32
+			started = make(chan struct{})
+			release = make(chan struct{})
+		)
+		wrappedJob := NewChain(QueueIfStillRunning(1, DiscardLogger)).Then(FuncJob(func() {
+			atomic.AddInt32(&runs, 1)
+			close(started)
+			<-release
+		}))
+		go wrappedJob.Run()
+		<-started
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		RunJob(ctx, wrappedJob)
+		close(release)
+		if n := atomic.LoadInt32(&runs); n != 1 {
+			t.Errorf("expected the queued run to be dropped, got %d runs", n)
+		}
+	})
+
+	t.Run("unbounded queue", func(t *testing.T) {
+		for _, maxQueued := range []int{-1, -5} {
+			var (
+				buf     syncWriter
+				runs    int32
+				started = make(chan struct{}, 5)
+				release = make(chan struct{})
+				wg      sync.WaitGroup
+			)
+			wrappedJob := NewChain(QueueIfStillRunning(maxQueued, VerbosePrintfLogger(log.New(&buf, "", 0)))).
+				Then(FuncJob(func() {
+					atomic.AddInt32(&runs, 1)
+					started <- struct{}{}
+					<-release
+				}))
+			wg.Add(4)
+			go func() { defer wg.Done(); wrappedJob.Run() }()
+			<-started
+			for i := 0; i < 3; i++ {
+				go func() { defer wg.Done(); wrappedJob.Run() }()
+			}
+			close(release)
+			wg.Wait()
+			if n := atomic.LoadInt32(&runs); n != 4 || strings.Contains(buf.String(), "queue full") {
+				t.Errorf("maxQueued %d: expected all 4 runs, got %d: %q", maxQueued, n, buf.String())
+			}
+		}
+	})
+}
+
+func TestSkipIfRunPastNext(t *testing.T) {
+	var buf syncWriter
+	runCtx := func(next time.Time) context.Context {
+		ctx := context.WithValue(context.Background(), scheduledKey{}, time.Now().Add(-time.Minute))
+		return context.WithValue(ctx, scheduleKey{}, fixedSchedule{next})
+	}
+	var runs int
+	job := NewChain(SkipIfRunPastNext(time.Second, VerbosePrintfLogger(log.New(&buf, "", 0)))).Then(FuncJob(func() { runs++ }))
+
+	RunJob(runCtx(time.Now().Add(time.Minute)), job)
+	if runs != 1 {
+		t.Errorf("expected a run with the next activation far off, got %d runs", runs)
+	}
+	RunJob(runCtx(time.Now().Add(500*time.Millisecond)), job)
+	if runs != 1 {
+		t.Errorf("expected a skip with the next activation imminent, got %d runs", runs)
+	}
+	if !strings.Contains(buf.String(), "next run imminent") {
+		t.Errorf("expected the skip to be logged, got %q", buf.String())
+	}
+	job.Run()
+	if runs != 2 {
+		t.Errorf("expected a run outside of a Cron, got %d runs", runs)
+	}
+
+	// A Cron provides the entry's schedule.
+	cron := New(WithChain(SkipIfRunPastNext(time.Second, DiscardLogger)))
+	id, _ := cron.AddFunc("@hourly", func() { runs++ })
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+	if runs != 3 {
+		t.Errorf("expected the entry to run, got %d runs", runs)
+	}
+	cron.startJob(cron.store.Get(id), time.Now().Add(-time.Hour+time.Millisecond))
+	cron.jobWaiter.Wait()
+	if runs != 3 {
+		t.Errorf("expected a late run to be skipped, got %d runs", runs)
+	}
+}
+
+func TestSkipIfRunPastNextUsesCronClock(t *testing.T) {
+	var (
+		runs int
+		now  = time.Date(2012, time.July, 9, 12, 0, 0, 0, time.UTC)
+	)
+	cron := New(WithChain(SkipIfRunPastNext(time.Second, DiscardLogger)),
+		WithNowFunc(func() time.Time { return now }))
+	id, _ := cron.AddFunc("@hourly", func() { runs++ })
+
+	// On the wall clock, the following activation is long past.
+	cron.startJob(cron.store.Get(id), now)
+	cron.jobWaiter.Wait()
+	if runs != 1 {
+		t.Errorf("expected the run to start on the cron clock, got %d runs", runs)
+	}
+	now = now.Add(time.Hour - time.Millisecond)
+	cron.startJob(cron.store.Get(id), now.Add(-time.Hour+time.Millisecond))
+	cron.jobWaiter.Wait()
+	if runs != 1 {
+		t.Errorf("expected a late run on the cron clock to be skipped, got %d runs", runs)
+	}
+}
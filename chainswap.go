@@ -0,0 +1,43 @@
+package cron
+
+// chainRequest asks the scheduler to replace the Cron's Chain, and to close
+// done once it has.
+type chainRequest struct {
+	chain Chain
+	done  chan struct{}
+}
+
+// SetChain replaces the Chain that wraps the jobs of all entries, including
+// those already added, e.g. to install a global rate limiter or remove
+// SkipIfStillRunning while the Cron is running. Each entry's own wrappers
+// (see WithWrappers) are kept, along with their state.
+//
+// The replacement takes effect between runs: runs already started complete
+// under the old Chain, and every later run goes through the new one. The
+// wrappers of the new Chain start afresh, so e.g. a new SkipIfStillRunning
+// does not see runs started under the old Chain.
+func (c *Cron) SetChain(chain Chain) {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		req := chainRequest{chain: chain, done: make(chan struct{})}
+		c.chains <- req
+		<-req.done
+		return
+	}
+	c.applyChain(chain)
+}
+
+// applyChain re-wraps the job of every entry in the store with the chain.
+func (c *Cron) applyChain(chain Chain) {
+	c.chain = chain
+	entries := c.store.Entries()
+	for _, e := range entries {
+		if e.inner == nil {
+			e.inner = c.observe(e.ID, e.Job)
+		}
+		e.WrappedJob = chain.Then(e.inner)
+		c.store.Update(e)
+	}
+	c.logger.Info("chain replaced", "entries", len(entries))
+}
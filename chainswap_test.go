@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSetChain(t *testing.T) {
+	for _, running := range []bool{false, true} {
+		var (
+			mu    sync.Mutex
+			calls []string
+		)
+		record := func(name string) JobWrapper {
+			return func(j Job) Job {
+				return FuncJob(func() {
+					mu.Lock()
+					calls = append(calls, name)
+					mu.Unlock()
+					j.Run()
+				})
+			}
+		}
+		cron := New(WithLogger(DiscardLogger), WithChain(record("old")))
+		id, _ := cron.AddFunc("@yearly", func() {}, WithWrappers(record("entry")))
+		if running {
+			cron.Start()
+		}
+
+		cron.SetChain(NewChain(record("new")))
+		if err := cron.RunNow(id); err != nil {
+			t.Fatal(err)
+		}
+		cron.jobWaiter.Wait()
+		if running {
+			cron.Stop()
+		}
+
+		mu.Lock()
+		if expected := []string{"new", "entry"}; !reflect.DeepEqual(calls, expected) {
+			t.Errorf("running=%v: expected %v, got %v", running, expected, calls)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestSetChainAppliesToNewEntries(t *testing.T) {
+	var runs []string
+	cron := New(WithLogger(DiscardLogger))
+	cron.SetChain(NewChain(func(j Job) Job {
+		return FuncJob(func() {
+			runs = append(runs, "chain")
+			j.Run()
+		})
+	}))
+	id, _ := cron.AddFunc("@yearly", func() { runs = append(runs, "job") })
+	if err := cron.RunNow(id); err != nil {
+		t.Fatal(err)
+	}
+	cron.jobWaiter.Wait()
+	if expected := []string{"chain", "job"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("expected %v, got %v", expected, runs)
+	}
+}
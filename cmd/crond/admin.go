@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// entryView is the JSON representation of an entry in the admin API.
+type entryView struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Spec    string    `json:"spec"`
+	Command string    `json:"command"`
+	Next    time.Time `json:"next"`
+	Prev    time.Time `json:"prev"`
+	Paused  bool      `json:"paused"`
+}
+
+// handler returns the HTTP admin API of the daemon:
+//
+//	GET  /entries  lists the scheduled entries as JSON
+//	POST /reload   reloads the crontab
+//	POST /trigger  runs the entries given by id parameters now
+//	POST /pause    pauses the entries given by id parameters
+//	POST /resume   resumes the entries given by id parameters
+//	GET  /metrics  serves metrics in the Prometheus text format
+//	GET  /healthz  reports that the daemon is up
+func (d *daemon) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/entries", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		views := []entryView{}
+		for _, e := range d.cron.Entries() {
+			job, ok := e.Job.(*execJob)
+			if !ok {
+				continue
+			}
+			views = append(views, entryView{
+				ID:      int(e.ID),
+				Name:    job.name,
+				Spec:    job.spec,
+				Command: job.command,
+				Next:    e.Next,
+				Prev:    e.Prev,
+				Paused:  e.Paused,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+	})
+	mux.HandleFunc("/reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := d.load(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	batch := func(op func([]cron.EntryID) []cron.EntryID) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ids []cron.EntryID
+			for _, v := range r.Form["id"] {
+				id, err := strconv.Atoi(v)
+				if err != nil {
+					http.Error(w, "invalid id "+strconv.Quote(v), http.StatusBadRequest)
+					return
+				}
+				ids = append(ids, cron.EntryID(id))
+			}
+			applied := []cron.EntryID{}
+			applied = append(applied, op(ids)...)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(applied)
+		}
+	}
+	mux.HandleFunc("/trigger", batch(func(ids []cron.EntryID) []cron.EntryID {
+		return d.cron.TriggerMany(ids)
+	}))
+	mux.HandleFunc("/pause", batch(d.cron.PauseMany))
+	mux.HandleFunc("/resume", batch(d.cron.ResumeMany))
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		d.metrics.write(w, len(d.cron.Entries()))
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	return mux
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// crontabEntry is a single job definition read from a crontab file.
+type crontabEntry struct {
+	Line    int
+	Spec    string
+	Command string
+}
+
+// name identifies the entry in logs and metrics.
+func (e crontabEntry) name() string {
+	return fmt.Sprintf("line%d", e.Line)
+}
+
+// jobName identifies the entry across reloads and restarts, by its schedule
+// and command, wherever its line is in the crontab. See cron.WithJobName.
+func (e crontabEntry) jobName() string {
+	sum := sha256.Sum256([]byte(e.Spec + "\n" + e.Command))
+	return "crontab-" + hex.EncodeToString(sum[:8])
+}
+
+// crontab is the parsed content of a crontab file.
+type crontab struct {
+	Env     []string
+	Entries []crontabEntry
+}
+
+// parseCrontab reads a crontab in the traditional format:
+//
+//	# Comments and blank lines are ignored.
+//	MAILTO=ops@example.com
+//	*/5 * * * * /usr/local/bin/poll
+//	CRON_TZ=Asia/Tokyo 30 4 * * * /usr/local/bin/report
+//	@daily /usr/local/bin/cleanup
+//
+// Variable assignments are passed to the environment of every command. The
+// schedule of each job takes the given number of fields, unless it is a
+// descriptor such as @daily or @every 5m.
+func parseCrontab(r io.Reader, fields int) (*crontab, error) {
+	var (
+		tab     crontab
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if isAssignment(text) {
+			tab.Env = append(tab.Env, text)
+			continue
+		}
+
+		words := strings.Fields(text)
+		var prefix []string
+		if strings.HasPrefix(words[0], "TZ=") || strings.HasPrefix(words[0], "CRON_TZ=") {
+			prefix, words = words[:1], words[1:]
+		}
+		n := fields
+		if len(words) > 0 && strings.HasPrefix(words[0], "@") {
+			n = 1
+			if words[0] == "@every" {
+				n = 2
+			}
+		}
+		if len(words) <= n {
+			return nil, fmt.Errorf("line %d: expected a schedule followed by a command: %s", line, text)
+		}
+		tab.Entries = append(tab.Entries, crontabEntry{
+			Line:    line,
+			Spec:    strings.Join(append(prefix, words[:n]...), " "),
+			Command: strings.Join(words[n:], " "),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &tab, nil
+}
+
+// isAssignment returns true if the line is an environment variable
+// assignment, e.g. "PATH=/usr/bin".
+func isAssignment(text string) bool {
+	eq := strings.Index(text, "=")
+	if eq <= 0 {
+		return false
+	}
+	name := text[:eq]
+	if name == "TZ" || name == "CRON_TZ" {
+		// A time zone prefix is followed by a schedule.
+		return !strings.ContainsAny(text, " \t")
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	const input = `
+# Nightly maintenance
+MAILTO=ops@example.com
+PATH=/usr/local/bin:/usr/bin
+
+*/5 * * * * poll --verbose
+CRON_TZ=Asia/Tokyo 30 4 * * * report > /dev/null 2>&1
+@daily cleanup
+@every 1h30m rotate logs
+`
+	tab, err := parseCrontab(strings.NewReader(input), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedEnv := []string{"MAILTO=ops@example.com", "PATH=/usr/local/bin:/usr/bin"}
+	if !reflect.DeepEqual(tab.Env, expectedEnv) {
+		t.Errorf("expected env %v, got %v", expectedEnv, tab.Env)
+	}
+	expected := []crontabEntry{
+		{6, "*/5 * * * *", "poll --verbose"},
+		{7, "CRON_TZ=Asia/Tokyo 30 4 * * *", "report > /dev/null 2>&1"},
+		{8, "@daily", "cleanup"},
+		{9, "@every 1h30m", "rotate logs"},
+	}
+	if !reflect.DeepEqual(tab.Entries, expected) {
+		t.Errorf("expected entries %v, got %v", expected, tab.Entries)
+	}
+}
+
+func TestParseCrontabSeconds(t *testing.T) {
+	tab, err := parseCrontab(strings.NewReader("0 */5 * * * * poll"), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := tab.Entries[0]; e.Spec != "0 */5 * * * *" || e.Command != "poll" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	for _, input := range []string{
+		"* * * * *",
+		"@daily",
+		"@every 5m",
+		"CRON_TZ=UTC * * * * *",
+	} {
+		if _, err := parseCrontab(strings.NewReader(input), 5); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
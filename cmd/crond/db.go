@@ -0,0 +1,43 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package main
+
+import (
+	"database/sql"
+
+	"github.com/robfig/cron/v3"
+)
+
+// openStore opens the SQLStore persisting the entries of the daemon in the
+// given table. The jobs of saved entries cannot be stored, so they are
+// restored with placeholders, which load replaces with the commands of the
+// crontab.
+func openStore(driver, dsn, table string, parser cron.ScheduleParser, logger cron.Logger) (cron.Store, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query("SELECT DISTINCT job_name FROM " + table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	jobs := make(map[string]cron.Job)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		jobs[name] = cron.FuncJob(func() {})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	store, err := cron.NewSQLStore(db, table, parser, jobs)
+	if err != nil {
+		return nil, err
+	}
+	store.Logger = logger
+	return store, nil
+}
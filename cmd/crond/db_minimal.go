@@ -0,0 +1,16 @@
+//go:build cronminimal
+// +build cronminimal
+
+package main
+
+import (
+	"errors"
+
+	"github.com/robfig/cron/v3"
+)
+
+// openStore reports that -db is not supported: SQLStore is left out of builds
+// with the cronminimal tag.
+func openStore(driver, dsn, table string, parser cron.ScheduleParser, logger cron.Logger) (cron.Store, error) {
+	return nil, errors.New("crond: -db is not supported in builds with the cronminimal tag")
+}
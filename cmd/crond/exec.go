@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// execJob runs a shell command, logging its output and recording the outcome
+// of each run in the daemon's metrics.
+type execJob struct {
+	name    string
+	spec    string
+	command string
+	env     []string
+	logger  cron.Logger
+	metrics *metrics
+}
+
+func (j *execJob) Run() {
+	var out bytes.Buffer
+	cmd := exec.Command("/bin/sh", "-c", j.command)
+	cmd.Env = append(os.Environ(), j.env...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+	j.metrics.observe(j.name, duration, err)
+
+	if err != nil {
+		j.logger.Error(err, "command failed", "job", j.name, "duration", duration, "output", out.String())
+		return
+	}
+	j.logger.Info("command completed", "job", j.name, "duration", duration, "output", out.String())
+}
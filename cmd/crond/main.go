@@ -0,0 +1,197 @@
+// Command crond is a cron daemon built on the cron package.
+//
+// It runs the jobs of a crontab file, serves an HTTP admin API with metrics
+// in the Prometheus text format, and may replace the system cron for simple
+// use cases, e.g. in containers:
+//
+//	crond -crontab /etc/crontab -addr :8080
+//
+// The crontab is reloaded on SIGHUP or on POST /reload. On SIGINT or SIGTERM
+// the daemon stops scheduling jobs and waits for running ones to complete.
+//
+// With -db, the entries are persisted to a SQL database (see cron.SQLStore for
+// the table it needs), so that when the daemon restarts, the jobs whose line
+// is unchanged resume from their last run, and runs missed while it was down
+// are caught up once. The database/sql driver named by -db-driver must be
+// linked into the binary, e.g. with a blank import added to this package:
+//
+//	crond -crontab /etc/crontab -db-driver postgres -db "dbname=crond sslmode=disable"
+//
+// Builds with the cronminimal tag, which leave out cron.SQLStore, do not
+// support -db.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/robfig/cron/v3"
+)
+
+func main() {
+	var (
+		path    = flag.String("crontab", "/etc/crontab", "path of the crontab file to run")
+		addr    = flag.String("addr", ":8080", "address of the HTTP admin API, or empty to disable it")
+		seconds = flag.Bool("seconds", false, "expect a leading seconds field in schedules")
+		verbose = flag.Bool("v", false, "log scheduling decisions and job output")
+		driver  = flag.String("db-driver", "", "database/sql driver of the database given by -db")
+		dsn     = flag.String("db", "", "data source name of a database persisting the entries across restarts, or empty to keep them in memory")
+		table   = flag.String("db-table", "cron_entries", "table of the entries in the database given by -db")
+	)
+	flag.Parse()
+
+	stdlog := log.New(os.Stdout, "crond: ", log.LstdFlags)
+	logger := cron.PrintfLogger(stdlog)
+	if *verbose {
+		logger = cron.VerbosePrintfLogger(stdlog)
+	}
+
+	var store cron.Store
+	if *dsn != "" {
+		var err error
+		if store, err = openStore(*driver, *dsn, *table, specParser(*seconds), logger); err != nil {
+			stdlog.Fatal(err)
+		}
+	}
+	d := newDaemon(*path, *seconds, logger, store)
+	if err := d.load(); err != nil {
+		stdlog.Fatal(err)
+	}
+	d.cron.Start()
+
+	var server *http.Server
+	if *addr != "" {
+		server = &http.Server{Addr: *addr, Handler: d.handler()}
+		go func() {
+			if err := server.ListenAndServe(); err != http.ErrServerClosed {
+				stdlog.Fatal(err)
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	for sig := range signals {
+		if sig == syscall.SIGHUP {
+			if err := d.load(); err != nil {
+				logger.Error(err, "reload")
+			}
+			continue
+		}
+		break
+	}
+
+	if server != nil {
+		server.Shutdown(context.Background())
+	}
+	<-d.cron.Stop().Done()
+}
+
+// daemon runs the jobs of a crontab file.
+type daemon struct {
+	path    string
+	fields  int
+	parser  cron.ScheduleParser
+	logger  cron.Logger
+	metrics *metrics
+	cron    *cron.Cron
+
+	mu sync.Mutex
+}
+
+// newDaemon returns a daemon running the jobs of the crontab at path. If store
+// is not nil, it holds the entries, and those it already holds are resumed
+// from their last run.
+func newDaemon(path string, seconds bool, logger cron.Logger, store cron.Store) *daemon {
+	parser := specParser(seconds)
+	opts := []cron.Option{
+		cron.WithLogger(logger),
+		cron.WithChain(cron.Recover(logger)),
+		cron.WithParser(parser),
+	}
+	if store != nil {
+		opts = append(opts, cron.WithStore(store), cron.WithWarmStart())
+	}
+	fields := 5
+	if seconds {
+		fields = 6
+	}
+	return &daemon{
+		path:    path,
+		fields:  fields,
+		parser:  parser,
+		logger:  logger,
+		metrics: newMetrics(),
+		cron:    cron.New(opts...),
+	}
+}
+
+// specParser returns the parser of the schedules of the crontab.
+func specParser(seconds bool) cron.ScheduleParser {
+	if seconds {
+		return cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	}
+	return cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+}
+
+// load (re)reads the crontab. The entries of lines that are still in it, with
+// the same schedule and command, are kept with their state and given the new
+// environment; the others are replaced. The current entries are kept if the
+// crontab cannot be read.
+func (d *daemon) load() error {
+	f, err := os.Open(d.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	tab, err := parseCrontab(f, d.fields)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range tab.Entries {
+		if _, err := d.parser.Parse(e.Spec); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	previous := make(map[string][]cron.EntryID)
+	for _, e := range d.cron.Entries() {
+		previous[e.JobName] = append(previous[e.JobName], e.ID)
+	}
+	for _, e := range tab.Entries {
+		job := &execJob{
+			name:    e.name(),
+			spec:    e.Spec,
+			command: e.Command,
+			env:     tab.Env,
+			logger:  d.logger,
+			metrics: d.metrics,
+		}
+		if ids := previous[e.jobName()]; len(ids) > 0 {
+			previous[e.jobName()] = ids[1:]
+			if err := d.cron.UpdateJob(ids[0], job); err != nil {
+				d.logger.Error(err, "load", "job", e.name())
+			}
+			continue
+		}
+		if _, err := d.cron.AddJob(e.Spec, job, cron.WithJobName(e.jobName())); err != nil {
+			d.logger.Error(err, "load", "job", e.name())
+		}
+	}
+	for _, ids := range previous {
+		for _, id := range ids {
+			d.cron.Remove(id)
+		}
+	}
+	d.logger.Info("loaded", "path", d.path, "entries", len(tab.Entries))
+	return nil
+}
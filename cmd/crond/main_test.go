@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestLoadKeepsUnchangedEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crond")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "crontab")
+	write := func(content string) {
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The store holds the entries saved by a previous run of the daemon.
+	var (
+		report = crontabEntry{Spec: "@daily", Command: "report"}
+		prev   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		store  = cron.NewInMemoryStore()
+		noop   = cron.FuncJob(func() {})
+	)
+	store.Add(&cron.Entry{ID: 1, Spec: report.Spec, JobName: report.jobName(),
+		Schedule: cron.Every(24 * time.Hour), Job: noop, Prev: prev})
+	store.Add(&cron.Entry{ID: 2, Spec: "@hourly", JobName: "crontab-removed",
+		Schedule: cron.Every(time.Hour), Job: noop})
+
+	write("# reports\n@daily report\n@hourly cleanup\n")
+	d := newDaemon(path, false, cron.DiscardLogger, store)
+	if err := d.load(); err != nil {
+		t.Fatal(err)
+	}
+	entries := d.cron.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+	kept := d.cron.Entry(1)
+	if job, ok := kept.Job.(*execJob); !ok || job.command != "report" || !kept.Prev.Equal(prev) {
+		t.Errorf("expected the saved entry to be kept with its command, got %+v", kept)
+	}
+	if d.cron.Entry(2).Valid() {
+		t.Error("expected the entry no longer in the crontab to be removed")
+	}
+
+	// A reload keeps the entries whose line moved, and replaces the others.
+	write("@hourly cleanup --all\n@daily report\n")
+	if err := d.load(); err != nil {
+		t.Fatal(err)
+	}
+	entries = d.cron.Entries()
+	if len(entries) != 2 || !d.cron.Entry(1).Valid() {
+		t.Fatalf("expected the report entry to be kept, got %v", entries)
+	}
+	if job := d.cron.Entry(1).Job.(*execJob); job.name != "line2" {
+		t.Errorf("expected the kept entry to be named after its new line, got %q", job.name)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// metrics accumulates per-job counters and renders them in the Prometheus
+// text exposition format.
+type metrics struct {
+	mu   sync.Mutex
+	jobs map[string]*jobMetrics
+}
+
+type jobMetrics struct {
+	runs     uint64
+	failures uint64
+	seconds  float64
+	last     time.Time
+}
+
+func newMetrics() *metrics {
+	return &metrics{jobs: make(map[string]*jobMetrics)}
+}
+
+// observe records a completed run of the named job.
+func (m *metrics) observe(name string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jm, ok := m.jobs[name]
+	if !ok {
+		jm = &jobMetrics{}
+		m.jobs[name] = jm
+	}
+	jm.runs++
+	if err != nil {
+		jm.failures++
+	}
+	jm.seconds += duration.Seconds()
+	jm.last = time.Now()
+}
+
+// write renders the metrics, along with the given number of entries.
+func (m *metrics) write(w io.Writer, entries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.jobs))
+	for name := range m.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "# HELP crond_entries Number of scheduled entries.")
+	fmt.Fprintln(w, "# TYPE crond_entries gauge")
+	fmt.Fprintf(w, "crond_entries %d\n", entries)
+
+	series := []struct {
+		name, help, kind string
+		value            func(*jobMetrics) string
+	}{
+		{"crond_job_runs_total", "Number of completed runs.", "counter",
+			func(jm *jobMetrics) string { return fmt.Sprint(jm.runs) }},
+		{"crond_job_failures_total", "Number of runs that exited with an error.", "counter",
+			func(jm *jobMetrics) string { return fmt.Sprint(jm.failures) }},
+		{"crond_job_duration_seconds_total", "Cumulative run time.", "counter",
+			func(jm *jobMetrics) string { return fmt.Sprint(jm.seconds) }},
+		{"crond_job_last_run_timestamp_seconds", "Completion time of the latest run.", "gauge",
+			func(jm *jobMetrics) string { return fmt.Sprint(jm.last.Unix()) }},
+	}
+	for _, s := range series {
+		fmt.Fprintf(w, "# HELP %s %s\n", s.name, s.help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", s.name, s.kind)
+		for _, name := range names {
+			fmt.Fprintf(w, "%s{job=%q} %s\n", s.name, name, s.value(m.jobs[name]))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Codec encodes the values persisted by this package: the entries saved by
+// RedisStore and SQLStore and the state written with MarshalStateCodec, such
+// as that of FileOnceStore and FileJournal. JSON is used by default; GobCodec is more
+// compact. Other formats, such as Protocol Buffers or MessagePack, may be
+// plugged in by implementing Codec and registering it with RegisterCodec, so
+// that the cron package does not depend on their libraries.
+type Codec interface {
+	// Name identifies the codec in the data it encodes, e.g. "gob".
+	Name() string
+	// Marshal encodes v.
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes data into v, which is a pointer.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// The codecs provided by this package, registered by default.
+var (
+	JSONCodec Codec = jsonCodec{}
+	GobCodec  Codec = gobCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                               { return "json" }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type gobCodec struct{}
+
+func (gobCodec) Name() string { return "gob" }
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"json": JSONCodec,
+		"gob":  GobCodec,
+	}
+)
+
+// RegisterCodec makes a Codec available to decode the data it encoded, which
+// records its name. It is meant to be called from init functions, in every
+// process reading the data. If RegisterCodec is called twice with the same
+// name or if codec is nil, it panics.
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if codec == nil {
+		panic("cron: RegisterCodec codec is nil")
+	}
+	if _, dup := codecs[codec.Name()]; dup {
+		panic("cron: RegisterCodec called twice for codec " + codec.Name())
+	}
+	codecs[codec.Name()] = codec
+}
+
+// codecMarker starts data encoded with a codec other than JSON, followed by
+// the name of the codec and another codecMarker. JSON never starts with it, so
+// data encoded with JSON is left as is and remains readable by older versions.
+const codecMarker = 0
+
+// encodeWith encodes v with the codec, JSON if nil, marking the data with the
+// name of the codec.
+func encodeWith(codec Codec, v interface{}) ([]byte, error) {
+	if codec == nil || codec.Name() == JSONCodec.Name() {
+		return json.Marshal(v)
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	marked := make([]byte, 0, len(codec.Name())+2+len(data))
+	marked = append(marked, codecMarker)
+	marked = append(marked, codec.Name()...)
+	marked = append(marked, codecMarker)
+	return append(marked, data...), nil
+}
+
+// codecOf returns the codec that encoded the data with encodeWith, and the
+// data without its marking.
+func codecOf(data []byte) (Codec, []byte, error) {
+	if len(data) == 0 || data[0] != codecMarker {
+		return JSONCodec, data, nil
+	}
+	end := bytes.IndexByte(data[1:], codecMarker)
+	if end < 0 {
+		return nil, nil, fmt.Errorf("cron: malformed codec marking")
+	}
+	name := string(data[1 : end+1])
+	codecsMu.RLock()
+	codec, ok := codecs[name]
+	codecsMu.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("cron: unknown codec %q", name)
+	}
+	return codec, data[end+2:], nil
+}
+
+// decode decodes data written by encodeWith into v, with the codec it was
+// encoded with.
+func decode(data []byte, v interface{}) error {
+	codec, data, err := codecOf(data)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(data, v)
+}
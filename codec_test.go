@@ -0,0 +1,134 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// customCodec is a codec wrapping JSON, to check that registered codecs
+// are selected by name.
+type customCodec struct{}
+
+func (customCodec) Name() string                          { return "test-custom" }
+func (customCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (customCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func init() {
+	RegisterCodec(customCodec{})
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	type value struct {
+		Name string
+		At   time.Time
+	}
+	in := value{"report", time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)}
+	for _, codec := range []Codec{JSONCodec, GobCodec, customCodec{}} {
+		data, err := encodeWith(codec, in)
+		if err != nil {
+			t.Fatalf("%s: %v", codec.Name(), err)
+		}
+		if codec == JSONCodec && data[0] != '{' {
+			t.Errorf("expected JSON to be left unmarked, got %q", data)
+		}
+		var out value
+		if err := decode(data, &out); err != nil || out.Name != in.Name || !out.At.Equal(in.At) {
+			t.Errorf("%s: unexpected result %+v, %v", codec.Name(), out, err)
+		}
+	}
+
+	if err := decode([]byte("\x00missing\x00data"), new(value)); err == nil ||
+		!strings.Contains(err.Error(), "unknown codec") {
+		t.Errorf("expected an unknown codec error, got %v", err)
+	}
+}
+
+func TestRegisterCodecTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	RegisterCodec(GobCodec)
+}
+
+func TestStateCodec(t *testing.T) {
+	data, err := MarshalStateCodec(GobCodec, "test", map[string]int{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("\x00gob\x00")) {
+		t.Errorf("expected the codec to be recorded, got %q", data)
+	}
+	var v map[string]int
+	if err := UnmarshalState("test", data, &v); err != nil || v["a"] != 1 {
+		t.Errorf("unexpected result %v, %v", v, err)
+	}
+	if err := UnmarshalState("other", data, &v); err == nil {
+		t.Error("expected an error for state of another kind")
+	}
+}
+
+func TestFileJournalCodec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal")
+
+	journal, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal.Codec = GobCodec
+	now := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	if err := journal.Started("report", now, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file is read back whatever the codec of the reader.
+	reopened, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs, _ := reopened.Unfinished()
+	if len(runs) != 1 || runs[0].Key != "report" || !runs[0].Started.Equal(now) {
+		t.Errorf("unexpected runs %+v", runs)
+	}
+}
+
+func TestRedisStoreCodec(t *testing.T) {
+	var (
+		client = newFakeRedis()
+		jobs   = map[string]Job{"report": FuncJob(func() {})}
+		next   = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	)
+	store, err := NewRedisStore(client, "cron", nil, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Codec = GobCodec
+	store.Add(&Entry{ID: 1, Spec: "@hourly", JobName: "report", Schedule: Every(time.Hour),
+		Job: jobs["report"], Next: next, Payload: []byte("secret")})
+	if value := client.hashes["cron:entries"]["1"]; !strings.HasPrefix(value, "\x00gob\x00") {
+		t.Errorf("expected a gob-encoded entry, got %q", value)
+	}
+
+	other, err := NewRedisStore(client, "cron", nil, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := other.Get(1)
+	if e == nil || e.Spec != "@hourly" || !e.Next.Equal(next) || string(e.Payload) != "secret" {
+		t.Errorf("expected the saved entry to be loaded, got %+v", e)
+	}
+}
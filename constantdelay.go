@@ -1,6 +1,10 @@
 package cron
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+)
 
 // ConstantDelaySchedule represents a simple recurring duty cycle, e.g. "Every 5 minutes".
 // It does not support jobs more frequent than once a second.
@@ -25,3 +29,105 @@ func Every(duration time.Duration) ConstantDelaySchedule {
 func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
 	return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 }
+
+// Spec returns the "@every" descriptor of the schedule.
+func (schedule ConstantDelaySchedule) Spec() string {
+	return "@every " + schedule.Delay.String()
+}
+
+// ImmediateDelaySchedule is a ConstantDelaySchedule whose first activation
+// happens as soon as it is added to a running Cron (or the Cron is started),
+// rather than after the first Delay has elapsed. See EveryImmediate.
+type ImmediateDelaySchedule struct {
+	ConstantDelaySchedule
+}
+
+// EveryImmediate returns a crontab Schedule that activates immediately and
+// then once every duration. The duration is rounded as in Every.
+func EveryImmediate(duration time.Duration) ImmediateDelaySchedule {
+	return ImmediateDelaySchedule{Every(duration)}
+}
+
+// Spec returns the "@every" descriptor of the schedule, marked "immediately".
+func (schedule ImmediateDelaySchedule) Spec() string {
+	return schedule.ConstantDelaySchedule.Spec() + " " + immediately
+}
+
+// first returns now: the schedule activates as soon as it is scheduled.
+func (schedule ImmediateDelaySchedule) first(now time.Time) time.Time {
+	return now
+}
+
+// AnchoredDelaySchedule activates at Anchor plus every multiple of Delay,
+// however long jobs take and whenever the entry was added. See EveryFrom.
+type AnchoredDelaySchedule struct {
+	Delay  time.Duration
+	Anchor time.Time
+}
+
+// EveryFrom returns a crontab Schedule that activates at anchor plus every
+// multiple of duration, e.g. every 15 minutes from midnight UTC to align the
+// runs with fixed data windows. Unlike Every, the activations do not depend on
+// when the schedule is consulted, so they do not drift. The anchor may be in
+// the past or the future. The duration is rounded as in Every.
+func EveryFrom(duration time.Duration, anchor time.Time) AnchoredDelaySchedule {
+	return AnchoredDelaySchedule{Delay: Every(duration).Delay, Anchor: anchor}
+}
+
+// Next returns the first activation later than t, or the zero time if the
+// delay is not positive.
+func (schedule AnchoredDelaySchedule) Next(t time.Time) time.Time {
+	if schedule.Delay <= 0 {
+		return time.Time{}
+	}
+	if t.Before(schedule.Anchor) {
+		return schedule.Anchor
+	}
+	return schedule.Anchor.Add((t.Sub(schedule.Anchor)/schedule.Delay + 1) * schedule.Delay)
+}
+
+// Spec returns the "@every" descriptor of the schedule, followed by "from"
+// and the anchor in RFC 3339 format.
+func (schedule AnchoredDelaySchedule) Spec() string {
+	return "@every " + schedule.Delay.String() + " from " + schedule.Anchor.Format(time.RFC3339Nano)
+}
+
+// immediately marks an "@every" descriptor as an ImmediateDelaySchedule.
+const immediately = "immediately"
+
+// parseEvery returns the schedule of an "@every" descriptor, given what
+// follows "@every ": a duration, optionally followed by "immediately" or by
+// "from" and an RFC 3339 anchor.
+func parseEvery(s string) (Schedule, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		fields = []string{""}
+	}
+	duration, err := time.ParseDuration(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(fields) == 1:
+		return Every(duration), nil
+	case len(fields) == 2 && fields[1] == immediately:
+		return EveryImmediate(duration), nil
+	case len(fields) == 3 && fields[1] == "from":
+		anchor, err := time.Parse(time.RFC3339Nano, fields[2])
+		if err != nil {
+			return nil, err
+		}
+		return EveryFrom(duration, anchor), nil
+	}
+	return nil, errors.New(`expected "immediately" or "from" and a time after the duration`)
+}
+
+// isInterval reports whether the activations of the schedule follow from the
+// time it is consulted, rather than fall at fixed times.
+func isInterval(schedule Schedule) bool {
+	switch schedule.(type) {
+	case ConstantDelaySchedule, ImmediateDelaySchedule:
+		return true
+	}
+	return false
+}
@@ -52,3 +52,102 @@ func TestConstantDelayNext(t *testing.T) {
 		}
 	}
 }
+
+func TestEveryImmediate(t *testing.T) {
+	schedule := EveryImmediate(time.Minute)
+	if schedule.Delay != time.Minute {
+		t.Fatalf("unexpected schedule: %+v", schedule)
+	}
+	now := getTime("Mon Jul 9 14:45 2012")
+	if actual := firstActivation(schedule, now); actual != now {
+		t.Errorf("expected first activation at %v, got %v", now, actual)
+	}
+	if actual, expected := schedule.Next(now), getTime("Mon Jul 9 14:46 2012"); actual != expected {
+		t.Errorf("expected next activation at %v, got %v", expected, actual)
+	}
+}
+
+func TestEveryImmediateRunsOnStart(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	cron := New(WithChain())
+	cron.Schedule(EveryImmediate(time.Hour), FuncJob(func() { ran <- struct{}{} }))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("expected job to run immediately")
+	}
+}
+
+func TestEveryImmediateWrapped(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		opt  EntryOption
+	}{
+		{"jitter", WithJitter(10 * time.Millisecond)},
+		{"calendar", WithCalendar(NewDateListCalendar(now.AddDate(0, 0, 1)))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := make(chan struct{}, 1)
+			cron := New(WithChain())
+			cron.Schedule(EveryImmediate(time.Hour), FuncJob(func() { ran <- struct{}{} }), tt.opt)
+			cron.Start()
+			defer cron.Stop()
+
+			select {
+			case <-ran:
+			case <-time.After(100 * time.Millisecond):
+				t.Error("expected job to run immediately")
+			}
+		})
+	}
+
+	// On a day that is not a business day, the first run waits for the next
+	// business day.
+	today := getTime("Mon Jul 9 14:45 2012")
+	schedule := calendarSchedule{EveryImmediate(time.Hour), NewDateListCalendar(today)}
+	if actual, expected := firstActivation(schedule, today), getTime("Tue Jul 10 00:00 2012"); actual != expected {
+		t.Errorf("expected first activation at %v, got %v", expected, actual)
+	}
+}
+
+func TestEveryFrom(t *testing.T) {
+	anchor := getTime("Mon Jul 9 00:00 2012")
+	tests := []struct {
+		time     string
+		expected string
+	}{
+		{"Sun Jul 8 12:00 2012", "Mon Jul 9 00:00 2012"},
+		{"Mon Jul 9 00:00 2012", "Mon Jul 9 00:15 2012"},
+		{"Mon Jul 9 14:44:59 2012", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 14:45 2012", "Mon Jul 9 15:00 2012"},
+		// A run finishing late does not shift the following ones.
+		{"Mon Jul 9 14:52:17 2012", "Mon Jul 9 15:00 2012"},
+		{"Wed Jul 11 23:59:59 2012", "Thu Jul 12 00:00 2012"},
+	}
+	for _, c := range tests {
+		actual := EveryFrom(15*time.Minute, anchor).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestAnchoredDelayZeroDelay(t *testing.T) {
+	anchor := getTime("Mon Jul 9 00:00 2012")
+	for _, s := range []AnchoredDelaySchedule{
+		{Anchor: anchor},
+		{Delay: -time.Minute, Anchor: anchor},
+	} {
+		for _, now := range []string{"Sun Jul 8 12:00 2012", "Mon Jul 9 14:45 2012"} {
+			if next := s.Next(getTime(now)); !next.IsZero() {
+				t.Errorf("%+v at %s: expected the zero time, got %v", s, now, next)
+			}
+		}
+	}
+}
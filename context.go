@@ -0,0 +1,103 @@
+package cron
+
+import "context"
+
+// JobWithContext is a job that receives the context of each run. The context
+// is cancelled when the Cron is stopped, and may carry a deadline, e.g. with
+// WithDeadlineUntilNext. Add it with Cron.AddJobCtx.
+type JobWithContext interface {
+	Run(ctx context.Context)
+}
+
+// contextJobAdapter turns a JobWithContext into a Job.
+type contextJobAdapter struct {
+	JobWithContext
+}
+
+// Run runs the job with context.Background().
+func (a contextJobAdapter) Run() { a.JobWithContext.Run(context.Background()) }
+
+func (a contextJobAdapter) runContext(ctx context.Context) { a.JobWithContext.Run(ctx) }
+
+// ContextFuncJob is a wrapper that turns a func(context.Context) into a
+// cron.Job. When run by a Cron, the function receives the context of the run,
+// which is cancelled when the Cron is stopped; when Run is called directly, it
+// receives context.Background().
+type ContextFuncJob func(ctx context.Context)
+
+// Run runs the function with context.Background().
+func (f ContextFuncJob) Run() { f(context.Background()) }
+
+func (f ContextFuncJob) runContext(ctx context.Context) { f(ctx) }
+
+// contextJob is implemented by jobs that accept the context of a run.
+type contextJob interface {
+	runContext(ctx context.Context)
+}
+
+// RunJob runs j, passing it ctx if it accepts a context. JobWrappers should
+// return a ContextErrFuncJob that calls RunJobE, so that the context of each
+// run reaches the wrapped job, and its error comes back; the wrappers in this
+// package do so.
+func RunJob(ctx context.Context, j Job) {
+	if cj, ok := j.(contextJob); ok {
+		cj.runContext(ctx)
+		return
+	}
+	j.Run()
+}
+
+// ErrorJob is a job that returns an error, which makes its run count as
+// failed and is logged by the Cron and reported to its listeners. Add it with
+// Cron.AddErrJob.
+type ErrorJob interface {
+	RunE() error
+}
+
+// errorJobAdapter turns an ErrorJob into a Job.
+type errorJobAdapter struct {
+	ErrorJob
+}
+
+// Run runs the job, discarding its error.
+func (a errorJobAdapter) Run() { a.ErrorJob.RunE() }
+
+// ErrFuncJob is a wrapper that turns a func() error into a cron.Job and an
+// ErrorJob.
+type ErrFuncJob func() error
+
+// Run runs the function, discarding its error.
+func (f ErrFuncJob) Run() { f() }
+
+// RunE runs the function.
+func (f ErrFuncJob) RunE() error { return f() }
+
+// ContextErrFuncJob is a wrapper that turns a func(context.Context) error into
+// a cron.Job, like ContextFuncJob, whose error is returned by RunJobE.
+type ContextErrFuncJob func(ctx context.Context) error
+
+// Run runs the function with context.Background(), discarding its error.
+func (f ContextErrFuncJob) Run() { f(context.Background()) }
+
+func (f ContextErrFuncJob) runContext(ctx context.Context) { f(ctx) }
+
+func (f ContextErrFuncJob) runContextE(ctx context.Context) error { return f(ctx) }
+
+// errorContextJob is implemented by jobs that accept the context of a run and
+// return an error.
+type errorContextJob interface {
+	runContextE(ctx context.Context) error
+}
+
+// RunJobE runs j like RunJob, and returns its error if it is an ErrorJob or a
+// ContextErrFuncJob.
+func RunJobE(ctx context.Context, j Job) error {
+	if ej, ok := j.(errorContextJob); ok {
+		return ej.runContextE(ctx)
+	}
+	if ej, ok := j.(ErrorJob); ok {
+		return ej.RunE()
+	}
+	RunJob(ctx, j)
+	return nil
+}
@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+func TestRunJobPassesContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "run")
+	var got interface{}
+	job := NewChain(Recover(DiscardLogger), SkipIfStillRunning(DiscardLogger), LockOSThread()).
+		Then(ContextFuncJob(func(ctx context.Context) { got = ctx.Value(ctxKey{}) }))
+
+	RunJob(ctx, job)
+	if got != "run" {
+		t.Errorf("expected the context to reach the job through the chain, got %v", got)
+	}
+
+	job.Run()
+	if got != nil {
+		t.Errorf("expected Run to use context.Background(), got %v", got)
+	}
+
+	// Plain jobs are simply run.
+	ran := false
+	RunJob(ctx, FuncJob(func() { ran = true }))
+	if !ran {
+		t.Error("expected the plain job to run")
+	}
+}
+
+func TestWithDeadlineUntilNext(t *testing.T) {
+	deadlines := make(chan time.Time, 1)
+	cron := newWithSeconds()
+	cron.AddJob("* * * * * ?", ContextFuncJob(func(ctx context.Context) {
+		deadline, _ := ctx.Deadline()
+		select {
+		case deadlines <- deadline:
+		default:
+		}
+	}), WithDeadlineUntilNext())
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case deadline := <-deadlines:
+		if deadline.IsZero() || deadline.Nanosecond() != 0 || time.Until(deadline) > time.Second {
+			t.Errorf("expected the deadline to be the next activation, got %v", deadline)
+		}
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the job to run")
+	}
+}
+
+type ctxJob chan error
+
+func (j ctxJob) Run(ctx context.Context) {
+	<-ctx.Done()
+	j <- ctx.Err()
+}
+
+func TestStopCancelsJobContext(t *testing.T) {
+	job := make(ctxJob, 1)
+	started := make(chan struct{}, 1)
+	cron := newWithSeconds()
+	cron.AddJobCtx("* * * * * ?", job)
+	cron.AddFuncCtx("* * * * * ?", func(ctx context.Context) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+	})
+	cron.Start()
+
+	select {
+	case <-started:
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the jobs to start")
+	}
+	ctx := cron.Stop()
+
+	select {
+	case err := <-job:
+		if err != context.Canceled {
+			t.Errorf("expected the job's context to be cancelled, got %v", err)
+		}
+	case <-time.After(OneSecond):
+		t.Fatal("expected Stop to cancel the running job")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(OneSecond):
+		t.Error("expected the running jobs to return after Stop")
+	}
+}
+
+func TestAddErrFunc(t *testing.T) {
+	var (
+		buf      syncWriter
+		rec      = &eventRecorder{}
+		errBoom  = errors.New("boom")
+		attempts int
+	)
+	cron := New(WithLogger(PrintfLogger(log.New(&buf, "", 0))), WithEventListener(rec),
+		WithChain(Recover(DiscardLogger), SkipIfStillRunning(DiscardLogger)))
+	id, err := cron.AddErrFunc("@hourly", func() error {
+		attempts++
+		return errBoom
+	}, WithRunTimeout(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+
+	if attempts != 1 {
+		t.Fatalf("expected one run, got %d", attempts)
+	}
+	if s := cron.StatsSnapshot()[id]; s.Failures != 1 || !s.LastFailed {
+		t.Errorf("expected the error to fail the run, got %+v", s)
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("expected the error to be logged, got %q", buf.String())
+	}
+	var completed JobCompleted
+	for _, ev := range rec.recorded() {
+		if ev, ok := ev.(JobCompleted); ok {
+			completed = ev
+		}
+	}
+	if completed.Err != errBoom || !completed.Failed {
+		t.Errorf("expected the error to reach the listener, got %+v", completed)
+	}
+}
+
+func TestRunJobE(t *testing.T) {
+	errBoom := errors.New("boom")
+	wrapped := NewChain(Recover(DiscardLogger), DelayIfStillRunning(DiscardLogger),
+		WithTimeout(time.Minute, DiscardLogger), LockOSThread()).Then(ErrFuncJob(func() error { return errBoom }))
+	if err := RunJobE(context.Background(), wrapped); err != errBoom {
+		t.Errorf("expected the error to pass through the wrappers, got %v", err)
+	}
+	if err := RunJobE(context.Background(), FuncJob(func() {})); err != nil {
+		t.Errorf("expected no error from a plain job, got %v", err)
+	}
+}
@@ -2,8 +2,12 @@ package cron
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,12 +15,15 @@ import (
 // specified by the schedule. It may be started, stopped, and the entries may
 // be inspected while running.
 type Cron struct {
-	entries   []*Entry
+	store     Store
 	chain     Chain
 	stop      chan struct{}
-	add       chan *Entry
+	add       chan []*Entry
 	remove    chan EntryID
 	snapshot  chan chan []Entry
+	page      chan pageRequest
+	batches   chan batchRequest
+	chains    chan chainRequest
 	running   bool
 	logger    Logger
 	runningMu sync.Mutex
@@ -24,6 +31,60 @@ type Cron struct {
 	parser    ScheduleParser
 	nextID    EntryID
 	jobWaiter sync.WaitGroup
+	quota     *Quota
+	outcome   chan runOutcome
+	// deadline and deadlineGrace are set by WithHardDeadline.
+	deadline      time.Duration
+	deadlineGrace time.Duration
+	rand          *lockedRand
+	precision     time.Duration
+	poolSize      int
+	aging         time.Duration
+	pool          *workerPool
+	loopDone      chan struct{}
+	stats         *statsRegistry
+	history       *history
+	inflight      inflightRuns
+	admission     func(Entry, time.Time) Decision
+	staggerIn     time.Duration
+	journal       *journalConfig
+	jobCtx        context.Context
+	jobCancel     context.CancelFunc
+	isolation     *ownerIsolation
+	skew          *skewLimit
+	nowFunc       func() time.Time
+	stale         time.Duration
+	onEvent       func(Event)
+	cipher        Cipher
+	suspend       chan bool
+	suspended     bool
+	allocRate     float64
+	namesMu       sync.Mutex
+	names         map[string]EntryID
+	inline        bool
+	listeners     []EventListener
+	idle          int32
+	warmStart     bool
+	// minWake is the minimum interval between wake-ups set by
+	// WithWakeBudget; lastWake is the latest wake-up and plannedWake the time
+	// the scheduler is sleeping until.
+	minWake     time.Duration
+	lastWake    time.Time
+	plannedWake time.Time
+	// maxDispatch, if positive, is the most entries dispatched per wake-up.
+	// See WithMaxDispatchPerWake.
+	maxDispatch int
+	// monotonic, if set, guards against schedules that do not move forward.
+	// See WithMonotonicNext.
+	monotonic *MonotonicConfig
+	// reloader, if set, keeps the entries in line with an EntrySource. See
+	// WithReloader.
+	reloader *reloader
+	// locks, if set, manages the distributed locks of the entries. See
+	// WithLockManager.
+	locks  LockManager
+	onIdle func()
+	acks   ackTracker
 }
 
 // ScheduleParser is an interface for schedule spec parsers that return a Schedule
@@ -39,7 +100,9 @@ type Job interface {
 // Schedule describes a job's duty cycle.
 type Schedule interface {
 	// Next returns the next activation time, later than the given time.
-	// Next is invoked initially, and then each time the job is run.
+	// Next is invoked initially, and then each time the job is run. Returning
+	// the zero time after an activation, as OneShotSchedule does, means the
+	// schedule is exhausted: its entry is then removed.
 	Next(time.Time) time.Time
 }
 
@@ -69,11 +132,166 @@ type Entry struct {
 	// It is kept around so that user code that needs to get at the job later,
 	// e.g. via Entries() can do so.
 	Job Job
+
+	// DegradedSchedule, if set, replaces Schedule while the entry's job is
+	// failing. See WithDegradedSchedule.
+	DegradedSchedule Schedule
+
+	// Degraded is true if the most recent run of the job failed and the entry
+	// is running on its DegradedSchedule.
+	Degraded bool
+
+	// Description is a human-readable explanation of the entry's purpose.
+	Description string
+
+	// Metadata holds arbitrary key/value context about the entry, such as its
+	// owning team or a runbook link.
+	Metadata map[string]string
+
+	// Paused is true if the entry has been paused with PauseMany. A paused
+	// entry is not scheduled, and its Next time is zero.
+	Paused bool
+
+	// Archived is true if the entry has been archived with Archive. An archived
+	// entry is not scheduled and is left out of Entries, but it keeps its
+	// configuration and statistics until it is restored or removed.
+	Archived bool
+
+	// Owner identifies the team or tenant responsible for the entry. See
+	// WithOwner and WithOwnerIsolation.
+	Owner string
+
+	// Priority orders runs waiting for a worker when the cron is configured
+	// with WithWorkerPool. Runs of higher priority entries start first.
+	Priority int
+
+	// Name identifies the entry to people, e.g. in logs and admin tools. It is
+	// unique among the entries of a Cron, if set. See WithName and
+	// EntryByName.
+	Name string
+
+	// Spec is the spec the entry was added with, or "" if it was added with
+	// Schedule.
+	Spec string
+
+	// JobName identifies the job to persistent stores, which resolve it back
+	// to a Job on startup. See WithJobName and SQLStore.
+	JobName string
+
+	// Payload holds the parameters of the job, set with WithPayload. If
+	// PayloadKey is set, it is encrypted with that key of the Cron's Cipher.
+	Payload []byte
+
+	// PayloadKey is the ID of the key that encrypted Payload, or "" if it is
+	// not encrypted.
+	PayloadKey string
+
+	// wrappers decorate this entry's job, inside the Cron's Chain.
+	wrappers []JobWrapper
+
+	// inner is the entry's job decorated by its wrappers, which the Cron's
+	// Chain wraps into WrappedJob.
+	inner Job
+
+	// once, if set, guards against running an occurrence more than once.
+	once *onceGuard
+
+	// group, if set, serializes this entry's runs with those of other entries.
+	group *Group
+
+	// maxQueueDelay, if positive, drops runs that could not start within
+	// this long of their scheduled time.
+	maxQueueDelay time.Duration
+
+	// journalKey, if set, identifies the entry in the Cron's Journal.
+	journalKey string
+
+	// timeout, if positive, limits the duration of each run. See
+	// WithRunTimeout.
+	timeout time.Duration
+
+	// source is where the entry came from. See WithSource.
+	source string
+
+	// misfire determines what happens to runs that start late. See
+	// WithMisfirePolicy.
+	misfire MisfirePolicy
+
+	// onMisfire, if set, overrides misfire for each late wake-up. See
+	// OnMisfire.
+	onMisfire MisfireHandler
+
+	// deadlineUntilNext sets the deadline of each run's context to the
+	// entry's following activation.
+	deadlineUntilNext bool
+
+	// jitter, if positive, delays each activation by a random duration less
+	// than it, drawn from rand. See WithJitter.
+	jitter time.Duration
+	rand   *lockedRand
+
+	// ack, if set, requires runs to be acknowledged. See WithManualAck.
+	ack *AckConfig
+
+	// hashKey, if set, is the key from which hashed values of the schedule
+	// are derived. See WithHashKey.
+	hashKey string
+
+	// calendar, if set, skips activations on days that are not business
+	// days. See WithCalendar.
+	calendar Calendar
 }
 
 // Valid returns true if this is not the zero entry.
 func (e Entry) Valid() bool { return e.ID != 0 }
 
+// ScheduleSpec returns the spec of the entry: the one it was added with, or
+// else that of its schedule if the schedule has a Spec method, as the bundled
+// schedules written as specs do. It returns "" otherwise, e.g. for a
+// schedule implemented outside this package.
+func (e Entry) ScheduleSpec() string {
+	if e.Spec != "" {
+		return e.Spec
+	}
+	if s, ok := e.Schedule.(specer); ok {
+		return s.Spec()
+	}
+	return ""
+}
+
+// activeSchedule returns the schedule the entry is currently running on.
+func (e *Entry) activeSchedule() Schedule {
+	schedule := e.plainSchedule()
+	if e.jitter > 0 {
+		return jitterSchedule{schedule, e.jitter, e.rand}
+	}
+	return schedule
+}
+
+// plainSchedule returns the schedule the entry is currently running on,
+// without jitter.
+func (e *Entry) plainSchedule() Schedule {
+	schedule := e.Schedule
+	if e.Degraded && e.DegradedSchedule != nil {
+		schedule = e.DegradedSchedule
+	}
+	if e.calendar != nil {
+		return calendarSchedule{schedule, e.calendar}
+	}
+	return schedule
+}
+
+// runOutcome reports whether a run of an entry's job failed, and the next run
+// time it requested, if any. See ReschedulingJob.
+type runOutcome struct {
+	id     EntryID
+	failed bool
+	next   time.Time
+
+	// redispatch, if set, asks to run an unacknowledged run again instead.
+	redispatch *ackRun
+}
+
 // byTime is a wrapper for sorting the entry array by time
 // (with zero time at the end).
 type byTime []*Entry
@@ -90,6 +308,9 @@ func (s byTime) Less(i, j int) bool {
 	if s[j].Next.IsZero() {
 		return true
 	}
+	if s[i].Next.Equal(s[j].Next) {
+		return s[i].ID < s[j].ID
+	}
 	return s[i].Next.Before(s[j].Next)
 }
 
@@ -109,24 +330,51 @@ func (s byTime) Less(i, j int) bool {
 //     Description: Wrap submitted jobs to customize behavior.
 //     Default:     A chain that recovers panics and logs them to stderr.
 //
+//   Store
+//     Description: Holds the entries of this cron.
+//     Default:     A HeapStore.
+//
 // See "cron.With*" to modify the default behavior.
 func New(opts ...Option) *Cron {
 	c := &Cron{
-		entries:   nil,
+		store:     NewHeapStore(),
 		chain:     NewChain(),
-		add:       make(chan *Entry),
+		add:       make(chan []*Entry),
 		stop:      make(chan struct{}),
 		snapshot:  make(chan chan []Entry),
+		page:      make(chan pageRequest),
 		remove:    make(chan EntryID),
+		batches:   make(chan batchRequest),
+		chains:    make(chan chainRequest),
+		suspend:   make(chan bool),
+		outcome:   make(chan runOutcome),
 		running:   false,
 		runningMu: sync.Mutex{},
 		logger:    DefaultLogger,
 		location:  time.Local,
 		parser:    standardParser,
+		rand:      newLockedRand(nil),
+		stats:     newStatsRegistry(),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.poolSize > 0 {
+		c.pool = newWorkerPool(c.poolSize, c.aging, c.now)
+	}
+	for _, e := range c.store.Entries() {
+		if e.ID > c.nextID {
+			c.nextID = e.ID
+		}
+		if e.WrappedJob == nil {
+			e.inner = c.observe(e.ID, e.Job)
+			e.WrappedJob = c.chain.Then(e.inner)
+		}
+		if e.Name != "" {
+			c.reserveName(e.Name, e.ID)
+		}
+		c.stats.add(e.ID, c.now())
+	}
 	return c
 }
 
@@ -138,43 +386,213 @@ func (f FuncJob) Run() { f() }
 // AddFunc adds a func to the Cron to be run on the given schedule.
 // The spec is parsed using the time zone of this Cron instance as the default.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddFunc(spec string, cmd func()) (EntryID, error) {
-	return c.AddJob(spec, FuncJob(cmd))
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddFuncCtx adds a func to the Cron to be run on the given schedule, like
+// AddFunc. The func receives a context that is cancelled when the Cron is
+// stopped.
+func (c *Cron) AddFuncCtx(spec string, cmd func(ctx context.Context), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, ContextFuncJob(cmd), opts...)
+}
+
+// AddJobCtx adds a JobWithContext to the Cron to be run on the given
+// schedule, like AddJob.
+func (c *Cron) AddJobCtx(spec string, cmd JobWithContext, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, contextJobAdapter{cmd}, opts...)
+}
+
+// AddErrFunc adds a func returning an error to the Cron to be run on the
+// given schedule, like AddFunc. A run that returns an error counts as failed.
+func (c *Cron) AddErrFunc(spec string, cmd func() error, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, ErrFuncJob(cmd), opts...)
+}
+
+// AddErrJob adds an ErrorJob to the Cron to be run on the given schedule,
+// like AddJob.
+func (c *Cron) AddErrJob(spec string, cmd ErrorJob, opts ...EntryOption) (EntryID, error) {
+	if j, ok := cmd.(Job); ok {
+		return c.AddJob(spec, j, opts...)
+	}
+	return c.AddJob(spec, errorJobAdapter{cmd}, opts...)
 }
 
 // AddJob adds a Job to the Cron to be run on the given schedule.
 // The spec is parsed using the time zone of this Cron instance as the default.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddJob(spec string, cmd Job) (EntryID, error) {
+// A *QuotaError is returned if the entry would exceed the configured Quota,
+// ErrDuplicateName if its name (see WithName) is taken, and ErrNoActivation if
+// its schedule is one-shot or bounded and has no activation left.
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
 	schedule, err := c.parser.Parse(spec)
 	if err != nil {
 		return 0, err
 	}
-	return c.Schedule(schedule, cmd), nil
+	return c.schedule(spec, schedule, cmd, opts)
 }
 
 // Schedule adds a Job to the Cron to be run on the given schedule.
 // The job is wrapped with the configured Chain.
-func (c *Cron) Schedule(schedule Schedule, cmd Job) EntryID {
+// If adding the entry would exceed the configured Quota, it is not added and
+// 0 is returned.
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	id, err := c.schedule("", schedule, cmd, opts)
+	if err != nil {
+		c.logger.Error(err, "schedule")
+	}
+	return id
+}
+
+// AddJobs adds the jobs to the Cron at once, as AddJob does each, and returns
+// their IDs, in order. Either all the jobs are added or none: every spec is
+// parsed and every entry checked against the Quota and the names of the
+// other entries before any is added. If the cron is running, the entries are
+// added in a single step of the run loop.
+//
+// A spec that fails to parse is reported with the index of its job. An entry
+// that is rejected is reported as by AddJob, with a *QuotaError,
+// ErrDuplicateName or ErrNoActivation.
+func (c *Cron) AddJobs(jobs []JobSpec) ([]EntryID, error) {
+	schedules := make([]Schedule, len(jobs))
+	for i, js := range jobs {
+		schedule, err := c.parser.Parse(js.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("cron: jobs[%d]: %v", i, err)
+		}
+		schedules[i] = schedule
+	}
+
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	entries := make([]*Entry, 0, len(jobs))
+	for i, js := range jobs {
+		entry, err := c.newEntry(js.Spec, schedules[i], js.Job, js.options())
+		if err != nil {
+			for _, e := range entries {
+				c.discardEntry(e)
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	c.addEntries(entries)
+	ids := make([]EntryID, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return ids, nil
+}
+
+// schedule adds the entry, returning a *QuotaError if it is rejected.
+func (c *Cron) schedule(spec string, schedule Schedule, cmd Job, opts []EntryOption) (EntryID, error) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
+	entry, err := c.newEntry(spec, schedule, cmd, opts)
+	if err != nil {
+		return 0, err
+	}
+	c.addEntries([]*Entry{entry})
+	return entry.ID, nil
+}
+
+// newEntry returns a new entry, holding its room in the Quota and its name,
+// ready to be added. It must be called with runningMu held.
+func (c *Cron) newEntry(spec string, schedule Schedule, cmd Job, opts []EntryOption) (*Entry, error) {
+	if err := c.quota.acquireEntry(); err != nil {
+		return nil, err
+	}
 	c.nextID++
 	entry := &Entry{
-		ID:         c.nextID,
-		Schedule:   schedule,
-		WrappedJob: c.chain.Then(cmd),
-		Job:        cmd,
+		ID:       c.nextID,
+		Schedule: schedule,
+		Job:      cmd,
+		Spec:     spec,
+	}
+	for _, opt := range opts {
+		opt(entry)
+	}
+	bindHashKey(entry)
+	entry.rand = c.rand
+	if exhausted(entry, firstActivation(entry.activeSchedule(), c.now())) {
+		c.quota.releaseEntry()
+		return nil, ErrNoActivation
+	}
+	if err := c.encryptPayload(entry); err != nil {
+		c.quota.releaseEntry()
+		return nil, err
+	}
+	if entry.Name != "" && !c.reserveName(entry.Name, entry.ID) {
+		c.quota.releaseEntry()
+		return nil, ErrDuplicateName
+	}
+	c.wrapJob(entry)
+	return entry, nil
+}
+
+// discardEntry gives back the room and name held by an entry returned by
+// newEntry that is not to be added.
+func (c *Cron) discardEntry(entry *Entry) {
+	c.quota.releaseEntry()
+	if entry.Name != "" {
+		c.unreserveName(entry.Name, entry.ID)
+	}
+}
+
+// addEntries adds the entries returned by newEntry: directly if the cron is
+// not running, or else in a single step of the run loop. It must be called
+// with runningMu held.
+func (c *Cron) addEntries(entries []*Entry) {
+	now := c.now()
+	for _, entry := range entries {
+		c.stats.add(entry.ID, now)
 	}
 	if !c.running {
-		c.entries = append(c.entries, entry)
+		for _, entry := range entries {
+			c.store.Add(entry)
+		}
 	} else {
-		c.add <- entry
+		c.add <- entries
+	}
+	for _, entry := range entries {
+		c.emit(Event{Type: EventCreated, Entry: entry.ID, Spec: entry.Spec,
+			Source: entry.source, Initiator: InitiatorAPI})
 	}
-	return entry.ID
 }
 
-// Entries returns a snapshot of the cron entries.
+// wrapJob decorates the entry's job with its wrappers and the Cron's Chain.
+func (c *Cron) wrapJob(entry *Entry) {
+	wrappers := entry.wrappers
+	if entry.timeout > 0 {
+		wrappers = append(wrappers[:len(wrappers):len(wrappers)], WithTimeout(entry.timeout, c.logger))
+	}
+	entry.inner = NewChain(wrappers...).Then(c.observe(entry.ID, entry.Job))
+	entry.WrappedJob = c.chain.Then(entry.inner)
+}
+
+// Entries returns a snapshot of the cron entries, excluding archived ones.
 func (c *Cron) Entries() []Entry {
+	return filterArchived(c.allEntries(), false)
+}
+
+// ArchivedEntries returns a snapshot of the archived cron entries.
+func (c *Cron) ArchivedEntries() []Entry {
+	return filterArchived(c.allEntries(), true)
+}
+
+// filterArchived returns the entries whose Archived field equals archived.
+func filterArchived(entries []Entry, archived bool) []Entry {
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.Archived == archived {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// allEntries returns a snapshot of all cron entries, including archived ones.
+func (c *Cron) allEntries() []Entry {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
@@ -192,7 +610,7 @@ func (c *Cron) Location() *time.Location {
 
 // Entry returns a snapshot of the given entry, or nil if it couldn't be found.
 func (c *Cron) Entry(id EntryID) Entry {
-	for _, entry := range c.Entries() {
+	for _, entry := range c.allEntries() {
 		if id == entry.ID {
 			return entry
 		}
@@ -205,9 +623,12 @@ func (c *Cron) Remove(id EntryID) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
+		// Free the name now, so that it may be reused as soon as Remove
+		// returns.
+		c.releaseName(id)
 		c.remove <- id
 	} else {
-		c.removeEntry(id)
+		c.removeEntry(id, InitiatorAPI)
 	}
 }
 
@@ -219,7 +640,10 @@ func (c *Cron) Start() {
 		return
 	}
 	c.running = true
-	go c.run()
+	c.loopDone = make(chan struct{})
+	c.jobCtx, c.jobCancel = context.WithCancel(context.Background())
+	c.startReloader()
+	go c.run(c.loopDone)
 }
 
 // Run the cron scheduler, or no-op if already running.
@@ -230,73 +654,163 @@ func (c *Cron) Run() {
 		return
 	}
 	c.running = true
+	c.loopDone = make(chan struct{})
+	c.jobCtx, c.jobCancel = context.WithCancel(context.Background())
+	c.startReloader()
+	done := c.loopDone
 	c.runningMu.Unlock()
-	c.run()
+	c.run(done)
 }
 
 // run the scheduler.. this is private just due to the need to synchronize
-// access to the 'running' state variable.
-func (c *Cron) run() {
+// access to the 'running' state variable. The given channel is closed when
+// the scheduler exits.
+func (c *Cron) run(done chan struct{}) {
+	defer close(done)
 	c.logger.Info("start")
+	c.notify(SchedulerStarted{Time: c.now()})
 
 	// Figure out the next activation times for each entry.
 	now := c.now()
-	for _, entry := range c.entries {
-		entry.Next = entry.Schedule.Next(now)
+	rerun := c.recoverInterrupted()
+	for _, entry := range c.store.Entries() {
+		entry.Next = c.stagger(c.startActivation(entry, now), now)
+		if entry.journalKey != "" && rerun[entry.journalKey] {
+			entry.Next = now
+		}
+		if entry.Paused || entry.Archived {
+			entry.Next = time.Time{}
+		} else if exhausted(entry, entry.Next) {
+			// The schedule was exhausted while the scheduler was stopped,
+			// e.g. an At time that has passed.
+			c.retire(entry)
+			continue
+		}
+		c.store.Update(entry)
 		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+		c.scheduled(entry, now)
 	}
 
+	// backlog is set when the latest wake-up left due entries for the next.
+	var backlog bool
 	for {
-		// Determine the next entry to run.
-		sort.Sort(byTime(c.entries))
-
-		var timer *time.Timer
-		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
-			// If there are no entries yet, just sleep - it still handles new entries
-			// and stop requests.
-			timer = time.NewTimer(100000 * time.Hour)
-		} else {
-			timer = time.NewTimer(c.entries[0].Next.Sub(now))
+		// Determine the next entry to run. If there is none, or all are
+		// suspended, the scheduler is idle: it has no timer, and only handles
+		// new entries and other requests.
+		var (
+			timer *time.Timer
+			wake  <-chan time.Time
+		)
+		next := c.store.Next()
+		c.setIdle(next.IsZero() || c.suspended)
+		if !c.IsIdle() {
+			if backlog {
+				// Entries left over by the previous wake-up are due now.
+				c.plannedWake = time.Time{}
+				timer = time.NewTimer(0)
+			} else {
+				c.plannedWake = c.wakeAt(next)
+				timer = time.NewTimer(c.plannedWake.Sub(now))
+			}
+			wake = timer.C
 		}
 
 		for {
 			select {
-			case now = <-timer.C:
-				now = now.In(c.location)
+			case <-wake:
+				// The value sent on the timer channel is when the timer fired,
+				// which may be long ago if the process was suspended, e.g. by
+				// laptop sleep or a VM snapshot, so read the clock instead.
+				now = c.now()
+				c.lastWake = now
 				c.logger.Info("wake", "now", now)
+				if c.suspended {
+					break
+				}
 
-				// Run every entry whose next time was less than now
-				for _, e := range c.entries {
-					if e.Next.After(now) || e.Next.IsZero() {
-						break
+				// Run every entry whose next time was less than now, earliest
+				// first, up to the limit per wake-up.
+				var ready []*Entry
+				ready, backlog = c.ready(now)
+				c.coalesced(ready, now)
+				for _, e := range ready {
+					ok, deferred := c.admit(e, now)
+					if deferred {
+						c.store.Update(e)
+						continue
+					}
+					if !ok {
+						// The skip has already been logged and counted.
+					} else if err := c.quota.acquireRun(now); err != nil {
+						c.logger.Error(err, "skip", "entry", e.ID)
+						c.skipped(e.ID, e.Next, SkipOverloaded, "quota exceeded", false)
+					} else if c.fire(e, now) {
+						c.store.Update(e)
+						continue
 					}
-					c.startJob(e.WrappedJob)
 					e.Prev = e.Next
-					e.Next = e.Schedule.Next(now)
+					e.Next = c.nextActivation(e, now)
+					c.store.Update(e)
 					c.logger.Info("run", "now", now, "entry", e.ID, "next", e.Next)
+					c.scheduled(e, now)
+					if e.Next.IsZero() {
+						c.retire(e)
+					}
 				}
+				c.plannedWake = time.Time{}
 
-			case newEntry := <-c.add:
-				timer.Stop()
+			case newEntries := <-c.add:
+				stopTimer(timer)
 				now = c.now()
-				newEntry.Next = newEntry.Schedule.Next(now)
-				c.entries = append(c.entries, newEntry)
-				c.logger.Info("added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
+				for _, newEntry := range newEntries {
+					newEntry.Next = firstActivation(newEntry.activeSchedule(), now)
+					c.store.Add(newEntry)
+					c.logger.Info("added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
+					c.scheduled(newEntry, now)
+				}
 
 			case replyChan := <-c.snapshot:
 				replyChan <- c.entrySnapshot()
 				continue
 
+			case req := <-c.page:
+				req.reply <- c.copyPage(req)
+				continue
+
 			case <-c.stop:
-				timer.Stop()
+				stopTimer(timer)
+				c.plannedWake = time.Time{}
 				c.logger.Info("stop")
+				c.notify(SchedulerStopped{Time: c.now()})
 				return
 
+			case req := <-c.chains:
+				c.applyChain(req.chain)
+				close(req.done)
+				continue
+
+			case req := <-c.batches:
+				stopTimer(timer)
+				now = c.now()
+				req.reply <- c.applyBatch(req, now)
+
+			case suspend := <-c.suspend:
+				stopTimer(timer)
+				now = c.now()
+				c.setSuspended(suspend, now)
+
 			case id := <-c.remove:
-				timer.Stop()
+				stopTimer(timer)
 				now = c.now()
-				c.removeEntry(id)
+				c.removeEntry(id, InitiatorAPI)
 				c.logger.Info("removed", "entry", id)
+
+			case o := <-c.outcome:
+				if !c.applyOutcome(o, c.now()) {
+					continue
+				}
+				stopTimer(timer)
+				now = c.now()
 			}
 
 			break
@@ -304,28 +818,385 @@ func (c *Cron) run() {
 	}
 }
 
-// startJob runs the given job in a new goroutine.
-func (c *Cron) startJob(j Job) {
+// ErrRunAbandoned is logged when a run exceeds the hard deadline configured
+// with WithHardDeadline and the scheduler stops waiting for it.
+var ErrRunAbandoned = errors.New("cron: run abandoned after exceeding its hard deadline")
+
+// RunAbandoned is reported when the scheduler stops waiting for a run that
+// exceeded its hard deadline. See WithHardDeadline.
+type RunAbandoned struct {
+	Entry     EntryID
+	Scheduled time.Time
+	Time      time.Time
+	// Duration is how long the run had been running.
+	Duration time.Duration
+}
+
+func (RunAbandoned) lifecycleEvent() {}
+
+// abandoned records that the scheduler stopped waiting for the run of the
+// entry that started at the given time. See WithHardDeadline.
+func (c *Cron) abandoned(id EntryID, scheduled, start time.Time) {
+	c.logger.Error(ErrRunAbandoned, "abandoned", "entry", id, "start", start)
+	now := c.now()
+	c.history.record(id, RunRecord{Scheduled: scheduled, Start: start, Result: ResultAbandoned})
+	c.notify(RunAbandoned{Entry: id, Scheduled: scheduled, Time: now, Duration: now.Sub(start)})
+}
+
+// startJob runs the given entry's wrapped job for the run scheduled at the
+// given time in a new goroutine, or queues it on the entry's group or the
+// worker pool, if any.
+func (c *Cron) startJob(e *Entry, scheduled time.Time) {
+	c.dispatch(e, scheduled, nil, triggerConfig{})
+}
+
+// dispatch is startJob, dispatching the given unacknowledged run again if
+// pending is set, and overriding the priority and group of the run as
+// configured by trigger.
+func (c *Cron) dispatch(e *Entry, scheduled time.Time, pending *ackRun, trigger triggerConfig) {
+	if e.WrappedJob == nil {
+		// The entry was added to a shared store by another process.
+		c.stats.add(e.ID, c.now())
+		e.inner = c.observe(e.ID, e.Job)
+		e.WrappedJob = c.chain.Then(e.inner)
+		c.store.Update(e)
+	}
+	var (
+		id      = e.ID
+		job     = e.WrappedJob
+		guard   = e.once
+		journal Journal
+		key     = e.journalKey
+		ctx     = c.jobCtx
+		cancel  = func() {}
+		once    sync.Once
+		done    = func() { once.Do(c.jobWaiter.Done) }
+	)
+	if c.journal != nil && key != "" {
+		journal = c.journal.journal
+	}
+	if ctx == nil {
+		// The cron is not running, e.g. the run was triggered before Start.
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, scheduledKey{}, scheduled)
+	ctx = context.WithValue(ctx, scheduleKey{}, e.activeSchedule())
+	ctx = context.WithValue(ctx, clockKey{}, c.now)
+	ctx = context.WithValue(ctx, lockKeyKey{}, entryLockKey(e))
+	ctx = context.WithValue(ctx, skipReporterKey{}, func(reason SkipReason, detail string) {
+		c.skipped(id, scheduled, reason, detail, false)
+	})
+	if pending == nil && e.ack != nil {
+		pending = c.acks.begin(id, scheduled, *e.ack, trigger)
+	}
+	if pending != nil {
+		ctx = context.WithValue(ctx, ackKey{}, pending)
+	}
+	if e.deadlineUntilNext {
+		if next := e.activeSchedule().Next(scheduled); !next.IsZero() {
+			ctx, cancel = context.WithDeadline(ctx, next)
+		}
+	}
+	if len(e.Payload) > 0 {
+		payload, err := c.decryptPayload(e)
+		if err != nil {
+			cancel()
+			c.logger.Error(err, "skip", "entry", id, "scheduled", scheduled)
+			c.skipped(id, scheduled, SkipFailed, "payload", false)
+			return
+		}
+		ctx = context.WithValue(ctx, payloadKey{}, payload)
+	}
+	if pj, ok := e.Job.(PreparedJob); ok {
+		execute, err := pj.Prepare(ctx)
+		if err != nil {
+			cancel()
+			c.logger.Info("skip", "entry", id, "scheduled", scheduled,
+				"reason", "prepare failed", "error", err)
+			c.skipped(id, scheduled, SkipFailed, "prepare failed", false)
+			return
+		}
+		ctx = withExecute(ctx, execute)
+	}
 	c.jobWaiter.Add(1)
-	go func() {
-		defer c.jobWaiter.Done()
-		j.Run()
-	}()
+	run := func() {
+		defer done()
+		defer cancel()
+		if guard != nil {
+			if !c.checkSkew(guard.store, id) {
+				c.logger.Info("skip", "entry", id, "scheduled", scheduled, "reason", "clock skew")
+				c.skipped(id, scheduled, SkipClockSkew, "", false)
+				return
+			}
+			claimed, err := guard.store.Claim(guard.key, scheduled)
+			if err != nil {
+				c.logger.Error(err, "skip", "entry", id, "scheduled", scheduled)
+				c.skipped(id, scheduled, SkipFailed, "once store", false)
+				return
+			}
+			if !claimed {
+				c.logger.Info("skip", "entry", id, "scheduled", scheduled, "reason", "already run")
+				c.skipped(id, scheduled, SkipAlreadyRun, "", false)
+				return
+			}
+		}
+		if journal != nil {
+			if err := journal.Started(key, scheduled, c.now()); err != nil {
+				c.logger.Error(err, "journal", "entry", id, "key", key)
+			}
+			defer func() {
+				if err := journal.Finished(key); err != nil {
+					c.logger.Error(err, "journal", "entry", id, "key", key)
+				}
+			}()
+		}
+		runCtx := ctx
+		if c.deadline > 0 {
+			var cancelRun context.CancelFunc
+			runCtx, cancelRun = context.WithTimeout(ctx, c.deadline)
+			defer cancelRun()
+			start := c.now()
+			abandon := time.AfterFunc(c.deadline+c.deadlineGrace, func() {
+				c.abandoned(id, scheduled, start)
+				done()
+			})
+			defer abandon.Stop()
+		}
+		c.runAccounted(runCtx, id, job)
+	}
+	group, priority := e.group, e.Priority
+	if trigger.group != nil {
+		group = trigger.group
+	}
+	if trigger.hasPriority {
+		priority = trigger.priority
+	}
+	switch {
+	case c.inline:
+		run()
+	case group != nil:
+		group.submit(groupRun{entry: id, scheduled: scheduled, now: c.now, run: run, drop: func(wait time.Duration) {
+			defer done()
+			defer cancel()
+			c.logger.Info("skip", "entry", id, "scheduled", scheduled,
+				"reason", "group timeout", "group", group.Name(), "wait", wait)
+			c.skipped(id, scheduled, SkipOverloaded, "group timeout", false)
+		}})
+	case c.pool != nil:
+		c.pool.submit(&queuedRun{
+			entry:     id,
+			priority:  priority,
+			run:       run,
+			scheduled: scheduled,
+			maxDelay:  e.maxQueueDelay,
+			drop: func(late time.Duration) {
+				defer done()
+				defer cancel()
+				c.logger.Info("skip", "entry", id, "scheduled", scheduled,
+					"reason", "max queue delay exceeded", "late", late)
+				c.skipped(id, scheduled, SkipOverloaded, "max queue delay exceeded", true)
+			},
+		})
+	default:
+		go run()
+	}
+}
+
+// firstScheduler is implemented by the schedules whose first activation is
+// not their Next after the time they are scheduled: ImmediateDelaySchedule,
+// and the schedules of WithJitter and WithCalendar, which forward to the
+// schedule they wrap.
+type firstScheduler interface {
+	first(now time.Time) time.Time
+}
+
+// firstActivation returns the time at which a newly scheduled entry should
+// first run.
+func firstActivation(schedule Schedule, now time.Time) time.Time {
+	if s, ok := schedule.(firstScheduler); ok {
+		return s.first(now)
+	}
+	return schedule.Next(now)
+}
+
+// startActivation returns the first activation of an entry when the scheduler
+// starts. With WithWarmStart, an entry that has run before resumes from its
+// Prev time, so that an activation due while the scheduler was down is in the
+// past, and fires at once as a misfire.
+func (c *Cron) startActivation(e *Entry, now time.Time) time.Time {
+	if c.warmStart && !e.Prev.IsZero() {
+		if next := e.activeSchedule().Next(e.Prev); !next.IsZero() && next.Before(now) {
+			return next
+		}
+	}
+	return firstActivation(e.activeSchedule(), now)
+}
+
+// stagger delays the first activation of an entry at start by a random amount
+// less than the configured start stagger window, if it is due within that
+// window.
+func (c *Cron) stagger(next, now time.Time) time.Time {
+	if c.staggerIn <= 0 || next.IsZero() || !next.Before(now.Add(c.staggerIn)) {
+		return next
+	}
+	return next.Add(c.rand.Duration(c.staggerIn))
+}
+
+// observe wraps the job submitted for the given entry so that the outcome of
+// each run is recorded in the entry's statistics and reported back to the
+// scheduler. A run fails if the job panics or returns an error, or if the
+// execute func of a PreparedJob returns an error. It is applied inside the
+// Chain, so it sees panics before Recover does.
+func (c *Cron) observe(id EntryID, j Job) Job {
+	return ContextFuncJob(func(ctx context.Context) {
+		var (
+			start     = c.now()
+			scheduled = scheduledFrom(ctx)
+			o         = runOutcome{id: id, failed: true}
+			runErr    error
+		)
+		c.notify(JobStarted{Entry: id, Scheduled: scheduled, Time: start})
+		defer func() {
+			var r interface{}
+			if len(c.listeners) > 0 || c.history != nil {
+				// Report panics, then let them continue to the chain.
+				if r = recover(); r != nil && len(c.listeners) > 0 {
+					c.notify(JobPanicked{Entry: id, Scheduled: scheduled, Time: c.now(),
+						Value: r, Stack: debug.Stack()})
+				}
+			}
+			duration := c.now().Sub(start)
+			c.stats.recordRun(id, start, duration, o.failed)
+			c.recordRun(id, scheduled, start, duration, o.failed, r != nil, runErr)
+			c.awaitAck(ctx, o.failed)
+			c.notify(JobCompleted{Entry: id, Scheduled: scheduled, Time: start,
+				Duration: duration, Failed: o.failed, Err: runErr})
+			c.runDone(o)
+			if r != nil {
+				panic(r)
+			}
+		}()
+		if execute, ok := executeFrom(ctx); ok {
+			if runErr = execute(ctx); runErr != nil {
+				c.logger.Error(runErr, "execute", "entry", id)
+				return
+			}
+		} else if rj, ok := j.(ReschedulingJob); ok {
+			o.next = rj.RunAndReschedule()
+		} else if runErr = RunJobE(ctx, j); runErr != nil {
+			c.logger.Error(runErr, "run", "entry", id)
+			return
+		}
+		o.failed = false
+	})
+}
+
+// runDone reports the outcome of a run to the scheduler.
+func (c *Cron) runDone(o runOutcome) {
+	if c.inline {
+		// The run loop itself is running the job, and will reschedule after
+		// it returns.
+		c.applyOutcome(o, c.now())
+		return
+	}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		c.outcome <- o
+	} else {
+		c.applyOutcome(o, c.now())
+	}
+}
+
+// applyOutcome switches an entry between its normal and degraded schedules
+// according to the outcome of its latest run, and applies the next run time
+// requested by the job, if any. It returns true if the entry's next activation
+// time changed.
+func (c *Cron) applyOutcome(o runOutcome, now time.Time) bool {
+	if o.redispatch != nil {
+		c.redispatch(o.redispatch, now)
+		return false
+	}
+	e := c.store.Get(o.id)
+	if e == nil {
+		return false
+	}
+	changed := false
+	if e.DegradedSchedule != nil && e.Degraded != o.failed {
+		e.Degraded = o.failed
+		if !e.Next.IsZero() {
+			e.Next = c.nextActivation(e, now)
+		}
+		c.store.Update(e)
+		if e.Degraded {
+			c.logger.Info("degraded", "now", now, "entry", e.ID, "next", e.Next)
+		} else {
+			c.logger.Info("recovered", "now", now, "entry", e.ID, "next", e.Next)
+		}
+		changed = true
+	}
+	if c.isolation.record(e.Owner, o.failed) {
+		c.pauseOwner(e.Owner, now)
+		changed = true
+	}
+	if !o.next.IsZero() && !e.Paused && !e.Archived {
+		if !o.next.After(now) {
+			c.logger.Error(errors.New("cron: requested next run is not in the future"),
+				"reschedule", "now", now, "entry", e.ID, "requested", o.next)
+			return changed
+		}
+		e.Next = o.next
+		c.store.Update(e)
+		c.logger.Info("rescheduled", "now", now, "entry", e.ID, "next", e.Next)
+		c.scheduled(e, now)
+		changed = true
+	}
+	return changed
+}
+
+// wakeAt returns the time at which the scheduler should wake to run entries
+// due at the given time: that time rounded up to the configured precision, and
+// no earlier than the wake budget allows.
+func (c *Cron) wakeAt(next time.Time) time.Time {
+	wake := next
+	if c.precision > 0 {
+		wake = next.Truncate(c.precision)
+		if wake.Before(next) {
+			wake = wake.Add(c.precision)
+		}
+	}
+	if c.minWake > 0 && !c.lastWake.IsZero() {
+		if earliest := c.lastWake.Add(c.minWake); wake.Before(earliest) {
+			wake = earliest
+		}
+	}
+	return wake
 }
 
 // now returns current time in c location
 func (c *Cron) now() time.Time {
+	if c.nowFunc != nil {
+		return c.nowFunc().In(c.location)
+	}
 	return time.Now().In(c.location)
 }
 
 // Stop stops the cron scheduler if it is running; otherwise it does nothing.
-// A context is returned so the caller can wait for running jobs to complete.
+// It also cancels the context passed to running jobs (see JobWithContext).
+// Stop returns once the scheduler's run loop has exited, so that it is not
+// running concurrently with the caller; a context is returned so the caller
+// can also wait for running jobs to complete. It is safe to call Stop any
+// number of times, before or after Start.
 func (c *Cron) Stop() context.Context {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
 		c.stop <- struct{}{}
+		<-c.loopDone
 		c.running = false
+		atomic.StoreInt32(&c.idle, 0)
+		c.jobCancel()
+		c.jobCtx = nil
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -335,21 +1206,62 @@ func (c *Cron) Stop() context.Context {
 	return ctx
 }
 
-// entrySnapshot returns a copy of the current cron entry list.
+// WaitStopped blocks until the scheduler's run loop has fully exited after a
+// call to Stop, or the context is done, in which case it returns the
+// context's error. Unlike the context returned by Stop, it does not wait for
+// running jobs to complete. It returns immediately if the cron was never
+// started.
+func (c *Cron) WaitStopped(ctx context.Context) error {
+	c.runningMu.Lock()
+	done := c.loopDone
+	c.runningMu.Unlock()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// entrySnapshot returns a copy of the current cron entry list, sorted by
+// next activation time.
 func (c *Cron) entrySnapshot() []Entry {
-	var entries = make([]Entry, len(c.entries))
-	for i, e := range c.entries {
+	stored := c.store.Entries()
+	sort.Sort(byTime(stored))
+	var entries = make([]Entry, len(stored))
+	for i, e := range stored {
 		entries[i] = *e
+		entries[i].Metadata = copyMetadata(e.Metadata)
 	}
 	return entries
 }
 
-func (c *Cron) removeEntry(id EntryID) {
-	var entries []*Entry
-	for _, e := range c.entries {
-		if e.ID != id {
-			entries = append(entries, e)
-		}
+func (c *Cron) removeEntry(id EntryID, initiator string) {
+	e := c.store.Get(id)
+	if e == nil {
+		return
 	}
-	c.entries = entries
+	c.releaseName(id)
+	if !c.store.Remove(id) {
+		return
+	}
+	c.quota.releaseEntry()
+	c.stats.remove(id)
+	c.history.remove(id)
+	c.emit(Event{Type: EventRemoved, Entry: id, Spec: e.Spec, Initiator: initiator})
+}
+
+// copyMetadata returns a copy of the given metadata map.
+func copyMetadata(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
 }
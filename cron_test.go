@@ -2,6 +2,7 @@ package cron
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -700,3 +701,158 @@ func stop(cron *Cron) chan bool {
 func newWithSeconds() *Cron {
 	return New(WithParser(secondParser), WithChain())
 }
+
+func TestWaitStopped(t *testing.T) {
+	t.Run("never started", func(t *testing.T) {
+		cron := newWithSeconds()
+		if err := cron.WaitStopped(context.Background()); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		cron := newWithSeconds()
+		cron.Start()
+		cron.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := cron.WaitStopped(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("still running", func(t *testing.T) {
+		cron := newWithSeconds()
+		cron.Start()
+		defer cron.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := cron.WaitStopped(ctx); err != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("does not wait for jobs", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		cron := newWithSeconds()
+		cron.AddFunc("* * * * * ?", func() { <-release })
+		cron.Start()
+		time.Sleep(OneSecond)
+		cron.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := cron.WaitStopped(ctx); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// Lifecycle methods may be called in any order without blocking or panicking.
+func TestLifecycleOrdering(t *testing.T) {
+	var calls int64
+	cron := newWithSeconds()
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt64(&calls, 1) })
+
+	cron.Stop()
+	cron.Stop()
+	cron.Start()
+	cron.Start()
+	cron.Stop()
+	cron.Stop()
+	cron.Start()
+	defer cron.Stop()
+
+	<-time.After(OneSecond)
+	if atomic.LoadInt64(&calls) == 0 {
+		t.Error("expected the job to run after restarting")
+	}
+}
+
+func TestAddJobRecordsSpecAndJobName(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("@daily", func() {}, WithJobName("report"))
+	e := cron.Entry(id)
+	if e.Spec != "@daily" || e.JobName != "report" {
+		t.Errorf("expected spec %q and job name %q, got %q and %q", "@daily", "report", e.Spec, e.JobName)
+	}
+	id = cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	if e := cron.Entry(id); e.Spec != "" {
+		t.Errorf("expected no spec for a scheduled job, got %q", e.Spec)
+	}
+}
+
+// Entries loaded by a persistent store have no wrapped job; New must wrap
+// them so that they run and are observed like added entries.
+func TestPreloadedEntriesAreWrapped(t *testing.T) {
+	var ran bool
+	store := NewInMemoryStore()
+	store.Add(&Entry{
+		ID:       5,
+		Schedule: Every(time.Second),
+		Job:      FuncJob(func() { ran = true }),
+	})
+
+	cron := New(WithStore(store))
+	e := cron.Entry(5)
+	if e.WrappedJob == nil {
+		t.Fatal("expected the preloaded entry to be wrapped")
+	}
+	e.WrappedJob.Run()
+	if !ran {
+		t.Error("expected the wrapped job to run the preloaded job")
+	}
+	if id, _ := cron.AddFunc("@daily", func() {}); id != 6 {
+		t.Errorf("expected the next ID to follow the preloaded one, got %d", id)
+	}
+}
+
+func TestAddJobs(t *testing.T) {
+	job := FuncJob(func() {})
+	cron := New(WithQuota(NewQuota(3, 0)))
+	ids, err := cron.AddJobs([]JobSpec{
+		{Name: "a", Spec: "@daily", Job: job},
+		{Spec: "@hourly", Job: job, Options: []EntryOption{WithOwner("ops")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || cron.Entry(ids[0]).Name != "a" || cron.Entry(ids[1]).Owner != "ops" {
+		t.Fatalf("expected both entries to be added, got %v", cron.Entries())
+	}
+
+	for _, jobs := range [][]JobSpec{
+		{{Name: "b", Spec: "@daily", Job: job}, {Spec: "bogus", Job: job}},
+		{{Name: "b", Spec: "@daily", Job: job}, {Name: "a", Spec: "@daily", Job: job}},
+		{{Name: "b", Spec: "@daily", Job: job}, {Name: "c", Spec: "@daily", Job: job}},
+	} {
+		if ids, err := cron.AddJobs(jobs); err == nil {
+			t.Errorf("expected an error, got %v", ids)
+		}
+		if n := len(cron.Entries()); n != 2 {
+			t.Errorf("expected no entries to be added, got %d entries", n)
+		}
+	}
+	// The rejected batches gave back their room and names.
+	if _, err := cron.AddFunc("@daily", func() {}, WithName("b")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddJobsWhileRunning(t *testing.T) {
+	cron := newWithSeconds()
+	cron.Start()
+	defer cron.Stop()
+	ids, err := cron.AddJobs([]JobSpec{
+		{Spec: "0 0 * * * ?", Job: FuncJob(func() {})},
+		{Spec: "0 30 * * * ?", Job: FuncJob(func() {})},
+	})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("expected 2 entries, got %v, %v", ids, err)
+	}
+	for _, id := range ids {
+		if cron.Entry(id).Next.IsZero() {
+			t.Errorf("expected entry %d to be scheduled", id)
+		}
+	}
+}
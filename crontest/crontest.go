@@ -0,0 +1,65 @@
+// Package crontest provides assertions for testing schedules, such as those
+// configured by the users of an application:
+//
+//	func TestReportSchedule(t *testing.T) {
+//		schedule, err := cron.ParseStandard(config.ReportSpec)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+//		crontest.AssertFiresAt(t, schedule, from,
+//			time.Date(2024, time.January, 1, 6, 0, 0, 0, time.UTC),
+//			time.Date(2024, time.January, 2, 6, 0, 0, 0, time.UTC))
+//		crontest.AssertNeverFiresBetween(t, schedule,
+//			time.Date(2024, time.January, 6, 6, 0, 0, 0, time.UTC),
+//			time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC))
+//	}
+package crontest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// timeFormat is the format of times in failure messages.
+const timeFormat = "Mon 2006-01-02 15:04:05 MST"
+
+// AssertFiresAt checks that the activations of the schedule following from
+// are the expected times, in order. A zero expected time asserts that the
+// schedule has no further activations. It reports the first activation that
+// differs.
+func AssertFiresAt(t testing.TB, schedule cron.Schedule, from time.Time, expected ...time.Time) {
+	t.Helper()
+	after := from
+	for i, want := range expected {
+		got := schedule.Next(after)
+		if !got.Equal(want) {
+			t.Errorf("activation %d after %s: expected %s, got %s",
+				i+1, format(from), format(want), format(got))
+			return
+		}
+		if got.IsZero() {
+			return
+		}
+		after = got
+	}
+}
+
+// AssertNeverFiresBetween checks that the schedule has no activation after
+// from and up to to, inclusive.
+func AssertNeverFiresBetween(t testing.TB, schedule cron.Schedule, from, to time.Time) {
+	t.Helper()
+	if next := schedule.Next(from); !next.IsZero() && !next.After(to) {
+		t.Errorf("expected no activation from %s to %s, got %s", format(from), format(to), format(next))
+	}
+}
+
+// format formats t for a failure message.
+func format(t time.Time) string {
+	if t.IsZero() {
+		return "none"
+	}
+	return t.Format(timeFormat)
+}
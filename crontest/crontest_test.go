@@ -0,0 +1,76 @@
+package crontest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// onceSchedule activates once, at the given time.
+type onceSchedule time.Time
+
+func (s onceSchedule) Next(t time.Time) time.Time {
+	if t.Before(time.Time(s)) {
+		return time.Time(s)
+	}
+	return time.Time{}
+}
+
+func TestAssertFiresAt(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 6 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d int) time.Time { return time.Date(2024, time.January, d, 6, 0, 0, 0, time.Local) }
+	from := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.Local)
+
+	AssertFiresAt(t, schedule, from, day(5), day(8), day(9))
+
+	r := &recorder{TB: t}
+	AssertFiresAt(r, schedule, from, day(5), day(6))
+	if len(r.failures) != 1 {
+		t.Errorf("expected a failure for the weekend, got %v", r.failures)
+	}
+}
+
+func TestAssertFiresAtExhausted(t *testing.T) {
+	at := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	AssertFiresAt(t, onceSchedule(at), at.Add(-time.Hour), at, time.Time{})
+
+	r := &recorder{TB: t}
+	AssertFiresAt(r, onceSchedule(at), at.Add(-time.Hour), at, at.Add(time.Hour))
+	if len(r.failures) != 1 {
+		t.Errorf("expected a failure once exhausted, got %v", r.failures)
+	}
+}
+
+func TestAssertNeverFiresBetween(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 6 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saturday := time.Date(2024, time.January, 6, 0, 0, 0, 0, time.Local)
+	monday := time.Date(2024, time.January, 8, 0, 0, 0, 0, time.Local)
+
+	AssertNeverFiresBetween(t, schedule, saturday, monday)
+
+	r := &recorder{TB: t}
+	AssertNeverFiresBetween(r, schedule, saturday, monday.Add(6*time.Hour))
+	if len(r.failures) != 1 {
+		t.Errorf("expected a failure for Monday's activation, got %v", r.failures)
+	}
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// DSTPolicy determines how a daily schedule behaves on days when its time of
+// day does not exist (clocks spring forward past it) or exists twice (clocks
+// fall back over it).
+type DSTPolicy int
+
+const (
+	// DSTRunOnce runs at the moment clocks spring forward if the time of day
+	// does not exist, and only at the first occurrence if it exists twice.
+	DSTRunOnce DSTPolicy = iota
+	// DSTSkipMissing skips days on which the time of day does not exist, and
+	// runs only at the first occurrence if it exists twice.
+	DSTSkipMissing
+	// DSTRunTwice runs at the moment clocks spring forward if the time of day
+	// does not exist, and at both occurrences if it exists twice.
+	DSTRunTwice
+)
+
+// dailySchedule activates once a day at a wall clock time, handling daylight
+// saving transitions according to its policy.
+type dailySchedule struct {
+	hour, minute int
+	loc          *time.Location
+	policy       DSTPolicy
+}
+
+// DailyAt returns a Schedule that activates every day at the given time of day
+// ("15:04" format) in the given location, or time.Local if nil. Unlike the
+// spec "0 2 * * *", the policy makes explicit what happens on days when that
+// time does not exist or exists twice because of daylight saving time.
+//
+// It panics if hhmm is not a valid time of day.
+func DailyAt(hhmm string, loc *time.Location, policy DSTPolicy) Schedule {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		panic(fmt.Sprintf("cron: invalid time of day %q: %v", hhmm, err))
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return dailySchedule{t.Hour(), t.Minute(), loc, policy}
+}
+
+// Next returns the next activation time, later than the given time.
+func (s dailySchedule) Next(t time.Time) time.Time {
+	local := t.In(s.loc)
+	for i := 0; i < 3; i++ {
+		y, m, d := local.AddDate(0, 0, i).Date()
+		for _, at := range s.activations(y, m, d) {
+			if at.After(t) {
+				return at.In(t.Location())
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// activations returns the activations on the given day, in order.
+func (s dailySchedule) activations(year int, month time.Month, day int) []time.Time {
+	at := time.Date(year, month, day, s.hour, s.minute, 0, 0, s.loc)
+	if at.Hour() != s.hour || at.Minute() != s.minute {
+		// The time of day does not exist.
+		if s.policy == DSTSkipMissing {
+			return nil
+		}
+		return []time.Time{s.afterGap(year, month, day)}
+	}
+
+	// The time of day may exist twice, an hour (or other DST offset) apart.
+	var all []time.Time
+	for _, shift := range []time.Duration{-time.Hour, 0, time.Hour} {
+		c := at.Add(shift)
+		if c.Hour() == s.hour && c.Minute() == s.minute && c.Day() == day {
+			all = append(all, c)
+		}
+	}
+	if s.policy != DSTRunTwice {
+		return all[:1]
+	}
+	return all
+}
+
+// afterGap returns the first instant of the given day whose wall clock time is
+// past the schedule's time of day: the moment clocks sprang forward over it.
+func (s dailySchedule) afterGap(year int, month time.Month, day int) time.Time {
+	target := s.hour*60 + s.minute
+	date := year*10000 + int(month)*100 + day
+	past := func(t time.Time) bool {
+		y, m, d := t.Date()
+		if tdate := y*10000 + int(m)*100 + d; tdate != date {
+			return tdate > date
+		}
+		return t.Hour()*60+t.Minute() >= target
+	}
+	lo := time.Date(year, month, day, 0, 0, 0, 0, s.loc).Truncate(time.Minute)
+	hi := lo.Add(26 * time.Hour)
+	for hi.Sub(lo) > time.Minute {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Minute)
+		if past(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyAt(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(month time.Month, day, hour, min int, offset string) time.Time {
+		t := time.Date(2012, month, day, hour, min, 0, 0, ny)
+		if offset == "EST" && t.Format("MST") != "EST" {
+			t = t.Add(time.Hour)
+		}
+		return t
+	}
+
+	tests := []struct {
+		hhmm     string
+		policy   DSTPolicy
+		from     time.Time
+		expected []time.Time
+	}{
+		// An ordinary day.
+		{"02:30", DSTRunOnce, at(time.July, 9, 0, 0, ""), []time.Time{
+			at(time.July, 9, 2, 30, ""), at(time.July, 10, 2, 30, "")}},
+
+		// 02:30 does not exist on Mar 11 2012.
+		{"02:30", DSTRunOnce, at(time.March, 10, 12, 0, ""), []time.Time{
+			at(time.March, 11, 3, 0, ""), at(time.March, 12, 2, 30, "")}},
+		{"02:30", DSTRunTwice, at(time.March, 10, 12, 0, ""), []time.Time{
+			at(time.March, 11, 3, 0, ""), at(time.March, 12, 2, 30, "")}},
+		{"02:30", DSTSkipMissing, at(time.March, 10, 12, 0, ""), []time.Time{
+			at(time.March, 12, 2, 30, "")}},
+
+		// 01:30 exists twice on Nov 4 2012.
+		{"01:30", DSTRunOnce, at(time.November, 3, 12, 0, ""), []time.Time{
+			at(time.November, 4, 1, 30, ""), at(time.November, 5, 1, 30, "")}},
+		{"01:30", DSTSkipMissing, at(time.November, 3, 12, 0, ""), []time.Time{
+			at(time.November, 4, 1, 30, ""), at(time.November, 5, 1, 30, "")}},
+		{"01:30", DSTRunTwice, at(time.November, 3, 12, 0, ""), []time.Time{
+			at(time.November, 4, 1, 30, ""), at(time.November, 4, 1, 30, "EST"), at(time.November, 5, 1, 30, "")}},
+	}
+
+	for _, test := range tests {
+		s := DailyAt(test.hhmm, ny, test.policy)
+		next := test.from
+		for _, expected := range test.expected {
+			next = s.Next(next)
+			if !next.Equal(expected) {
+				t.Errorf("%s, policy %d, from %v: expected %v, got %v",
+					test.hhmm, test.policy, test.from, expected, next)
+				break
+			}
+		}
+	}
+}
+
+func TestDailyAtInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid time of day")
+		}
+	}()
+	DailyAt("25:00", time.UTC, DSTRunOnce)
+}
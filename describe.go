@@ -0,0 +1,302 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Describe returns an English description of the schedule, such as "At 05:30,
+// on Monday through Friday (America/New_York)", e.g. for admin interfaces.
+// Schedules of types defined outside this package are described with their
+// String method if they have one, or as "Custom schedule" otherwise.
+func Describe(schedule Schedule) string {
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		return describeSpec(s)
+	case *quartzSchedule:
+		return describeQuartz(s)
+	case *hashedSchedule:
+		return describeSpec(s.resolved)
+	case ConstantDelaySchedule:
+		return fmt.Sprintf("Every %v", s.Delay)
+	case ImmediateDelaySchedule:
+		return fmt.Sprintf("Every %v, starting immediately", s.Delay)
+	case AnchoredDelaySchedule:
+		return fmt.Sprintf("Every %v from %s", s.Delay, s.Anchor.Format(time.RFC3339))
+	case ShiftedSchedule:
+		if s.Offset < 0 {
+			return fmt.Sprintf("%s, moved %v earlier", Describe(s.Schedule), -s.Offset)
+		}
+		return fmt.Sprintf("%s, moved %v later", Describe(s.Schedule), s.Offset)
+	case OneShotSchedule:
+		return "Once, at " + s.At.Format(time.RFC1123)
+	case dailySchedule:
+		return fmt.Sprintf("At %02d:%02d every day%s", s.hour, s.minute, describeLocation(s.loc))
+	case businessDaySchedule:
+		if s.direction == Backward {
+			return Describe(s.schedule) + ", moved to the previous business day if needed"
+		}
+		return Describe(s.schedule) + ", moved to the next business day if needed"
+	case WindowSchedule:
+		window := fmt.Sprintf("%s, between %s and %s", Describe(s.Schedule),
+			clock(uint(s.Start/time.Hour), uint(s.Start%time.Hour/time.Minute), 0),
+			clock(uint(s.End/time.Hour), uint(s.End%time.Hour/time.Minute), 0))
+		if s.Days != 0 {
+			window += " on " + describeValues(fieldValues(s.Days, dow), dayName)
+		}
+		return window + describeLocation(s.Location)
+	case MonthlyBusinessDaySchedule:
+		at := "At " + clock(uint(s.TimeOfDay/time.Hour), uint(s.TimeOfDay%time.Hour/time.Minute), 0)
+		switch {
+		case s.N == -1:
+			at += ", on the last business day of the month"
+		case s.N < 0:
+			at += fmt.Sprintf(", on the last business day of the month minus %d business days", -s.N-1)
+		default:
+			at += fmt.Sprintf(", on business day %d of the month", s.N)
+		}
+		return at + describeLocation(s.Location)
+	case RepeatingIntervalSchedule:
+		every := fmt.Sprintf("Every %s, starting %s", s.Period, s.Start.Format(time.RFC3339))
+		if s.Repetitions >= 0 {
+			every += fmt.Sprintf(", %d times", s.Repetitions)
+		}
+		return every
+	case calendarSchedule:
+		return Describe(s.schedule) + ", on business days only"
+	case jitterSchedule:
+		return fmt.Sprintf("%s, delayed by up to %v", Describe(s.schedule), s.max)
+	case fmt.Stringer:
+		return s.String()
+	}
+	return "Custom schedule"
+}
+
+// describeParser parses the specs of DescribeSpec that are not Quartz
+// expressions.
+var describeParser = NewParser(
+	SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor | Hashed | ISO8601,
+)
+
+// DescribeSpec parses the spec and describes the resulting schedule. It
+// accepts standard specs with an optional seconds field, descriptors, hashed
+// values and ISO 8601 repeating intervals, as well as Quartz expressions
+// such as "0 30 5 ? * 6L". Specs parsed otherwise may be passed to Describe.
+func DescribeSpec(spec string) (string, error) {
+	schedule, err := describeParser.Parse(spec)
+	if err != nil {
+		quartz, quartzErr := NewParser(Quartz).Parse(spec)
+		if quartzErr != nil {
+			return "", err
+		}
+		schedule = quartz
+	}
+	return Describe(schedule), nil
+}
+
+// describeSpec describes the time of day, days, months and location of s.
+func describeSpec(s *SpecSchedule) string {
+	parts := []string{describeTime(
+		fieldValues(s.Second, seconds), fieldValues(s.Minute, minutes), fieldValues(s.Hour, hours))}
+	if days := describeDays(s); days != "" {
+		parts = append(parts, days)
+	}
+	if !isAll(s.Month, months) {
+		parts = append(parts, "in "+describeValues(fieldValues(s.Month, months), monthName))
+	}
+	return strings.Join(parts, ", ") + describeLocation(s.Location)
+}
+
+// describeTime describes the times of day given by the values of the seconds,
+// minutes and hours fields.
+func describeTime(secs, mins, hrs []uint) string {
+	if len(secs) == 1 && len(mins) == 1 {
+		switch {
+		case len(hrs) <= 6:
+			var times []string
+			for _, h := range hrs {
+				times = append(times, clock(h, mins[0], secs[0]))
+			}
+			return "At " + joinList(times)
+		case len(hrs) == 24 && mins[0] == 0 && secs[0] == 0:
+			return "Every hour"
+		case len(hrs) == 24:
+			return "Every hour at " + describeMinuteSecond(mins[0], secs[0])
+		}
+		if step, ok := everyStep(hrs, hours); ok && mins[0] == 0 && secs[0] == 0 {
+			return fmt.Sprintf("Every %d hours", step)
+		}
+		return fmt.Sprintf("At %s during hours %s",
+			describeMinuteSecond(mins[0], secs[0]), describeValues(hrs, number))
+	}
+
+	var parts []string
+	switch {
+	case len(secs) == 60:
+		parts = append(parts, "every second")
+	case len(secs) == 1 && secs[0] == 0:
+	default:
+		parts = append(parts, describeUnit(secs, seconds, "second"))
+	}
+	switch {
+	case len(mins) == 60 && len(parts) == 0:
+		parts = append(parts, "every minute")
+	case len(mins) == 60:
+	default:
+		parts = append(parts, describeUnit(mins, minutes, "minute"))
+	}
+	switch {
+	case len(hrs) == 24:
+	case len(hrs) == 1:
+		parts = append(parts, fmt.Sprintf("during hour %d", hrs[0]))
+	default:
+		parts = append(parts, "during hours "+describeValues(hrs, number))
+	}
+	s := strings.Join(parts, ", ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// describeUnit describes the values of a seconds or minutes field, e.g. "every
+// 15 minutes" or "at minutes 0 and 30".
+func describeUnit(values []uint, r bounds, unit string) string {
+	if step, ok := everyStep(values, r); ok {
+		return fmt.Sprintf("every %d %ss", step, unit)
+	}
+	if len(values) == 1 {
+		return fmt.Sprintf("at %s %d", unit, values[0])
+	}
+	return fmt.Sprintf("at %ss %s", unit, describeValues(values, number))
+}
+
+// describeMinuteSecond describes a minute and second of the hour.
+func describeMinuteSecond(minute, second uint) string {
+	if second == 0 {
+		return fmt.Sprintf("minute %d", minute)
+	}
+	return fmt.Sprintf("minute %d, second %d", minute, second)
+}
+
+// describeDays describes the days of month and of week of s, if restricted.
+func describeDays(s *SpecSchedule) string {
+	var (
+		onDom = "on " + describeDom(s)
+		onDow = "on " + describeValues(fieldValues(s.Dow, dow), dayName)
+	)
+	// As in dayMatches, a day must match both fields if either is a star,
+	// and either field otherwise.
+	switch {
+	case s.Dom&starBit > 0 && s.Dow&starBit > 0:
+		return ""
+	case s.Dow&starBit > 0:
+		return onDom
+	case s.Dom&starBit > 0:
+		return onDow
+	}
+	return onDom + " or " + onDow
+}
+
+// describeDom describes the days of month of s, e.g. "day 1 of the month and
+// the last day of the month".
+func describeDom(s *SpecSchedule) string {
+	var items []string
+	if values := fieldValues(s.Dom, dom); len(values) > 0 {
+		items = append(items, "day "+describeValues(values, number)+" of the month")
+	}
+	for _, offset := range fieldValues(s.Dom>>lastDomShift, lastDom) {
+		items = append(items, describeLastDay(offset))
+	}
+	return joinList(items)
+}
+
+// describeLastDay describes the day offset days before the last day of the
+// month.
+func describeLastDay(offset uint) string {
+	switch offset {
+	case 0:
+		return "the last day of the month"
+	case 1:
+		return "the last day of the month minus 1 day"
+	}
+	return fmt.Sprintf("the last day of the month minus %d days", offset)
+}
+
+// describeLocation describes the location of a schedule, unless it is local.
+func describeLocation(loc *time.Location) string {
+	if loc == nil || loc == time.Local {
+		return ""
+	}
+	return " (" + loc.String() + ")"
+}
+
+// everyStep returns the step if the values are every step-th value of the
+// field, starting at its minimum, as given by "*/step".
+func everyStep(values []uint, r bounds) (uint, bool) {
+	if len(values) < 2 || values[0] != r.min {
+		return 0, false
+	}
+	step := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+	return step, values[len(values)-1]+step > r.max
+}
+
+// describeValues lists the values, collapsing runs of three or more
+// consecutive values into ranges, e.g. "1, 5 through 9 and 12".
+func describeValues(values []uint, name func(uint) string) string {
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, name(values[i])+" through "+name(values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, name(values[k]))
+			}
+		}
+		i = j + 1
+	}
+	return joinList(items)
+}
+
+// joinList joins the items as an English list, e.g. "a, b and c".
+func joinList(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// fieldValues returns the values set in the bits of a field, in order.
+func fieldValues(bits uint64, r bounds) []uint {
+	var values []uint
+	for v := r.min; v <= r.max; v++ {
+		if bits&(1<<v) > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// isAll returns true if every value of the field is set.
+func isAll(bits uint64, r bounds) bool {
+	return bits&getBits(r.min, r.max, 1) == getBits(r.min, r.max, 1)
+}
+
+// clock formats a time of day, omitting zero seconds.
+func clock(hour, minute, second uint) string {
+	if second == 0 {
+		return fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+}
+
+func number(v uint) string    { return fmt.Sprint(v) }
+func monthName(v uint) string { return time.Month(v).String() }
+func dayName(v uint) string   { return time.Weekday(v).String() }
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribeSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"30 5 * * *", "At 05:30"},
+		{"* * * * *", "Every minute"},
+		{"*/15 * * * *", "Every 15 minutes"},
+		{"15,45 * * * *", "At minutes 15 and 45"},
+		{"@hourly", "Every hour"},
+		{"30 * * * *", "Every hour at minute 30"},
+		{"0 */2 * * *", "Every 2 hours"},
+		{"0 9-17 * * 1-5", "At minute 0 during hours 9 through 17, on Monday through Friday"},
+		{"*/5 9 * * *", "Every 5 minutes, during hour 9"},
+		{"0 0,12 1 */3 *", "At 00:00 and 12:00, on day 1 of the month, in January, April, July and October"},
+		{"0 0 1,15 * 1", "At 00:00, on day 1 and 15 of the month or on Monday"},
+		{"0 0 * 1-3,6 *", "At 00:00, in January through March and June"},
+		{"0 0 L-2 * *", "At 00:00, on the last day of the month minus 2 days"},
+		{"0 0 1,L * *", "At 00:00, on day 1 of the month and the last day of the month"},
+		{"CRON_TZ=America/New_York 30 5 * * 5", "At 05:30, on Friday (America/New_York)"},
+		{"@every 1h30m", "Every 1h30m0s"},
+		{"@hourly between 09:00 and 17:00 on mon-fri", "Every hour, between 09:00 and 17:00 on Monday through Friday"},
+		{"0 30 5 ? * 5L", "At 05:30, on the last Thursday of the month"},
+		{"CRON_TZ=America/New_York 0 30 5 ? * 6L", "At 05:30, on the last Friday of the month (America/New_York)"},
+		{"15 30 5 * * *", "At 05:30:15"},
+		{"R5/2024-01-01T00:00:00Z/PT1H", "Every PT1H, starting 2024-01-01T00:00:00Z, 5 times"},
+	}
+	for _, test := range tests {
+		actual, err := DescribeSpec(test.spec)
+		if err != nil {
+			t.Errorf("%q: %v", test.spec, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.spec, test.expected, actual)
+		}
+	}
+
+	if _, err := DescribeSpec("* * *"); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	secondParser := NewParser(Second | Minute | Hour | Dom | Month | Dow | Descriptor)
+	daily, _ := ParseStandard("0 2 * * *")
+	tests := []struct {
+		schedule Schedule
+		expected string
+	}{
+		{mustParse(t, secondParser, "* * * * * *"), "Every second"},
+		{mustParse(t, secondParser, "*/10 * * * * *"), "Every 10 seconds"},
+		{mustParse(t, secondParser, "15 30 5 * * *"), "At 05:30:15"},
+		{EveryImmediate(time.Minute), "Every 1m0s, starting immediately"},
+		{Shift(daily, 20*time.Minute), "At 02:00, moved 20m0s later"},
+		{DailyAt("02:30", time.UTC, DSTRunOnce), "At 02:30 every day (UTC)"},
+		{ShiftToBusinessDay(daily, NewBusinessCalendar(), Forward),
+			"At 02:00, moved to the next business day if needed"},
+		{NthBusinessDayOfMonth(3, time.UTC), "At 00:00, on business day 3 of the month (UTC)"},
+		{LastBusinessDayOfMonth(time.UTC), "At 00:00, on the last business day of the month (UTC)"},
+		{fixedSchedule{}, "Custom schedule"},
+	}
+	for _, test := range tests {
+		if actual := Describe(test.schedule); actual != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, actual)
+		}
+	}
+}
+
+func mustParse(t *testing.T, p Parser, spec string) Schedule {
+	s, err := p.Parse(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -36,6 +36,11 @@ them in their own goroutines.
 	..
 	c.Stop()  // Stop the scheduler (does not stop any jobs already running).
 
+Jobs that should be interruptible may take a context instead. It is cancelled
+when the Cron is stopped, so that they can return early:
+
+	c.AddFuncCtx("@hourly", func(ctx context.Context) { sync(ctx) })
+
 CRON Expression Format
 
 A cron expression represents a set of times, using 5 space-separated fields.
@@ -46,10 +51,15 @@ A cron expression represents a set of times, using 5 space-separated fields.
 	Hours        | Yes        | 0-23            | * / , -
 	Day of month | Yes        | 1-31            | * / , - ?
 	Month        | Yes        | 1-12 or JAN-DEC | * / , -
-	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ?
+	Day of week  | Yes        | 0-7 or SUN-SAT  | * / , - ?
 
 Month and Day-of-week field values are case insensitive.  "SUN", "Sun", and
-"sun" are equally accepted.
+"sun" are equally accepted.  Names may be used wherever numbers are, including
+in ranges with steps and in lists, as in "JAN-MAR,SEP-NOV" or "MON-FRI/2".
+
+As in Vixie cron, both 0 and 7 mean Sunday in the Day-of-week field, and ranges
+of days of week may cross Sunday: "FRI-MON" and "5-1" both mean Friday through
+Monday.
 
 The specific interpretation of the format is based on the Cron Wikipedia page:
 https://en.wikipedia.org/wiki/Cron
@@ -73,6 +83,15 @@ parser you saw earlier, except that its seconds field is REQUIRED:
 That emulates Quartz, the most popular alternative Cron schedule format:
 http://www.quartz-scheduler.org/documentation/quartz-2.x/tutorials/crontrigger.html
 
+To accept Quartz expressions as written, including the optional year field, days
+of week numbered from 1 (SUN) to 7 (SAT), and the L, W, # and ? characters, use
+the Quartz option:
+
+	cron.New(cron.WithParser(cron.NewParser(cron.Quartz)))
+
+	// 10:15 on the last Friday of every month
+	c.AddFunc("0 15 10 ? * 6L", func() { ... })
+
 Special Characters
 
 Asterisk ( * )
@@ -105,6 +124,29 @@ Question mark ( ? )
 Question mark may be used instead of '*' for leaving either day-of-month or
 day-of-week blank.
 
+L ( L, L-n )
+
+L may be used in the day-of-month field to mean the last day of the month, and
+L-n to mean n days before it. For example, "0 0 L-2 * *" runs two days before
+the end of every month, i.e. on the 29th of a 31-day month and on the 26th or
+27th of February. L may be combined with other days in a list, as in "1,L".
+
+Hash ( H )
+
+H, as in Jenkins, may be used in any field of a spec parsed with the Hashed
+option, to mean a value of the field derived from a hash of the entry's job
+name, name or ID. It spreads jobs sharing a spec over the range of the field,
+rather than running them all at once.
+H(low-high) restricts the value to a range, and H/step picks an offset below
+step: for example, "H/15 * * * *" runs every 15 minutes from the same offset
+every hour. In the day-of-month field, H ranges from 1 to 28. The key may be
+set with WithHashKey, e.g. to spread a fleet of hosts sharing a spec:
+
+	c := cron.New(cron.WithParser(cron.NewParser(
+		cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor | cron.Hashed,
+	)))
+	c.AddFunc("H H(0-5) * * *", backup, cron.WithHashKey(hostname))
+
 Predefined schedules
 
 You may use one of several pre-defined schedules in place of a cron expression.
@@ -130,10 +172,46 @@ where "duration" is a string accepted by time.ParseDuration
 For example, "@every 1h30m10s" would indicate a schedule that activates after
 1 hour, 30 minutes, 10 seconds, and then every interval after that.
 
+To also run the job as soon as it is added or cron is started, follow the
+duration with "immediately", or schedule it with cron.EveryImmediate:
+
+	c.AddFunc("@every 1m immediately", poll)
+	c.Schedule(cron.EveryImmediate(time.Minute), job)
+
+To keep the activations aligned to an anchor, e.g. every 15 minutes from
+midnight UTC whenever the job was added, follow the duration with "from" and
+the anchor in RFC 3339 format, or use cron.EveryFrom:
+
+	c.AddFunc("@every 15m from 2026-01-01T00:00:00Z", export)
+	c.Schedule(cron.EveryFrom(15*time.Minute, midnight), job)
+
 Note: The interval does not take the job runtime into account.  For example,
 if a job takes 3 minutes to run, and it is scheduled to run every 5 minutes,
 it will have only 2 minutes of idle time between each run.
 
+Windows
+
+Any predefined schedule or interval may be restricted to a daily window of
+wall clock time by following it with "between <start> and <end>", and to some
+days of the week by then adding "on <days>", e.g. to poll during business
+hours:
+
+	@every 5m between 09:00 and 17:00 on mon-fri
+
+Both ends of the window are inclusive. An interval starts over each time the
+window opens, so the schedule above first runs at 09:00 every weekday. See
+cron.Between.
+
+ISO 8601
+
+Parsers created with the ISO8601 option also accept ISO 8601 repeating
+intervals, which carry a start, a period and an optional number of
+activations, e.g. five hourly runs:
+
+	R5/2024-01-01T00:00:00Z/PT1H
+
+They may also be parsed directly with cron.ParseISO8601.
+
 Time zones
 
 By default, all interpretation and scheduling is done in the machine's local
@@ -178,6 +256,7 @@ to achieve the following effects:
   - Recover any panics from jobs (activated by default)
   - Delay a job's execution if the previous run hasn't completed yet
   - Skip a job's execution if the previous run hasn't completed yet
+  - Queue a bounded number of a job's executions behind the previous run
   - Log each job's invocations
 
 Install wrappers for all jobs added to a cron using the `cron.WithChain` option:
@@ -192,6 +271,18 @@ Install wrappers for individual jobs by explicitly wrapping them:
 		cron.SkipIfStillRunning(logger),
 	).Then(job)
 
+or, equivalently, by passing them when adding the job:
+
+	c.AddJob(spec, job, cron.WithWrappers(
+		cron.SkipIfStillRunning(logger),
+	))
+
+The Chain of a running Cron may be replaced with SetChain; runs in progress
+complete under the old one.
+
+Wrappers registered by name with RegisterWrapper may also be attached from
+configuration files, using WrappersFromConfig.
+
 Thread safety
 
 Since the Cron service runs concurrently with the calling code, some amount of
@@ -216,16 +307,28 @@ Activate it with a one-off logger as follows:
 		cron.WithLogger(
 			cron.VerbosePrintfLogger(log.New(os.Stdout, "cron: ", log.LstdFlags))))
 
+Dependencies
+
+The cron package depends only on the standard library. Integrations that pull
+in networking or database support (HTTPHealthCheck, SQLOnceStore and SQLStore)
+may be left out of the build with the cronminimal build tag:
+
+	go build -tags cronminimal
+
+The crond daemon, with its HTTP admin interface and metrics, lives in the
+separate cmd/crond package and is never linked into programs that only import
+cron.
+
 
 Implementation
 
-Cron entries are stored in an array, sorted by their next activation time.  Cron
-sleeps until the next job is due to be run.
+Cron entries are kept in a Store (a HeapStore by default; see
+cron.WithStore). Cron sleeps until the next job is due to be run.
 
 Upon waking:
- - it runs each entry that is active on that second
+ - it asks the store for every entry that is due, in any order
+ - it runs those entries, earliest activation time first
  - it calculates the next run times for the jobs that were run
- - it re-sorts the array of entries by next activation time.
  - it goes to sleep until the soonest job.
 */
 package cron
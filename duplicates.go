@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Fingerprinter may be implemented by jobs to identify them for duplicate
+// detection. Two jobs with the same fingerprint are considered to do the same
+// work.
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// FindDuplicates returns groups of entries that are likely to have been
+// registered more than once: their schedules activate at the same times and
+// their jobs share a fingerprint. Each group is sorted by ID and holds at
+// least two entries.
+//
+// Jobs are fingerprinted as follows:
+//   - Jobs implementing Fingerprinter are identified by their fingerprint.
+//   - FuncJobs, ContextFuncJobs, ErrFuncJobs and ContextErrFuncJobs are
+//     identified by their function's code, so two closures created by the
+//     same function literal are treated as the same job.
+//   - Other jobs are identified by value, if they are comparable.
+//
+// Jobs that cannot be fingerprinted are never reported as duplicates.
+func (c *Cron) FindDuplicates() [][]EntryID {
+	entries := c.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	var (
+		groups [][]EntryID
+		seen   = make(map[EntryID]bool)
+	)
+	for i, e := range entries {
+		if seen[e.ID] {
+			continue
+		}
+		fp, ok := jobFingerprint(e.Job)
+		if !ok {
+			continue
+		}
+		group := []EntryID{e.ID}
+		for _, other := range entries[i+1:] {
+			if seen[other.ID] {
+				continue
+			}
+			if ofp, ok := jobFingerprint(other.Job); !ok || ofp != fp {
+				continue
+			}
+			if !equivalentSchedules(e.Schedule, other.Schedule) {
+				continue
+			}
+			seen[other.ID] = true
+			group = append(group, other.ID)
+		}
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// funcFingerprint identifies a func job by its type and the code of its
+// function.
+type funcFingerprint struct {
+	typ  reflect.Type
+	code uintptr
+}
+
+// jobFingerprint returns a comparable value identifying the given job, or
+// false if the job cannot be identified.
+func jobFingerprint(j Job) (interface{}, bool) {
+	switch j := j.(type) {
+	case nil:
+		return nil, false
+	case Fingerprinter:
+		return j.Fingerprint(), true
+	case FuncJob, ContextFuncJob, ErrFuncJob, ContextErrFuncJob:
+		return funcFingerprint{reflect.TypeOf(j), reflect.ValueOf(j).Pointer()}, true
+	}
+	if !reflect.TypeOf(j).Comparable() {
+		return nil, false
+	}
+	return j, true
+}
+
+// equivalentSchedules reports whether the two schedules activate at the same
+// times. Spec schedules are compared after normalization, so that e.g.
+// "@daily" and "0 0 * * *" are equivalent.
+func equivalentSchedules(a, b Schedule) bool {
+	sa, ok := a.(*SpecSchedule)
+	if !ok {
+		return reflect.DeepEqual(a, b)
+	}
+	sb, ok := b.(*SpecSchedule)
+	if !ok {
+		return false
+	}
+	return sa.normalized() == sb.normalized()
+}
+
+// normalizedSpec is a comparable form of a SpecSchedule.
+type normalizedSpec struct {
+	second, minute, hour, dom, month, dow uint64
+	location                              string
+}
+
+// normalized returns the comparable form of the schedule. The star bit is only
+// retained on the day fields, where it affects matching.
+func (s *SpecSchedule) normalized() normalizedSpec {
+	var loc string
+	if s.Location != nil {
+		loc = s.Location.String()
+	}
+	return normalizedSpec{
+		second:   s.Second &^ starBit,
+		minute:   s.Minute &^ starBit,
+		hour:     s.Hour &^ starBit,
+		dom:      s.Dom,
+		month:    s.Month &^ starBit,
+		dow:      s.Dow,
+		location: loc,
+	}
+}
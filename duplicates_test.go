@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type namedJob string
+
+func (namedJob) Run() {}
+
+type fingerprintJob struct {
+	id  string
+	ptr *int
+}
+
+func (j fingerprintJob) Run()                {}
+func (j fingerprintJob) Fingerprint() string { return j.id }
+
+func TestFindDuplicates(t *testing.T) {
+	sendEmails := func() {}
+	cron := New()
+	a, _ := cron.AddFunc("@daily", sendEmails)
+	cron.AddFunc("@hourly", sendEmails)
+	b, _ := cron.AddFunc("0 0 * * *", sendEmails)
+	c, _ := cron.AddJob("*/5 * * * *", namedJob("report"))
+	d, _ := cron.AddJob("0-55/5 * * * *", namedJob("report"))
+	cron.AddJob("*/5 * * * *", namedJob("cleanup"))
+	e := cron.Schedule(Every(time.Minute), fingerprintJob{"sync", new(int)})
+	f := cron.Schedule(Every(time.Minute), fingerprintJob{"sync", new(int)})
+	cron.Schedule(Every(time.Hour), fingerprintJob{"sync", new(int)})
+
+	expected := [][]EntryID{{a, b}, {c, d}, {e, f}}
+	if actual := cron.FindDuplicates(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestFindDuplicatesFuncJobs(t *testing.T) {
+	cron := New()
+	withCtx := func(context.Context) {}
+	withErr := func() error { return nil }
+	withCtxErr := func(context.Context) error { return nil }
+	a := cron.Schedule(Every(time.Minute), ContextFuncJob(withCtx))
+	b := cron.Schedule(Every(time.Minute), ContextFuncJob(withCtx))
+	c := cron.Schedule(Every(time.Minute), ErrFuncJob(withErr))
+	d := cron.Schedule(Every(time.Minute), ErrFuncJob(withErr))
+	e := cron.Schedule(Every(time.Minute), ContextErrFuncJob(withCtxErr))
+	f := cron.Schedule(Every(time.Minute), ContextErrFuncJob(withCtxErr))
+	cron.Schedule(Every(time.Minute), ContextErrFuncJob(func(context.Context) error { return nil }))
+
+	expected := [][]EntryID{{a, b}, {c, d}, {e, f}}
+	if actual := cron.FindDuplicates(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestFindDuplicatesDifferentLocations(t *testing.T) {
+	cron := New()
+	cron.AddJob("CRON_TZ=UTC 0 6 * * *", namedJob("report"))
+	cron.AddJob("CRON_TZ=Asia/Tokyo 0 6 * * *", namedJob("report"))
+	if dups := cron.FindDuplicates(); len(dups) != 0 {
+		t.Errorf("expected no duplicates, got %v", dups)
+	}
+}
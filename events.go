@@ -0,0 +1,82 @@
+package cron
+
+import "time"
+
+// EventType identifies a change to the entries of a Cron.
+type EventType string
+
+// The changes reported to the handler installed with WithEventHandler.
+const (
+	// EventCreated is emitted when an entry is added.
+	EventCreated EventType = "created"
+	// EventUpdated is emitted when the schedule or job of an entry is changed.
+	EventUpdated EventType = "updated"
+	// EventPaused is emitted when an entry is paused.
+	EventPaused EventType = "paused"
+	// EventResumed is emitted when a paused entry is resumed.
+	EventResumed EventType = "resumed"
+	// EventArchived is emitted when an entry is archived.
+	EventArchived EventType = "archived"
+	// EventRestored is emitted when an archived entry is restored.
+	EventRestored EventType = "restored"
+	// EventRemoved is emitted when an entry is removed.
+	EventRemoved EventType = "removed"
+	// EventTriggered is emitted when entries are run on demand, by RunNow or
+	// TriggerMany.
+	EventTriggered EventType = "triggered"
+)
+
+// Initiators of the changes reported in events.
+const (
+	// InitiatorAPI marks changes requested by calling the Cron's methods.
+	InitiatorAPI = "api"
+	// InitiatorScheduler marks changes made by the scheduler itself, e.g.
+	// pausing the entries of an owner under WithOwnerIsolation.
+	InitiatorScheduler = "scheduler"
+)
+
+// Event describes a change to an entry, with enough detail for an external
+// audit or reconciliation system to mirror the cron's schedule without
+// diffing snapshots of its entries.
+type Event struct {
+	// Type is the kind of change.
+	Type EventType
+	// Entry is the ID of the changed entry.
+	Entry EntryID
+	// Entries holds the IDs of the entries changed together by an
+	// operation on several entries, e.g. PauseMany. Entry and Spec are only
+	// set if it changed a single entry.
+	Entries []EntryID
+	// Time is when the change was made.
+	Time time.Time
+	// Spec is the spec of the entry, or "" if it was added with Schedule.
+	Spec string
+	// OldSpec is the spec of the entry before an EventUpdated.
+	OldSpec string
+	// Source is where the entry came from (see WithSource), for
+	// EventCreated.
+	Source string
+	// Reason explains an EventPaused, e.g. "consecutive failures".
+	Reason string
+	// Initiator is InitiatorAPI or InitiatorScheduler.
+	Initiator string
+}
+
+// WithSource records where an entry came from, e.g. "hand", "api" or "file",
+// in the EventCreated emitted for it. See WithEventHandler.
+func WithSource(source string) EntryOption {
+	return func(e *Entry) {
+		e.source = source
+	}
+}
+
+// emit reports the event to the handler, if any.
+func (c *Cron) emit(ev Event) {
+	if c.onEvent == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = c.now()
+	}
+	c.onEvent(ev)
+}
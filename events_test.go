@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithEventHandler(t *testing.T) {
+	var events []Event
+	cron := New(WithEventHandler(func(ev Event) { events = append(events, ev) }))
+
+	id, _ := cron.AddFunc("@daily", func() {}, WithSource("file"), WithOwner("team"))
+	cron.PauseMany([]EntryID{id})
+	cron.ResumeMany([]EntryID{id})
+	cron.Archive(id)
+	cron.Restore(id)
+	cron.pauseOwner("team", time.Now())
+	cron.TriggerMany([]EntryID{id})
+	cron.Remove(id)
+	cron.Remove(id)
+
+	expected := []Event{
+		{Type: EventCreated, Source: "file", Initiator: InitiatorAPI},
+		{Type: EventPaused, Entries: []EntryID{id}, Reason: "requested", Initiator: InitiatorAPI},
+		{Type: EventResumed, Entries: []EntryID{id}, Initiator: InitiatorAPI},
+		{Type: EventArchived, Entries: []EntryID{id}, Initiator: InitiatorAPI},
+		{Type: EventRestored, Entries: []EntryID{id}, Initiator: InitiatorAPI},
+		{Type: EventPaused, Reason: "consecutive failures", Initiator: InitiatorScheduler},
+		{Type: EventTriggered, Entries: []EntryID{id}, Initiator: InitiatorAPI},
+		{Type: EventRemoved, Initiator: InitiatorAPI},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %+v", len(expected), events)
+	}
+	for i, ev := range events {
+		if ev.Entry != id || ev.Spec != "@daily" || ev.Time.IsZero() {
+			t.Errorf("event %d: expected entry %d, spec and time, got %+v", i, id, ev)
+		}
+		ev.Entry, ev.Spec, ev.Time = 0, "", time.Time{}
+		if !reflect.DeepEqual(ev, expected[i]) {
+			t.Errorf("event %d: expected %+v, got %+v", i, expected[i], ev)
+		}
+	}
+}
+
+// ofType returns the events of the given type.
+func ofType(events []Event, typ EventType) []Event {
+	var matched []Event
+	for _, ev := range events {
+		if ev.Type == typ {
+			matched = append(matched, ev)
+		}
+	}
+	return matched
+}
+
+func TestBatchEmitsOneEvent(t *testing.T) {
+	var events []Event
+	cron := New(WithEventHandler(func(ev Event) { events = append(events, ev) }))
+	a, _ := cron.AddFunc("@daily", func() {})
+	b, _ := cron.AddFunc("@hourly", func() {})
+	cron.PauseMany([]EntryID{a, b, b + 1})
+
+	paused := ofType(events, EventPaused)
+	if len(paused) != 1 {
+		t.Fatalf("expected a single event, got %+v", paused)
+	}
+	if ev := paused[0]; !reflect.DeepEqual(ev.Entries, []EntryID{a, b}) || ev.Entry != 0 || ev.Spec != "" {
+		t.Errorf("expected the event to carry entries %d and %d, got %+v", a, b, ev)
+	}
+
+	// Nothing changed, so nothing is reported.
+	cron.PauseMany([]EntryID{a, b})
+	if paused := ofType(events, EventPaused); len(paused) != 1 {
+		t.Errorf("expected no event for a batch that changed nothing, got %+v", paused)
+	}
+}
+
+func TestTriggerManyEmitsOneEvent(t *testing.T) {
+	var events []Event
+	cron := New(WithEventHandler(func(ev Event) { events = append(events, ev) }))
+	a, _ := cron.AddFunc("@daily", func() {})
+	b, _ := cron.AddFunc("@hourly", func() {})
+	c, _ := cron.AddFunc("@weekly", func() {})
+	cron.TriggerMany([]EntryID{a, b, c})
+	cron.jobWaiter.Wait()
+
+	triggered := ofType(events, EventTriggered)
+	if len(triggered) != 1 || !reflect.DeepEqual(triggered[0].Entries, []EntryID{a, b, c}) {
+		t.Errorf("expected a single event listing entries %d, %d and %d, got %+v", a, b, c, triggered)
+	}
+}
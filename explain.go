@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Explanation describes how a schedule arrived at its next activation time.
+type Explanation struct {
+	// From is the time the computation started from.
+	From time.Time
+	// Next is the next activation time, or the zero time if there is none.
+	Next time.Time
+	// Steps lists the adjustments made on the way from From to Next, in order.
+	Steps []ExplanationStep
+}
+
+// ExplanationStep is a single adjustment made while computing an activation
+// time.
+type ExplanationStep struct {
+	// Field is the part of the schedule responsible for the adjustment, e.g.
+	// "month", "day", "hour", "minute", "second", "year", "location" or "dst".
+	Field string
+	// Time is the candidate time after the adjustment.
+	Time time.Time
+	// Reason describes the adjustment.
+	Reason string
+}
+
+// ExplainNext returns the next activation of s after from, along with the steps
+// that led to it: which fields forced the time forward, how day-of-month and
+// day-of-week restrictions were applied, and where daylight saving time
+// changed the result. It is meant for debugging; use Next to schedule.
+//
+// Schedules other than SpecSchedule, ConstantDelaySchedule and ShiftedSchedule
+// are reported with their next time and no steps.
+func ExplainNext(s Schedule, from time.Time) Explanation {
+	e := Explanation{From: from}
+	e.Next = e.explain(s, from)
+	return e
+}
+
+func (e *Explanation) explain(s Schedule, from time.Time) time.Time {
+	switch s := s.(type) {
+	case *SpecSchedule:
+		return s.next(from, e)
+	case ConstantDelaySchedule:
+		next := s.Next(from)
+		e.add("delay", next, "fixed delay of %v", s.Delay)
+		return next
+	case ImmediateDelaySchedule:
+		return e.explain(s.ConstantDelaySchedule, from)
+	case AnchoredDelaySchedule:
+		next := s.Next(from)
+		e.add("delay", next, "every %v from %s", s.Delay, s.Anchor.Format(time.RFC3339))
+		return next
+	case ShiftedSchedule:
+		next := e.explain(s.Schedule, from.Add(-s.Offset))
+		if next.IsZero() {
+			return next
+		}
+		next = next.Add(s.Offset)
+		e.add("offset", next, "shifted by %v", s.Offset)
+		return next
+	}
+	return s.Next(from)
+}
+
+func (e *Explanation) add(field string, t time.Time, format string, args ...interface{}) {
+	e.Steps = append(e.Steps, ExplanationStep{
+		Field:  field,
+		Time:   t,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// String formats the explanation as one line per step, followed by the result.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "from %s\n", e.From.Format(time.RFC3339))
+	for _, step := range e.Steps {
+		fmt.Fprintf(&b, "  %-8s %s  %s\n", step.Field, step.Time.Format(time.RFC3339), step.Reason)
+	}
+	if e.Next.IsZero() {
+		b.WriteString("next: never\n")
+	} else {
+		fmt.Fprintf(&b, "next: %s\n", e.Next.Format(time.RFC3339))
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplainNext(t *testing.T) {
+	s, _ := ParseStandard("0 3 15 * *")
+	e := ExplainNext(s, getTime("Mon Jul 9 23:00 2012"))
+	if expected := getTime("Sun Jul 15 03:00 2012"); !e.Next.Equal(expected) {
+		t.Errorf("expected next %v, got %v", expected, e.Next)
+	}
+	if !e.Next.Equal(s.Next(e.From)) {
+		t.Errorf("explanation disagrees with Next: %v != %v", e.Next, s.Next(e.From))
+	}
+	fields := explainedFields(e)
+	if fields != "day hour" {
+		t.Errorf("expected day and hour steps, got %q\n%s", fields, e)
+	}
+	if reason := e.Steps[0].Reason; !strings.Contains(reason, "day of month 9 not in schedule") {
+		t.Errorf("unexpected reason: %s", reason)
+	}
+}
+
+func TestExplainNextDomOrDow(t *testing.T) {
+	s, _ := ParseStandard("0 0 1 * MON")
+	e := ExplainNext(s, getTime("Tue Jul 10 00:00 2012"))
+	if expected := getTime("Mon Jul 16 00:00 2012"); !e.Next.Equal(expected) {
+		t.Errorf("expected next %v, got %v", expected, e.Next)
+	}
+	if !strings.Contains(e.Steps[0].Reason, "either one suffices") {
+		t.Errorf("expected the day step to explain DOM/DOW semantics, got\n%s", e)
+	}
+}
+
+func TestExplainNextDST(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	s, _ := ParseStandard("30 2 * * *")
+	e := ExplainNext(s, time.Date(2012, 3, 11, 0, 0, 0, 0, ny))
+	if !e.Next.Equal(s.Next(e.From)) {
+		t.Errorf("explanation disagrees with Next: %v != %v", e.Next, s.Next(e.From))
+	}
+	if !strings.Contains(explainedFields(e), "dst") {
+		t.Errorf("expected a dst step, got\n%s", e)
+	}
+}
+
+func TestExplainNextOtherSchedules(t *testing.T) {
+	from := getTime("Mon Jul 9 23:00 2012")
+	s, _ := ParseStandard("0 0 * * *")
+	e := ExplainNext(Shift(s, time.Hour), from)
+	if expected := getTime("Tue Jul 10 01:00 2012"); !e.Next.Equal(expected) {
+		t.Errorf("expected next %v, got %v", expected, e.Next)
+	}
+	if fields := explainedFields(e); fields != "day offset" {
+		t.Errorf("unexpected steps %q\n%s", fields, e)
+	}
+
+	never, _ := ParseStandard("0 0 30 2 *")
+	if e := ExplainNext(never, from); !e.Next.IsZero() || !strings.Contains(e.String(), "never") {
+		t.Errorf("expected no next time, got\n%s", e)
+	}
+}
+
+func explainedFields(e Explanation) string {
+	var fields []string
+	for _, step := range e.Steps {
+		fields = append(fields, step.Field)
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// entryJSON is the JSON form of an Entry.
+type entryJSON struct {
+	ID               EntryID           `json:"id"`
+	Name             string            `json:"name,omitempty"`
+	Spec             string            `json:"spec,omitempty"`
+	Schedule         json.RawMessage   `json:"schedule,omitempty"`
+	DegradedSchedule json.RawMessage   `json:"degraded_schedule,omitempty"`
+	Degraded         bool              `json:"degraded,omitempty"`
+	Next             time.Time         `json:"next"`
+	Prev             time.Time         `json:"prev"`
+	JobName          string            `json:"job_name,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Paused           bool              `json:"paused,omitempty"`
+	Archived         bool              `json:"archived,omitempty"`
+	Owner            string            `json:"owner,omitempty"`
+	Priority         int               `json:"priority,omitempty"`
+	Payload          []byte            `json:"payload,omitempty"`
+	PayloadKey       string            `json:"payload_key,omitempty"`
+}
+
+// MarshalJSON encodes the entry's exported fields other than its jobs, with
+// its schedules encoded as MarshalSchedule does. Schedules that are not
+// bundled with this package are left out; ExportJSON rejects them instead.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	v, _ := e.toJSON(false)
+	return json.Marshal(v)
+}
+
+// toJSON returns the JSON form of the entry. If strict is true, schedules
+// that cannot be encoded are an error; otherwise they are left out.
+func (e Entry) toJSON(strict bool) (entryJSON, error) {
+	v := entryJSON{
+		ID:          e.ID,
+		Name:        e.Name,
+		Spec:        e.Spec,
+		Degraded:    e.Degraded,
+		Next:        e.Next,
+		Prev:        e.Prev,
+		JobName:     e.JobName,
+		Description: e.Description,
+		Metadata:    e.Metadata,
+		Paused:      e.Paused,
+		Archived:    e.Archived,
+		Owner:       e.Owner,
+		Priority:    e.Priority,
+		Payload:     e.Payload,
+		PayloadKey:  e.PayloadKey,
+	}
+	var err error
+	if v.Schedule, err = MarshalSchedule(e.Schedule); err != nil && strict {
+		return entryJSON{}, fmt.Errorf("cron: entry %d: %v", e.ID, err)
+	}
+	if e.DegradedSchedule != nil {
+		if v.DegradedSchedule, err = MarshalSchedule(e.DegradedSchedule); err != nil && strict {
+			return entryJSON{}, fmt.Errorf("cron: entry %d: %v", e.ID, err)
+		}
+	}
+	return v, nil
+}
+
+// UnmarshalJSON decodes an entry encoded by MarshalJSON. Its jobs are left
+// nil; ImportJSON resolves them by job name. Its Schedule is nil if it was
+// left out.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var v entryJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var schedule, degraded Schedule
+	var err error
+	if len(v.Schedule) > 0 {
+		if schedule, err = UnmarshalSchedule(v.Schedule); err != nil {
+			return fmt.Errorf("cron: entry %d: %v", v.ID, err)
+		}
+	}
+	if len(v.DegradedSchedule) > 0 {
+		if degraded, err = UnmarshalSchedule(v.DegradedSchedule); err != nil {
+			return fmt.Errorf("cron: entry %d: %v", v.ID, err)
+		}
+	}
+	*e = Entry{
+		ID:               v.ID,
+		Name:             v.Name,
+		Spec:             v.Spec,
+		Schedule:         schedule,
+		DegradedSchedule: degraded,
+		Degraded:         v.Degraded,
+		Next:             v.Next,
+		Prev:             v.Prev,
+		JobName:          v.JobName,
+		Description:      v.Description,
+		Metadata:         v.Metadata,
+		Paused:           v.Paused,
+		Archived:         v.Archived,
+		Owner:            v.Owner,
+		Priority:         v.Priority,
+		Payload:          v.Payload,
+		PayloadKey:       v.PayloadKey,
+	}
+	return nil
+}
+
+// ExportJSON encodes all entries of the cron, including archived ones, as a
+// JSON array wrapped as versioned StateKindEntries state (see MarshalState),
+// e.g. to save them to configuration. ImportJSON adds them back. Unlike
+// Entry.MarshalJSON, it fails if an entry has a schedule that is not bundled
+// with this package, since it could not be imported.
+func (c *Cron) ExportJSON() ([]byte, error) {
+	entries := c.allEntries()
+	exported := make([]entryJSON, 0, len(entries))
+	for _, e := range entries {
+		v, err := e.toJSON(true)
+		if err != nil {
+			return nil, err
+		}
+		exported = append(exported, v)
+	}
+	return MarshalState(StateKindEntries, exported)
+}
+
+// ImportJSON adds the entries encoded by ExportJSON, resolving their jobs by
+// job name (see WithJobName) in jobs, and returns their IDs, in order. The
+// entries are given new IDs; their other exported fields are kept, except
+// for Next, which is computed again, and Degraded. Paused and archived entries
+// are paused or archived once added.
+//
+// Exports written by older versions of the package are migrated, and a bare
+// JSON array is read as an unversioned export. The data is decoded and every
+// job resolved before any entry is added. An entry may still be rejected when
+// added, e.g. by a quota or because its name is taken, in which case the
+// entries added before it are removed. Either way, an import that fails adds
+// nothing.
+func (c *Cron) ImportJSON(data []byte, jobs map[string]Job) ([]EntryID, error) {
+	var entries []Entry
+	if err := UnmarshalState(StateKindEntries, data, &entries); err != nil {
+		return nil, err
+	}
+	for i, e := range entries {
+		if e.Schedule == nil {
+			return nil, fmt.Errorf("cron: entry %d: no schedule", e.ID)
+		}
+		job, ok := jobs[e.JobName]
+		if !ok {
+			return nil, fmt.Errorf("cron: entry %d: unknown job %q", e.ID, e.JobName)
+		}
+		entries[i].Job = job
+	}
+
+	var ids, paused []EntryID
+	for _, e := range entries {
+		imported := e
+		opts := []EntryOption{func(added *Entry) {
+			added.Name = imported.Name
+			added.DegradedSchedule = imported.DegradedSchedule
+			added.Prev = imported.Prev
+			added.JobName = imported.JobName
+			added.Description = imported.Description
+			added.Metadata = copyMetadata(imported.Metadata)
+			added.Owner = imported.Owner
+			added.Priority = imported.Priority
+			added.Payload = imported.Payload
+			added.PayloadKey = imported.PayloadKey
+			if s, ok := imported.Schedule.(*hashedSchedule); ok {
+				added.hashKey = s.key
+			}
+		}}
+		id, err := c.schedule(e.Spec, e.Schedule, e.Job, opts)
+		if err != nil {
+			for _, id := range ids {
+				c.Remove(id)
+			}
+			return nil, fmt.Errorf("cron: entry %d: %v", e.ID, err)
+		}
+		ids = append(ids, id)
+		if e.Paused {
+			paused = append(paused, id)
+		}
+		if e.Archived {
+			c.Archive(id)
+		}
+	}
+	if len(paused) > 0 {
+		c.PauseMany(paused)
+	}
+	return ids, nil
+}
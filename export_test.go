@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportJSON(t *testing.T) {
+	job := FuncJob(func() {})
+	src := New()
+	src.AddFunc("30 5 * * *", job, WithJobName("report"), WithName("daily report"),
+		WithMetadata(map[string]string{"team": "finance"}), WithOwner("finance"), WithPriority(3))
+	src.Schedule(Every(time.Minute), job, WithJobName("poll"),
+		WithDegradedSchedule(Every(10*time.Second)))
+	paused := src.Schedule(At(time.Now().Add(time.Hour)), job, WithJobName("reminder"))
+	src.PauseMany([]EntryID{paused})
+
+	data, err := src.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := New()
+	ids, err := dst.ImportJSON(data, map[string]Job{"report": job, "poll": job, "reminder": job})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("expected 3 entries, got %v", ids)
+	}
+	originals := make(map[string]Entry)
+	for _, e := range src.Entries() {
+		originals[e.JobName] = e
+	}
+	for _, id := range ids {
+		imported := dst.Entry(id)
+		original := originals[imported.JobName]
+		if imported.Name != original.Name || imported.Spec != original.Spec ||
+			imported.Owner != original.Owner || imported.Priority != original.Priority ||
+			imported.Paused != original.Paused || imported.Metadata["team"] != original.Metadata["team"] ||
+			Describe(imported.Schedule) != Describe(original.Schedule) ||
+			(imported.DegradedSchedule == nil) != (original.DegradedSchedule == nil) {
+			t.Errorf("expected %+v, got %+v", original, imported)
+		}
+	}
+}
+
+func TestImportJSONUnknownJob(t *testing.T) {
+	src := New()
+	src.AddFunc("@hourly", func() {}, WithJobName("a"))
+	src.AddFunc("@daily", func() {}, WithJobName("b"))
+	data, _ := src.ExportJSON()
+
+	dst := New()
+	if _, err := dst.ImportJSON(data, map[string]Job{"a": FuncJob(func() {})}); err == nil ||
+		!strings.Contains(err.Error(), `unknown job "b"`) {
+		t.Errorf("expected an unknown job error, got %v", err)
+	}
+	if entries := dst.Entries(); len(entries) != 0 {
+		t.Errorf("expected no entries to be added, got %v", entries)
+	}
+}
+
+func TestImportJSONRejectedEntry(t *testing.T) {
+	src := New()
+	src.AddFunc("@hourly", func() {}, WithJobName("a"), WithName("a"))
+	src.AddFunc("@daily", func() {}, WithJobName("b"), WithName("b"))
+	data, _ := src.ExportJSON()
+
+	dst := New()
+	existing, _ := dst.AddFunc("@daily", func() {}, WithName("b"))
+	ids, err := dst.ImportJSON(data, map[string]Job{"a": FuncJob(func() {}), "b": FuncJob(func() {})})
+	if err == nil || ids != nil {
+		t.Errorf("expected the taken name to fail the import, got %v, %v", ids, err)
+	}
+	if entries := dst.Entries(); len(entries) != 1 || entries[0].ID != existing {
+		t.Errorf("expected the imported entries to be removed, got %v", entries)
+	}
+}
+
+func TestExportJSONCustomSchedule(t *testing.T) {
+	c := New()
+	id := c.Schedule(fixedSchedule{}, FuncJob(func() {}), WithName("custom"))
+	if _, err := c.ExportJSON(); err == nil {
+		t.Error("expected an error")
+	}
+
+	// Marshaling the entry itself leaves the schedule out.
+	data, err := json.Marshal(c.Entry(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Entry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name != "custom" || decoded.Schedule != nil {
+		t.Errorf("expected the entry without its schedule, got %+v", decoded)
+	}
+	if _, err := New().ImportJSON([]byte("["+string(data)+"]"), nil); err == nil {
+		t.Error("expected an error importing an entry without a schedule")
+	}
+}
+
+func TestImportJSONVersion(t *testing.T) {
+	src := New()
+	src.AddFunc("@hourly", func() {}, WithJobName("a"))
+	data, _ := src.ExportJSON()
+	if !strings.Contains(string(data), `"version":1,"kind":"entries"`) {
+		t.Errorf("expected a versioned export, got %s", data)
+	}
+	jobs := map[string]Job{"a": FuncJob(func() {})}
+
+	// An unversioned export is a bare array.
+	legacy := `[{"id":1,"spec":"@hourly","schedule":{"type":"spec","spec":"@hourly"},"job_name":"a"}]`
+	if ids, err := New().ImportJSON([]byte(legacy), jobs); err != nil || len(ids) != 1 {
+		t.Errorf("expected the unversioned export to be imported, got %v, %v", ids, err)
+	}
+
+	newer := `{"version":99,"kind":"entries","data":[]}`
+	_, err := New().ImportJSON([]byte(newer), jobs)
+	if verr, ok := err.(*StateVersionError); !ok || verr.Version != 99 {
+		t.Errorf("expected a *StateVersionError, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FanOut is a Job that runs several target jobs concurrently under a single
+// entry, e.g. refreshing a cache in each region, and reports the outcome of
+// each target. See FanOutJob.
+//
+// A target fails if it panics, if it returns an error (see ErrorJob) or, if it
+// is a PreparedJob (e.g. a PrepareFunc), if Prepare or its execute func
+// returns an error. The run of
+// the FanOut fails, with a *FanOutError, if any target fails.
+type FanOut struct {
+	targets     []Job
+	concurrency int
+	failFast    bool
+
+	// OnTarget, if set, is called with the outcome of each target as soon as
+	// it is known, including targets skipped by failFast. It may be called
+	// concurrently.
+	OnTarget func(ctx context.Context, result TargetResult)
+
+	mu   sync.Mutex
+	last []TargetResult
+}
+
+// TargetResult is the outcome of a target of a FanOut in one run.
+type TargetResult struct {
+	// Index is the position of the target in the FanOut's targets.
+	Index int
+	// Name is the target's String(), if it implements fmt.Stringer.
+	Name string
+	// Err is why the target failed, or nil if it succeeded.
+	Err error
+	// Skipped is true if the target was not started, because another target
+	// failed with failFast set or the run's context was done.
+	Skipped bool
+	// Duration is how long the target ran.
+	Duration time.Duration
+}
+
+// FanOutJob returns a FanOut running the given targets, at most concurrency
+// at a time (all at once if concurrency is not positive). If failFast is set,
+// the first failure cancels the context of the targets still running and
+// skips those not started yet.
+func FanOutJob(targets []Job, concurrency int, failFast bool) *FanOut {
+	return &FanOut{targets: targets, concurrency: concurrency, failFast: failFast}
+}
+
+// Run runs the targets with context.Background().
+func (f *FanOut) Run() {
+	f.run(context.Background())
+}
+
+// Prepare returns the execute func of a run, which returns a *FanOutError if
+// any target fails. See PreparedJob.
+func (f *FanOut) Prepare(ctx context.Context) (func(ctx context.Context) error, error) {
+	return f.run, nil
+}
+
+// LastResults returns the outcomes of the targets in the latest completed run,
+// in the order of the targets.
+func (f *FanOut) LastResults() []TargetResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]TargetResult(nil), f.last...)
+}
+
+func (f *FanOut) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	n := f.concurrency
+	if n <= 0 || n > len(f.targets) {
+		n = len(f.targets)
+	}
+	var (
+		results = make([]TargetResult, len(f.targets))
+		slots   = make(chan struct{}, n)
+		wg      sync.WaitGroup
+	)
+	for i, target := range f.targets {
+		results[i] = TargetResult{Index: i}
+		if s, ok := target.(fmt.Stringer); ok {
+			results[i].Name = s.String()
+		}
+		acquired := false
+		select {
+		case slots <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			if acquired {
+				<-slots
+			}
+			results[i].Err, results[i].Skipped = ctx.Err(), true
+			f.report(ctx, results[i])
+			continue
+		}
+		wg.Add(1)
+		go func(result *TargetResult, target Job) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			start := time.Now()
+			result.Err = runTarget(ctx, target)
+			result.Duration = time.Since(start)
+			if result.Err != nil && f.failFast {
+				cancel()
+			}
+			f.report(ctx, *result)
+		}(&results[i], target)
+	}
+	wg.Wait()
+
+	f.mu.Lock()
+	f.last = results
+	f.mu.Unlock()
+	for _, r := range results {
+		if r.Err != nil {
+			return &FanOutError{Results: results}
+		}
+	}
+	return nil
+}
+
+// report passes the outcome of a target to the OnTarget hook, if any.
+func (f *FanOut) report(ctx context.Context, result TargetResult) {
+	if f.OnTarget != nil {
+		f.OnTarget(ctx, result)
+	}
+}
+
+// runTarget runs a target of a FanOut, turning panics into errors.
+func runTarget(ctx context.Context, target Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	if pj, ok := target.(PreparedJob); ok {
+		execute, err := pj.Prepare(ctx)
+		if err != nil {
+			return err
+		}
+		return execute(ctx)
+	}
+	return RunJobE(ctx, target)
+}
+
+// FanOutError is the error of a run of a FanOut in which some targets failed.
+type FanOutError struct {
+	// Results holds the outcomes of all targets, in order.
+	Results []TargetResult
+}
+
+// Failed returns the outcomes of the targets that failed or were skipped.
+func (e *FanOutError) Failed() []TargetResult {
+	var failed []TargetResult
+	for _, r := range e.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func (e *FanOutError) Error() string {
+	var (
+		failed  = e.Failed()
+		details []string
+	)
+	for _, r := range failed {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("target %d", r.Index)
+		}
+		details = append(details, fmt.Sprintf("%s: %v", name, r.Err))
+	}
+	return fmt.Sprintf("cron: %d of %d targets failed: %s",
+		len(failed), len(e.Results), strings.Join(details, "; "))
+}
@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// region is a fan-out target that fails with err.
+type region struct {
+	name string
+	err  error
+}
+
+func (r region) String() string { return r.name }
+
+func (r region) Run() {}
+
+func (r region) Prepare(ctx context.Context) (func(context.Context) error, error) {
+	return func(context.Context) error { return r.err }, nil
+}
+
+func TestFanOutJob(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		reported = make(map[string]error)
+	)
+	fan := FanOutJob([]Job{
+		region{name: "us"},
+		region{name: "eu", err: errors.New("unreachable")},
+		FuncJob(func() { panic("boom") }),
+	}, 2, false)
+	fan.OnTarget = func(_ context.Context, r TargetResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported[r.Name] = r.Err
+	}
+
+	var buf syncWriter
+	cron := New(WithChain(), WithLogger(PrintfLogger(log.New(&buf, "", 0))))
+	id, _ := cron.AddJob("@every 1h", fan)
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+
+	if len(reported) != 3 || reported["us"] != nil || reported["eu"] == nil {
+		t.Errorf("expected an outcome for each target, got %v", reported)
+	}
+	results := fan.LastResults()
+	if len(results) != 3 || results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "boom") {
+		t.Errorf("expected the panic to fail the third target, got %+v", results)
+	}
+	if s := cron.StatsSnapshot()[id]; !s.LastFailed {
+		t.Error("expected the run to fail")
+	}
+	if out := buf.String(); !strings.Contains(out, "2 of 3 targets failed: eu: unreachable; target 2: panic: boom") {
+		t.Errorf("expected the failed targets to be logged, got %q", out)
+	}
+}
+
+func TestFanOutJobFailFast(t *testing.T) {
+	var ran []string
+	target := func(name string, err error) Job {
+		return PrepareFunc(func(context.Context) (func(context.Context) error, error) {
+			return func(context.Context) error {
+				ran = append(ran, name)
+				return err
+			}, nil
+		})
+	}
+	fan := FanOutJob([]Job{
+		target("a", nil),
+		target("b", errors.New("failed")),
+		target("c", nil),
+	}, 1, true)
+
+	execute, _ := fan.Prepare(context.Background())
+	err := execute(context.Background())
+	fanErr, ok := err.(*FanOutError)
+	if !ok {
+		t.Fatalf("expected a FanOutError, got %v", err)
+	}
+	if strings.Join(ran, ",") != "a,b" {
+		t.Errorf("expected the targets after the failure not to run, got %v", ran)
+	}
+	if failed := fanErr.Failed(); len(failed) != 2 || !failed[1].Skipped {
+		t.Errorf("expected the last target to be skipped, got %+v", failed)
+	}
+}
@@ -0,0 +1,177 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// Group serializes the runs of its entries: they run one at a time, in the
+// order in which they became due, even if their activation times coincide.
+// This suits pipelines whose stages are registered as separate entries but
+// must never interleave, e.g. ETL jobs.
+//
+// Waiting runs are queued by scheduled time, first in first out, so that an
+// entry that fires often cannot keep a rarely firing one waiting: a daily run
+// due at 03:00 starts before any run scheduled after 03:00. The time runs spend
+// waiting is reported by Stats.
+//
+// Runs of grouped entries do not use the worker pool.
+type Group struct {
+	name    string
+	timeout time.Duration
+
+	mu    sync.Mutex
+	queue []groupRun
+	busy  bool
+	stats GroupStats
+}
+
+// groupRun is a run waiting for its turn in a Group.
+type groupRun struct {
+	entry     EntryID
+	scheduled time.Time
+	enqueued  time.Time
+	now       func() time.Time // the clock of the Cron that submitted the run
+	run       func()
+	drop      func(wait time.Duration)
+}
+
+// GroupStats holds cumulative statistics about the runs of a Group, in
+// particular how long they waited for their turn. A MaxWait or OldestWait that
+// keeps growing points to entries starved by others.
+type GroupStats struct {
+	// Runs is the number of runs started.
+	Runs uint64 `json:"runs"`
+	// Dropped is the number of runs skipped because they waited longer than
+	// the group's timeout.
+	Dropped uint64 `json:"dropped"`
+	// Queued is the number of runs currently waiting.
+	Queued int `json:"queued"`
+	// OldestWait is how long the run at the head of the queue has been
+	// waiting, or zero if none is.
+	OldestWait time.Duration `json:"oldest_wait_ns"`
+	// TotalWait and MaxWait are the total and longest time that started or
+	// dropped runs spent waiting.
+	TotalWait time.Duration `json:"total_wait_ns"`
+	MaxWait   time.Duration `json:"max_wait_ns"`
+	// Entries holds the statistics of each entry of the group, keyed by ID.
+	Entries map[EntryID]GroupEntryStats `json:"entries,omitempty"`
+}
+
+// GroupEntryStats holds the statistics of the runs of one entry in a Group.
+type GroupEntryStats struct {
+	Runs      uint64        `json:"runs"`
+	Dropped   uint64        `json:"dropped"`
+	TotalWait time.Duration `json:"total_wait_ns"`
+	MaxWait   time.Duration `json:"max_wait_ns"`
+}
+
+// NewGroup returns a new Group with the given name. If timeout is positive,
+// runs that have waited longer than timeout for the preceding runs of the
+// group to complete are skipped rather than started late.
+func NewGroup(name string, timeout time.Duration) *Group {
+	return &Group{name: name, timeout: timeout}
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// InGroup adds the entry to the given group.
+func InGroup(g *Group) EntryOption {
+	return func(e *Entry) {
+		e.group = g
+	}
+}
+
+// Stats returns the statistics of the group.
+func (g *Group) Stats() GroupStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := g.stats
+	s.Queued = len(g.queue)
+	if len(g.queue) > 0 {
+		// The queue is ordered by scheduled time, not by waiting time.
+		oldest := g.queue[0]
+		for _, r := range g.queue[1:] {
+			if r.enqueued.Before(oldest.enqueued) {
+				oldest = r
+			}
+		}
+		s.OldestWait = oldest.now().Sub(oldest.enqueued)
+	}
+	s.Entries = make(map[EntryID]GroupEntryStats, len(g.stats.Entries))
+	for id, es := range g.stats.Entries {
+		s.Entries[id] = es
+	}
+	return s
+}
+
+// submit queues a run after the runs scheduled at or before it, starting a
+// goroutine to work through the queue if none is active.
+func (g *Group) submit(r groupRun) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	r.enqueued = r.now()
+	i := len(g.queue)
+	for i > 0 && r.scheduled.Before(g.queue[i-1].scheduled) {
+		i--
+	}
+	g.queue = append(g.queue, groupRun{})
+	copy(g.queue[i+1:], g.queue[i:])
+	g.queue[i] = r
+	if !g.busy {
+		g.busy = true
+		go g.work()
+	}
+}
+
+// record counts a run of the given entry that waited for wait before it was
+// started or, if dropped is true, skipped. The group must be locked.
+func (g *Group) record(id EntryID, wait time.Duration, dropped bool) {
+	if g.stats.Entries == nil {
+		g.stats.Entries = make(map[EntryID]GroupEntryStats)
+	}
+	es := g.stats.Entries[id]
+	if dropped {
+		g.stats.Dropped++
+		es.Dropped++
+	} else {
+		g.stats.Runs++
+		es.Runs++
+	}
+	g.stats.TotalWait += wait
+	es.TotalWait += wait
+	if wait > g.stats.MaxWait {
+		g.stats.MaxWait = wait
+	}
+	if wait > es.MaxWait {
+		es.MaxWait = wait
+	}
+	g.stats.Entries[id] = es
+}
+
+// work runs queued runs one at a time until the queue is empty.
+func (g *Group) work() {
+	for {
+		g.mu.Lock()
+		if len(g.queue) == 0 {
+			g.busy = false
+			g.mu.Unlock()
+			return
+		}
+		r := g.queue[0]
+		g.queue = g.queue[1:]
+		wait := r.now().Sub(r.enqueued)
+		dropped := g.timeout > 0 && wait > g.timeout
+		g.record(r.entry, wait, dropped)
+		g.mu.Unlock()
+
+		if dropped {
+			r.drop(wait)
+			continue
+		}
+		r.run()
+	}
+}
@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupSerializesRuns(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+		wg     sync.WaitGroup
+		group  = NewGroup("etl", 0)
+		cron   = New(WithParser(secondParser), WithChain())
+		stage  = func(name string) func() {
+			return func() {
+				defer wg.Done()
+				mu.Lock()
+				events = append(events, name+" start")
+				mu.Unlock()
+				time.Sleep(50 * time.Millisecond)
+				mu.Lock()
+				events = append(events, name+" end")
+				mu.Unlock()
+			}
+		}
+	)
+	wg.Add(3)
+	cron.AddFunc("* * * * * ?", stage("extract"), InGroup(group))
+	cron.AddFunc("* * * * * ?", stage("transform"), InGroup(group))
+	cron.AddFunc("* * * * * ?", stage("load"), InGroup(group))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(OneSecond):
+		t.Fatal("expected all stages to run")
+	case <-wait(&wg):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{
+		"extract start", "extract end",
+		"transform start", "transform end",
+		"load start", "load end",
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, events)
+		}
+	}
+}
+
+func TestGroupTimeout(t *testing.T) {
+	group := NewGroup("etl", 10*time.Millisecond)
+	var (
+		wg      sync.WaitGroup
+		ran     bool
+		dropped time.Duration
+	)
+	wg.Add(2)
+	group.submit(groupRun{now: time.Now, run: func() {
+		defer wg.Done()
+		time.Sleep(50 * time.Millisecond)
+	}})
+	group.submit(groupRun{
+		now:  time.Now,
+		run:  func() { ran = true; wg.Done() },
+		drop: func(wait time.Duration) { dropped = wait; wg.Done() },
+	})
+	wg.Wait()
+
+	if ran || dropped < 10*time.Millisecond {
+		t.Errorf("expected the second run to be dropped, got ran=%v wait=%v", ran, dropped)
+	}
+}
+
+func TestGroupFairness(t *testing.T) {
+	var (
+		t0      = time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+		group   = NewGroup("shared", 0)
+		release = make(chan struct{})
+		mu      sync.Mutex
+		order   []EntryID
+		wg      sync.WaitGroup
+	)
+	submit := func(id EntryID, scheduled time.Time, block bool) {
+		wg.Add(1)
+		group.submit(groupRun{entry: id, scheduled: scheduled, now: time.Now, run: func() {
+			defer wg.Done()
+			if block {
+				<-release
+			}
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}})
+	}
+
+	// A frequent entry holds the group and queues more runs before a daily
+	// entry that became due earlier.
+	submit(1, t0, true)
+	submit(1, t0.Add(2*time.Minute), false)
+	submit(1, t0.Add(3*time.Minute), false)
+	submit(2, t0.Add(time.Minute), false)
+
+	time.Sleep(10 * time.Millisecond)
+	if s := group.Stats(); s.Queued != 3 || s.OldestWait < 10*time.Millisecond {
+		t.Errorf("expected 3 runs waiting for at least 10ms, got %+v", s)
+	}
+	close(release)
+	wg.Wait()
+
+	mu.Lock()
+	if expected := []EntryID{1, 2, 1, 1}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected runs in order %v, got %v", expected, order)
+	}
+	mu.Unlock()
+
+	s := group.Stats()
+	if s.Runs != 4 || s.Queued != 0 || s.OldestWait != 0 || s.MaxWait < 10*time.Millisecond {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	if es := s.Entries[2]; es.Runs != 1 || es.MaxWait < 10*time.Millisecond {
+		t.Errorf("expected the daily entry to have waited at least 10ms, got %+v", es)
+	}
+	if es := s.Entries[1]; es.Runs != 3 {
+		t.Errorf("expected 3 runs of the frequent entry, got %+v", es)
+	}
+}
+
+func TestGroupUsesCronClock(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		now     = time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+		group   = NewGroup("etl", time.Minute)
+		started = make(chan struct{})
+		release = make(chan struct{})
+		dropped = make(chan time.Duration, 1)
+	)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	group.submit(groupRun{now: clock, run: func() { close(started); <-release }})
+	<-started
+	group.submit(groupRun{entry: 2, now: clock, run: func() {},
+		drop: func(wait time.Duration) { dropped <- wait }})
+
+	mu.Lock()
+	now = now.Add(2 * time.Minute)
+	mu.Unlock()
+	if s := group.Stats(); s.OldestWait != 2*time.Minute {
+		t.Errorf("expected the queued run to have waited 2m on the cron clock, got %v", s.OldestWait)
+	}
+	close(release)
+	if wait := <-dropped; wait != 2*time.Minute {
+		t.Errorf("expected the run to be dropped after 2m, got %v", wait)
+	}
+}
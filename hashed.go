@@ -0,0 +1,204 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hashedSchedule is a schedule whose spec has Jenkins-style hashed values,
+// such as "H" or "H(0-7)". Each hashed value is resolved to a value of its
+// range derived from a hash of a key identifying the entry, so that entries
+// with the same spec are spread across the range rather than all running at
+// once. Until it is bound to an entry, the key is the spec itself.
+type hashedSchedule struct {
+	spec     string
+	fields   []string // normalized, with hashed values
+	loc      *time.Location
+	key      string
+	resolved *SpecSchedule
+}
+
+// WithHashKey sets the key from which the hashed values (see Hashed) of the
+// entry's spec are derived. It defaults to the entry's job name (see
+// WithJobName), or else its name (see WithName), or else its ID. Entries whose
+// specs and keys are equal run at the same times, so e.g. a fleet of services
+// sharing a spec may use their host names as keys. The key is not
+// persisted by stores, which resolve saved entries with their job name.
+func WithHashKey(key string) EntryOption {
+	return func(e *Entry) {
+		e.hashKey = key
+	}
+}
+
+// newHashedSchedule returns the schedule of a spec with hashed values, given
+// its normalized fields, resolved with the spec as key. It returns an error if
+// the fields are invalid.
+func newHashedSchedule(spec string, fields []string, loc *time.Location) (Schedule, error) {
+	s := &hashedSchedule{spec: spec, fields: fields, loc: loc}
+	if err := s.resolve(spec); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// resolve resolves the hashed values of the fields with the given key.
+func (s *hashedSchedule) resolve(key string) error {
+	resolved := make([]string, len(s.fields))
+	for i, field := range s.fields {
+		seed := hashSeed(key, i)
+		var exprs []string
+		for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+			expr, err := expandHashed(expr, i, seed)
+			if err != nil {
+				return err
+			}
+			exprs = append(exprs, expr)
+		}
+		resolved[i] = strings.Join(exprs, ",")
+	}
+	schedule, err := specFromFields(resolved, s.loc)
+	if err != nil {
+		return err
+	}
+	s.key, s.resolved = key, schedule
+	return nil
+}
+
+// withHashKey returns a copy of the schedule resolved with the given key.
+func (s *hashedSchedule) withHashKey(key string) Schedule {
+	bound := *s
+	if err := bound.resolve(key); err != nil {
+		// The fields were validated with another key, and keys only select
+		// values within the validated ranges.
+		return s
+	}
+	return &bound
+}
+
+// Next returns the next activation time of the resolved schedule.
+func (s *hashedSchedule) Next(t time.Time) time.Time {
+	return s.resolved.Next(t)
+}
+
+// String returns the spec the schedule was parsed from.
+func (s *hashedSchedule) String() string {
+	return s.spec
+}
+
+// Spec returns the spec the schedule was parsed from.
+func (s *hashedSchedule) Spec() string {
+	return s.spec
+}
+
+// hashKeyed is implemented by schedules whose values depend on a key
+// identifying their entry.
+type hashKeyed interface {
+	withHashKey(key string) Schedule
+}
+
+// bindHashKey resolves the hashed values of the entry's schedules, if any,
+// with the entry's hash key.
+func bindHashKey(e *Entry) {
+	key := e.hashKey
+	for _, k := range []string{e.JobName, e.Name, strconv.Itoa(int(e.ID))} {
+		if key == "" {
+			key = k
+		}
+	}
+	if s, ok := e.Schedule.(hashKeyed); ok {
+		e.Schedule = s.withHashKey(key)
+	}
+	if s, ok := e.DegradedSchedule.(hashKeyed); ok {
+		e.DegradedSchedule = s.withHashKey(key)
+	}
+}
+
+// hasHashedValues returns true if any of the fields has a hashed value.
+func hasHashedValues(fields []string) bool {
+	for _, field := range fields {
+		for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+			if strings.HasPrefix(expr, "H") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hashSeed returns the hash of the key for the field at the given place, so
+// that the fields of a spec get unrelated values.
+func hashSeed(key string, place int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{byte(place)})
+	return h.Sum64()
+}
+
+// expandHashed returns the range expression given by a hashed value of the
+// field at the given place, or expr itself if it is not hashed:
+//
+//	H               a value of the field, e.g. "17" for minutes
+//	H(low-high)     a value from low to high
+//	H/step          every step values from an offset below step, e.g. "7-59/15"
+//	H(low-high)/step
+//
+// As in Jenkins, H in the day of month field ranges from 1 to 28, so that it
+// matches every month.
+func expandHashed(expr string, place int, seed uint64) (string, error) {
+	if !strings.HasPrefix(expr, "H") {
+		return expr, nil
+	}
+	r := fieldBounds[place]
+	low, high := r.min, r.max
+	if places[place] == Dom {
+		high = 28
+	}
+	rest := expr[1:]
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return "", fmt.Errorf("missing ) in hashed value: %s", expr)
+		}
+		lowAndHigh := strings.Split(rest[1:end], "-")
+		if len(lowAndHigh) != 2 {
+			return "", fmt.Errorf("expected H(low-high): %s", expr)
+		}
+		var err error
+		if low, err = parseIntOrName(lowAndHigh[0], r.names); err != nil {
+			return "", err
+		}
+		if high, err = parseIntOrName(lowAndHigh[1], r.names); err != nil {
+			return "", err
+		}
+		switch {
+		case low < r.min:
+			return "", fmt.Errorf("beginning of range (%d) below minimum (%d): %s", low, r.min, expr)
+		case high > r.max:
+			return "", fmt.Errorf("end of range (%d) above maximum (%d): %s", high, r.max, expr)
+		case low > high:
+			return "", fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", low, high, expr)
+		}
+		rest = rest[end+1:]
+	}
+	span := uint64(high - low + 1)
+	switch {
+	case rest == "":
+		return strconv.FormatUint(uint64(low)+seed%span, 10), nil
+	case strings.HasPrefix(rest, "/"):
+		step, err := mustParseInt(rest[1:])
+		if err != nil {
+			return "", err
+		}
+		if step == 0 {
+			return "", fmt.Errorf("step of range should be a positive number: %s", expr)
+		}
+		if uint64(step) < span {
+			span = uint64(step)
+		}
+		return fmt.Sprintf("%d-%d/%d", uint64(low)+seed%span, high, step), nil
+	}
+	return "", fmt.Errorf("expected H, H(low-high) or H/step: %s", expr)
+}
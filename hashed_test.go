@@ -0,0 +1,135 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHashedValues(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | Hashed)
+	sched, err := parser.Parse("H H(2-5) H * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sched.(*hashedSchedule)
+
+	// The values are deterministic for a key, and within the ranges.
+	times := make(map[time.Time]bool)
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		s := hashed.withHashKey(key).(*hashedSchedule)
+		again := hashed.withHashKey(key).(*hashedSchedule)
+		if *s.resolved != *again.resolved {
+			t.Errorf("%s: expected the same schedule, got %+v and %+v", key, s.resolved, again.resolved)
+		}
+		next := s.Next(getTime("Mon Jan 1 00:00 2024"))
+		if next.Hour() < 2 || next.Hour() > 5 || next.Day() > 28 {
+			t.Errorf("%s: unexpected next activation %v", key, next)
+		}
+		times[next] = true
+	}
+	if len(times) < 2 {
+		t.Errorf("expected different keys to spread the activations, got %v", times)
+	}
+
+	// The spec is kept as written.
+	if hashed.String() != "H H(2-5) H * *" {
+		t.Errorf("unexpected String: %q", hashed.String())
+	}
+}
+
+func TestHashedSteps(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | Hashed)
+	sched, err := parser.Parse("H/15 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		s := sched.(*hashedSchedule).withHashKey(key)
+		first := s.Next(getTime("Mon Jan 1 00:00 2024"))
+		if first.Minute() >= 15 {
+			t.Errorf("%s: expected an offset below 15, got %v", key, first)
+		}
+		next := first
+		for i := 0; i < 8; i++ {
+			following := s.Next(next)
+			if following.Sub(next) != 15*time.Minute {
+				t.Errorf("%s: expected runs 15 minutes apart, got %v and %v", key, next, following)
+			}
+			next = following
+		}
+	}
+}
+
+func TestHashedErrors(t *testing.T) {
+	options := Minute | Hour | Dom | Month | Dow | Hashed
+	parser := NewParser(options)
+	for _, spec := range []string{
+		"H(70-80) * * * *",
+		"H(5-2) * * * *",
+		"H(5 * * * *",
+		"H/0 * * * *",
+		"Hx * * * *",
+	} {
+		if _, err := parser.Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+		if issues := ValidateSpec(spec, options); len(issues) != 1 || issues[0].Field != 0 {
+			t.Errorf("%s: expected an issue with the minute field, got %+v", spec, issues)
+		}
+	}
+
+	// Without the option, H is rejected.
+	if _, err := NewParser(Minute | Hour | Dom | Month | Dow).Parse("H * * * *"); err == nil {
+		t.Error("expected an error without the Hashed option")
+	}
+	if issues := ValidateSpec("H(0-7) H * * *", options); len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+	if !parser.Capabilities().Hashed {
+		t.Error("expected the Hashed capability")
+	}
+}
+
+func TestHashKeyEntries(t *testing.T) {
+	cron := New(WithParser(NewParser(Second | Minute | Hour | Dom | Month | Dow | Hashed)))
+	var ids []EntryID
+	for _, key := range []string{"host-1", "host-2", "host-3", "host-4"} {
+		id, err := cron.AddFunc("H H H * * *", func() {}, WithHashKey(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	named, _ := cron.AddFunc("H H H * * *", func() {}, WithName("host-1"))
+
+	now := getTime("Mon Jan 1 00:00 2024")
+	times := make(map[time.Time]bool)
+	for _, id := range ids {
+		times[cron.Entry(id).Schedule.Next(now)] = true
+	}
+	if len(times) < 2 {
+		t.Errorf("expected entries with different keys to run at different times, got %v", times)
+	}
+
+	// The name is the default key.
+	if cron.Entry(named).Schedule.Next(now) != cron.Entry(ids[0]).Schedule.Next(now) {
+		t.Error("expected an entry named after a key to run with it")
+	}
+	if d := Describe(cron.Entry(named).Schedule); strings.Contains(d, "H") {
+		t.Errorf("expected the resolved schedule to be described, got %q", d)
+	}
+}
+
+func TestHashKeyDefaultsToID(t *testing.T) {
+	if _, err := ParseStandard("H * * * *"); err == nil {
+		t.Error("expected ParseStandard to reject hashed values")
+	}
+	cron := New(WithParser(NewParser(Second | Minute | Hour | Dom | Month | Dow | Hashed)))
+	first, _ := cron.AddFunc("H H H * * *", func() {})
+	second, _ := cron.AddFunc("H H H * * *", func() {})
+	now := getTime("Mon Jan 1 00:00 2024")
+	if cron.Entry(first).Schedule.Next(now) == cron.Entry(second).Schedule.Next(now) {
+		t.Error("expected unnamed entries sharing a spec to be spread by ID")
+	}
+}
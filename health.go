@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HealthPolicy determines what HealthGate does with a run while the
+// dependency is unhealthy.
+type HealthPolicy int
+
+const (
+	// SkipWhileUnhealthy skips runs while the dependency is unhealthy.
+	SkipWhileUnhealthy HealthPolicy = iota
+	// DelayWhileUnhealthy holds runs until the dependency is healthy again.
+	DelayWhileUnhealthy
+)
+
+// healthRetryInterval is how often DelayWhileUnhealthy re-checks health.
+var healthRetryInterval = time.Second
+
+// HealthGate checks the health of a dependency before each run of the
+// wrapped job, and skips or delays the run according to policy while the
+// checker returns an error. Transitions between healthy and unhealthy are
+// logged at Info (with the checker's error at Error), so that outages are
+// visible without logging every skipped run.
+func HealthGate(checker func(context.Context) error, policy HealthPolicy, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		var (
+			mu        sync.Mutex
+			unhealthy bool
+		)
+		check := func(ctx context.Context) bool {
+			err := checker(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil && !unhealthy:
+				unhealthy = true
+				logger.Error(err, "dependency unhealthy")
+			case err == nil && unhealthy:
+				unhealthy = false
+				logger.Info("dependency healthy")
+			}
+			return err == nil
+		}
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			for !check(ctx) {
+				if policy == SkipWhileUnhealthy {
+					logger.Info("skip", "reason", "dependency unhealthy")
+					ReportSkip(ctx, SkipUnhealthy, "")
+					return nil
+				}
+				select {
+				case <-time.After(healthRetryInterval):
+				case <-ctx.Done():
+					logger.Info("skip", "reason", "dependency unhealthy", "error", ctx.Err())
+					ReportSkip(ctx, SkipUnhealthy, ctx.Err().Error())
+					return nil
+				}
+			}
+			return RunJobE(ctx, j)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// HTTPHealthCheck returns a health checker for HealthGate that issues a GET
+// request to the given URL and considers any 2xx response healthy. If client
+// is nil, http.DefaultClient is used.
+func HTTPHealthCheck(url string, client *http.Client) func(context.Context) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("health check %s: unexpected status %s", url, resp.Status)
+		}
+		return nil
+	}
+}
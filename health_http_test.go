@@ -0,0 +1,29 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPHealthCheck(t *testing.T) {
+	status := int32(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	check := HTTPHealthCheck(server.URL, nil)
+	if err := check(context.Background()); err != nil {
+		t.Errorf("expected healthy, got %v", err)
+	}
+	atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+	if err := check(context.Background()); err == nil {
+		t.Error("expected an error for a 503 response")
+	}
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthGateSkip(t *testing.T) {
+	var (
+		buf     syncWriter
+		healthy int32
+		runs    int32
+	)
+	checker := func(context.Context) error {
+		if atomic.LoadInt32(&healthy) == 0 {
+			return errors.New("database down")
+		}
+		return nil
+	}
+	job := HealthGate(checker, SkipWhileUnhealthy, newBufLogger(&buf))(
+		FuncJob(func() { atomic.AddInt32(&runs, 1) }))
+
+	job.Run()
+	job.Run()
+	if runs != 0 {
+		t.Errorf("expected runs to be skipped, got %d", runs)
+	}
+	if n := strings.Count(buf.String(), "dependency unhealthy, error=database down"); n != 1 {
+		t.Errorf("expected the outage to be logged once, got %d:\n%s", n, buf.String())
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	job.Run()
+	if runs != 1 {
+		t.Errorf("expected the job to run once healthy, got %d", runs)
+	}
+}
+
+func TestHealthGateDelay(t *testing.T) {
+	defer func(interval time.Duration) { healthRetryInterval = interval }(healthRetryInterval)
+	healthRetryInterval = time.Millisecond
+
+	var checks, runs int32
+	checker := func(context.Context) error {
+		if atomic.AddInt32(&checks, 1) < 3 {
+			return errors.New("api down")
+		}
+		return nil
+	}
+	HealthGate(checker, DelayWhileUnhealthy, DiscardLogger)(
+		FuncJob(func() { atomic.AddInt32(&runs, 1) })).Run()
+	if checks != 3 || runs != 1 {
+		t.Errorf("expected the run to be delayed until healthy, got %d checks and %d runs", checks, runs)
+	}
+}
@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// RunResult classifies how an activation of an entry ended. See RunRecord.
+type RunResult string
+
+const (
+	// ResultOK is recorded for a run that completed without error.
+	ResultOK RunResult = "ok"
+	// ResultFailed is recorded for a run that returned an error.
+	ResultFailed RunResult = "failed"
+	// ResultPanicked is recorded for a run that panicked.
+	ResultPanicked RunResult = "panicked"
+	// ResultSkipped is recorded for an activation that did not result in a
+	// run, e.g. because the previous run was still in progress.
+	ResultSkipped RunResult = "skipped"
+	// ResultAbandoned is recorded when the scheduler stops waiting for a run
+	// that exceeded its hard deadline (see WithHardDeadline). The run is
+	// recorded again if it completes.
+	ResultAbandoned RunResult = "abandoned"
+)
+
+// RunRecord describes an activation of an entry, as kept by WithHistory.
+type RunRecord struct {
+	// Scheduled is the time the run was scheduled for, or the zero time for
+	// runs started otherwise, e.g. by RunNow.
+	Scheduled time.Time
+	// Start and End are the times the run started and completed. They are
+	// zero for skipped activations, and End for abandoned runs.
+	Start, End time.Time
+	// Duration is End minus Start.
+	Duration time.Duration
+	// Result is how the activation ended.
+	Result RunResult
+	// Err is the error returned by a failed run.
+	Err error
+	// SkipReason is the reason an activation was skipped.
+	SkipReason SkipReason
+}
+
+// history keeps the latest runs of every entry of a Cron. A nil *history
+// keeps nothing.
+type history struct {
+	size int
+
+	mu   sync.Mutex
+	runs map[EntryID]*runRing
+}
+
+// runRing holds the latest runs of an entry, overwriting the oldest once
+// full.
+type runRing struct {
+	records []RunRecord
+	next    int // index of the oldest record once full
+}
+
+func newHistory(size int) *history {
+	return &history{size: size, runs: make(map[EntryID]*runRing)}
+}
+
+// record adds the run to the history of the entry.
+func (h *history) record(id EntryID, r RunRecord) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring := h.runs[id]
+	if ring == nil {
+		ring = &runRing{records: make([]RunRecord, 0, h.size)}
+		h.runs[id] = ring
+	}
+	if len(ring.records) < h.size {
+		ring.records = append(ring.records, r)
+		return
+	}
+	ring.records[ring.next] = r
+	ring.next = (ring.next + 1) % h.size
+}
+
+// remove forgets the history of the entry.
+func (h *history) remove(id EntryID) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.runs, id)
+}
+
+// get returns a copy of the history of the entry, oldest first.
+func (h *history) get(id EntryID) []RunRecord {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring := h.runs[id]
+	if ring == nil {
+		return nil
+	}
+	records := make([]RunRecord, 0, len(ring.records))
+	records = append(records, ring.records[ring.next:]...)
+	return append(records, ring.records[:ring.next]...)
+}
+
+// History returns the latest activations of the given entry, oldest first,
+// as kept by WithHistory: when each was scheduled, started and completed, and
+// whether it succeeded, failed, panicked or was skipped. It returns nil if
+// the entry has none, or if the Cron keeps no history.
+func (c *Cron) History(id EntryID) []RunRecord {
+	return c.history.get(id)
+}
+
+// recordRun adds a completed run to the history of its entry.
+func (c *Cron) recordRun(id EntryID, scheduled, start time.Time, duration time.Duration,
+	failed, panicked bool, err error) {
+	r := RunRecord{Scheduled: scheduled, Start: start, End: start.Add(duration),
+		Duration: duration, Result: ResultOK}
+	switch {
+	case panicked:
+		r.Result = ResultPanicked
+	case failed:
+		r.Result, r.Err = ResultFailed, err
+	}
+	c.history.record(id, r)
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	cron := New(WithChain(Recover(DiscardLogger)), WithHistory(10))
+	ok, _ := cron.AddFunc("@daily", func() {})
+	failing, _ := cron.AddErrFunc("@daily", func() error { return errors.New("fail") })
+	panicking, _ := cron.AddFunc("@daily", func() { panic("boom") })
+	cron.Start()
+	for _, id := range []EntryID{ok, failing, panicking} {
+		if err := cron.RunNow(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-cron.Stop().Done()
+
+	for id, expected := range map[EntryID]RunResult{ok: ResultOK, failing: ResultFailed, panicking: ResultPanicked} {
+		runs := cron.History(id)
+		if len(runs) != 1 {
+			t.Errorf("entry %d: expected a run, got %v", id, runs)
+			continue
+		}
+		r := runs[0]
+		if r.Result != expected || r.Start.IsZero() || r.End.Before(r.Start) {
+			t.Errorf("entry %d: expected a %s run, got %+v", id, expected, r)
+		}
+		if (r.Err != nil) != (expected == ResultFailed) {
+			t.Errorf("entry %d: unexpected error %v", id, r.Err)
+		}
+	}
+
+	cron.Remove(ok)
+	if runs := cron.History(ok); runs != nil {
+		t.Errorf("expected the history of a removed entry to be dropped, got %v", runs)
+	}
+}
+
+func TestHistoryKeepsLatest(t *testing.T) {
+	cron := New(WithHistory(3))
+	id, _ := cron.AddFunc("@daily", func() {})
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		cron.skipped(id, base.Add(time.Duration(i)*time.Hour), SkipStillRunning, "", false)
+	}
+	runs := cron.History(id)
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %v", runs)
+	}
+	for i, r := range runs {
+		if expected := base.Add(time.Duration(i+2) * time.Hour); !r.Scheduled.Equal(expected) ||
+			r.Result != ResultSkipped || r.SkipReason != SkipStillRunning {
+			t.Errorf("run %d: expected a skip scheduled at %v, got %+v", i, expected, r)
+		}
+	}
+}
+
+func TestNoHistory(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("@daily", func() {})
+	cron.skipped(id, time.Now(), SkipStillRunning, "", false)
+	if runs := cron.History(id); runs != nil {
+		t.Errorf("expected no history, got %v", runs)
+	}
+}
@@ -0,0 +1,40 @@
+package cron
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// IsIdle reports whether the scheduler is running with nothing to do: no
+// entry has an upcoming activation, or all are suspended with PauseAll. Jobs
+// may still be running. It returns false if the scheduler is not running.
+func (c *Cron) IsIdle() bool {
+	return atomic.LoadInt32(&c.idle) == 1
+}
+
+// setIdle records whether the scheduler is idle, calling the idle callback if
+// that changed.
+func (c *Cron) setIdle(idle bool) {
+	var v int32
+	if idle {
+		v = 1
+	}
+	if atomic.SwapInt32(&c.idle, v) == v {
+		return
+	}
+	if idle {
+		c.logger.Info("idle")
+	} else {
+		c.logger.Info("busy")
+	}
+	if c.onIdle != nil {
+		c.onIdle()
+	}
+}
+
+// stopTimer stops the scheduler's timer, if it is not idle.
+func stopTimer(t *time.Timer) {
+	if t != nil {
+		t.Stop()
+	}
+}
@@ -0,0 +1,46 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleCallback(t *testing.T) {
+	transitions := make(chan bool, 10)
+	var cron *Cron
+	cron = New(WithIdleCallback(func() { transitions <- cron.IsIdle() }))
+	if cron.IsIdle() {
+		t.Error("expected a stopped cron not to be idle")
+	}
+	expect := func(idle bool) {
+		t.Helper()
+		select {
+		case got := <-transitions:
+			if got != idle {
+				t.Errorf("expected idle=%v, got %v", idle, got)
+			}
+		case <-time.After(OneSecond):
+			t.Fatalf("expected a transition to idle=%v", idle)
+		}
+	}
+
+	cron.Start()
+	expect(true)
+	id, _ := cron.AddFunc("@hourly", func() {})
+	expect(false)
+	cron.PauseAll()
+	expect(true)
+	cron.ResumeAll()
+	expect(false)
+	cron.Remove(id)
+	expect(true)
+	cron.Stop()
+	if cron.IsIdle() {
+		t.Error("expected a stopped cron not to be idle")
+	}
+	select {
+	case idle := <-transitions:
+		t.Errorf("unexpected transition to idle=%v", idle)
+	default:
+	}
+}
@@ -0,0 +1,201 @@
+package cron
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is an ISO 8601 duration, such as "P1M" or "PT1H30M". Its calendar
+// components are applied with time.AddDate, so that "P1M" is a month whatever
+// its length, and its clock components as a time.Duration.
+type Period struct {
+	Years, Months, Days int
+	Duration            time.Duration
+}
+
+// String formats the period in ISO 8601, e.g. "P1DT12H".
+func (p Period) String() string {
+	var b strings.Builder
+	b.WriteString("P")
+	for _, c := range []struct {
+		n    int
+		unit string
+	}{{p.Years, "Y"}, {p.Months, "M"}, {p.Days, "D"}} {
+		if c.n != 0 {
+			fmt.Fprintf(&b, "%d%s", c.n, c.unit)
+		}
+	}
+	if d := p.Duration; d != 0 {
+		b.WriteString("T")
+		if h := d / time.Hour; h != 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m := d % time.Hour / time.Minute; m != 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+		if s := d % time.Minute; s != 0 {
+			b.WriteString(strconv.FormatFloat(s.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// addTo returns t moved by n periods.
+func (p Period) addTo(t time.Time, n int) time.Time {
+	return t.AddDate(n*p.Years, n*p.Months, n*p.Days).Add(time.Duration(n) * p.Duration)
+}
+
+// approximate returns the approximate length of the period, which is exact if
+// it has no calendar components.
+func (p Period) approximate() time.Duration {
+	const day = 24 * time.Hour
+	return time.Duration(p.Years)*time.Duration(365.2425*float64(day)) +
+		time.Duration(p.Months)*time.Duration(30.436875*float64(day)) +
+		time.Duration(p.Days)*day + p.Duration
+}
+
+// RepeatingIntervalSchedule activates at the start of each of a series of
+// consecutive periods, as an ISO 8601 repeating interval such as
+// "R5/2024-01-01T00:00:00Z/PT1H". See ParseISO8601.
+type RepeatingIntervalSchedule struct {
+	// Start is the first activation.
+	Start time.Time
+	// Period is the time between activations.
+	Period Period
+	// Repetitions is the number of activations, or -1 if unbounded.
+	Repetitions int
+}
+
+// Next returns the next activation time, later than the given time, or the
+// zero time once the repetitions are exhausted, or if the period is not
+// positive. Activations are computed from Start rather than from each other,
+// so they do not drift.
+func (s RepeatingIntervalSchedule) Next(t time.Time) time.Time {
+	if s.Period.approximate() <= 0 {
+		return time.Time{}
+	}
+	k := 0
+	if !t.Before(s.Start) {
+		k = int(t.Sub(s.Start) / s.Period.approximate())
+		for k > 0 && s.Period.addTo(s.Start, k-1).After(t) {
+			k--
+		}
+		for !s.Period.addTo(s.Start, k).After(t) {
+			k++
+		}
+	}
+	if s.Repetitions >= 0 && k >= s.Repetitions {
+		return time.Time{}
+	}
+	return s.Period.addTo(s.Start, k)
+}
+
+func (RepeatingIntervalSchedule) finite() {}
+
+// ParseISO8601 parses an ISO 8601 repeating interval, "R[n]/start/period",
+// "R[n]/start/end" or "Rn/period/end", e.g. "R5/2024-01-01T00:00:00Z/PT1H"
+// for five hourly activations. Without n, the repetitions are unbounded. Times
+// are in RFC 3339 format, or in the local time zone if they have no offset.
+// Periods take years, months, weeks, days, hours, minutes and seconds.
+//
+// Parsers created with the ISO8601 option accept the same intervals.
+func ParseISO8601(spec string) (RepeatingIntervalSchedule, error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "R") {
+		return RepeatingIntervalSchedule{}, fmt.Errorf("expected a repeating interval such as R5/2024-01-01T00:00:00Z/PT1H: %s", spec)
+	}
+	s := RepeatingIntervalSchedule{Repetitions: -1}
+	if n := parts[0][1:]; n != "" && n != "-1" {
+		var err error
+		if s.Repetitions, err = strconv.Atoi(n); err != nil || s.Repetitions < 0 {
+			return RepeatingIntervalSchedule{}, fmt.Errorf("invalid number of repetitions %s: %s", parts[0], spec)
+		}
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(parts[1], "P"):
+		if s.Repetitions < 0 {
+			return RepeatingIntervalSchedule{}, fmt.Errorf("an interval ending at a time needs a number of repetitions: %s", spec)
+		}
+		var end time.Time
+		if s.Period, err = parsePeriod(parts[1]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+		if end, err = parseISOTime(parts[2]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+		s.Start = s.Period.addTo(end, -s.Repetitions)
+	case strings.HasPrefix(parts[2], "P"):
+		if s.Start, err = parseISOTime(parts[1]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+		if s.Period, err = parsePeriod(parts[2]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+	default:
+		var end time.Time
+		if s.Start, err = parseISOTime(parts[1]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+		if end, err = parseISOTime(parts[2]); err != nil {
+			return RepeatingIntervalSchedule{}, err
+		}
+		s.Period = Period{Duration: end.Sub(s.Start)}
+	}
+	if s.Period.approximate() <= 0 {
+		return RepeatingIntervalSchedule{}, fmt.Errorf("period must be positive: %s", spec)
+	}
+	return s, nil
+}
+
+// parseISOTime parses a time in RFC 3339 format, or a local time without an
+// offset.
+func parseISOTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s: expected a time such as 2024-01-01T00:00:00Z", value)
+	}
+	return t, nil
+}
+
+var periodPattern = regexp.MustCompile(
+	`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// parsePeriod parses an ISO 8601 duration, such as "P1DT12H".
+func parsePeriod(value string) (Period, error) {
+	m := periodPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return Period{}, fmt.Errorf("invalid period %s: expected a duration such as PT1H or P1D", value)
+	}
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+		return v
+	}
+	p := Period{Years: n(1), Months: n(2), Days: 7*n(3) + n(4)}
+	p.Duration = time.Duration(n(5))*time.Hour + time.Duration(n(6))*time.Minute
+	if m[7] != "" {
+		secs, _ := strconv.ParseFloat(strings.Replace(m[7], ",", ".", 1), 64)
+		p.Duration += time.Duration(secs * float64(time.Second))
+	}
+	return p, nil
+}
+
+// Spec returns the schedule as an ISO 8601 repeating interval, such as
+// "R5/2024-01-01T00:00:00Z/PT1H".
+func (s RepeatingIntervalSchedule) Spec() string {
+	r := "R"
+	if s.Repetitions >= 0 {
+		r += strconv.Itoa(s.Repetitions)
+	}
+	return r + "/" + s.Start.Format(time.RFC3339Nano) + "/" + s.Period.String()
+}
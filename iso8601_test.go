@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601(t *testing.T) {
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		spec     string
+		from     time.Time
+		expected []time.Time
+	}{
+		{"R3/2024-01-01T00:00:00Z/PT1H", utc(time.January, 1, 0).Add(-time.Second),
+			[]time.Time{utc(time.January, 1, 0), utc(time.January, 1, 1), utc(time.January, 1, 2), {}}},
+		{"R3/2024-01-01T00:00:00Z/PT1H", utc(time.January, 1, 1).Add(30 * time.Minute),
+			[]time.Time{utc(time.January, 1, 2), {}}},
+		{"R/2024-01-31T00:00:00Z/P1M", utc(time.June, 1, 0),
+			[]time.Time{utc(time.July, 1, 0), utc(time.July, 31, 0), utc(time.August, 31, 0)}},
+		{"R/2024-01-01T00:00:00Z/P1W", utc(time.January, 1, 0),
+			[]time.Time{utc(time.January, 8, 0), utc(time.January, 15, 0)}},
+		{"R2/2024-01-01T00:00:00Z/2024-01-01T12:00:00Z", utc(time.January, 1, 0),
+			[]time.Time{utc(time.January, 1, 12), {}}},
+		{"R2/P1D/2024-01-10T00:00:00Z", utc(time.January, 1, 0),
+			[]time.Time{utc(time.January, 8, 0), utc(time.January, 9, 0), {}}},
+		{"R0/2024-01-01T00:00:00Z/PT1H", utc(time.January, 1, 0).Add(-time.Second),
+			[]time.Time{{}}},
+	}
+	for _, tt := range tests {
+		s, err := ParseISO8601(tt.spec)
+		if err != nil {
+			t.Errorf("%s: %v", tt.spec, err)
+			continue
+		}
+		from := tt.from
+		for _, expected := range tt.expected {
+			next := s.Next(from)
+			if !next.Equal(expected) {
+				t.Errorf("%s, %v: expected %v, got %v", tt.spec, from, expected, next)
+				break
+			}
+			from = next
+		}
+	}
+}
+
+func TestParseISO8601Errors(t *testing.T) {
+	for _, spec := range []string{
+		"R5/2024-01-01T00:00:00Z",
+		"R5/2024-01-01/PT1H",
+		"Rx/2024-01-01T00:00:00Z/PT1H",
+		"R5/2024-01-01T00:00:00Z/PT",
+		"R5/2024-01-01T00:00:00Z/P0D",
+		"R5/2024-01-01T00:00:00Z/1h",
+		"R/P1D/2024-01-10T00:00:00Z",
+		"R2/2024-01-02T00:00:00Z/2024-01-01T00:00:00Z",
+	} {
+		if _, err := ParseISO8601(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestRepeatingIntervalZeroPeriod(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range []RepeatingIntervalSchedule{
+		{},
+		{Start: start, Repetitions: -1},
+		{Start: start, Period: Period{Days: 1, Duration: -24 * time.Hour}, Repetitions: 3},
+	} {
+		if next := s.Next(start); !next.IsZero() {
+			t.Errorf("%+v: expected the zero time, got %v", s, next)
+		}
+	}
+}
+
+func TestParserISO8601(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | ISO8601)
+	s, err := p.Parse("R5/2024-01-01T00:00:00Z/PT1H30M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Describe(s); d != "Every PT1H30M, starting 2024-01-01T00:00:00Z, 5 times" {
+		t.Errorf("unexpected description %q", d)
+	}
+	if issues := ValidateSpec("R5/2024-01-01T00:00:00Z/1h", Minute|Hour|Dom|Month|Dow|ISO8601); len(issues) != 1 {
+		t.Errorf("expected an issue, got %v", issues)
+	}
+	if _, err := ParseStandard("R5/2024-01-01T00:00:00Z/PT1H"); err == nil {
+		t.Error("expected the standard parser to reject ISO 8601 intervals")
+	}
+}
@@ -0,0 +1,119 @@
+package cron
+
+import "context"
+
+// entryPageSize is the number of entries EachEntry copies at a time.
+const entryPageSize = 256
+
+// pageRequest asks the run loop for a page of copies of entries: those
+// following the entry with ID after if the store is an EntryRanger, or else
+// those at offset in a snapshot of the store's entries.
+type pageRequest struct {
+	after EntryID
+	// entries is the snapshot being iterated, or nil to start.
+	entries []*Entry
+	offset  int
+	reply   chan entryPage
+}
+
+// entryPage is a page of copies of entries, along with the snapshot of the
+// store's entries they come from, if any.
+type entryPage struct {
+	entries []*Entry
+	page    []Entry
+	// more is true if entries may follow the page.
+	more bool
+}
+
+// EachEntry calls fn with a snapshot of each entry, excluding archived ones,
+// in no particular order, until fn returns false. Unlike Entries, it copies
+// only a few entries at a time, so that looking up one entry among hundreds of
+// thousands is cheap, and it does not block the scheduler while fn runs.
+// Entries added or removed during the iteration may or may not be visited.
+func (c *Cron) EachEntry(fn func(Entry) bool) {
+	var req pageRequest
+	for {
+		p := c.entryPage(req)
+		for _, e := range p.page {
+			if e.Archived {
+				continue
+			}
+			if !fn(e) {
+				return
+			}
+		}
+		if !p.more {
+			return
+		}
+		req.after = p.page[len(p.page)-1].ID
+		req.entries, req.offset = p.entries, req.offset+entryPageSize
+	}
+}
+
+// StreamEntries sends a snapshot of each entry, excluding archived ones, on
+// the returned channel, which is closed after the last entry or once ctx is
+// done. See EachEntry.
+func (c *Cron) StreamEntries(ctx context.Context) <-chan Entry {
+	ch := make(chan Entry)
+	go func() {
+		defer close(ch)
+		c.EachEntry(func(e Entry) bool {
+			select {
+			case ch <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch
+}
+
+// entryPage returns the requested page of entries, copied by the run loop if
+// it is running.
+func (c *Cron) entryPage(req pageRequest) entryPage {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		req.reply = make(chan entryPage, 1)
+		c.page <- req
+		return <-req.reply
+	}
+	return c.copyPage(req)
+}
+
+// copyPage copies the requested page of entries. Unless the store is an
+// EntryRanger, the first page takes a snapshot of the store's entries, which
+// the following pages are copied from.
+func (c *Cron) copyPage(req pageRequest) entryPage {
+	if r, ok := c.store.(EntryRanger); ok {
+		entries := r.EntriesAfter(req.after, entryPageSize)
+		return entryPage{page: copyEntries(entries), more: len(entries) == entryPageSize}
+	}
+	if req.entries == nil {
+		req.entries = c.store.Entries()
+	}
+	end := req.offset + entryPageSize
+	if end > len(req.entries) {
+		end = len(req.entries)
+	}
+	var page []Entry
+	if req.offset < end {
+		page = copyEntries(req.entries[req.offset:end])
+	}
+	return entryPage{req.entries, page, end < len(req.entries)}
+}
+
+// copyEntries returns snapshots of the entries.
+func copyEntries(entries []*Entry) []Entry {
+	if len(entries) == 0 {
+		return nil
+	}
+	page := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		entry := *e
+		entry.Metadata = copyMetadata(e.Metadata)
+		page = append(page, entry)
+	}
+	return page
+}
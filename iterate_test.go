@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"context"
+	"testing"
+)
+
+// plainStore hides the optional interfaces of the Store it wraps, such as
+// EntryRanger.
+type plainStore struct {
+	Store
+}
+
+func TestEachEntry(t *testing.T) {
+	for _, running := range []bool{false, true} {
+		cron := New()
+		const n = 2*entryPageSize + 10
+		for i := 0; i < n; i++ {
+			cron.AddFunc("@daily", func() {})
+		}
+		archived, _ := cron.AddFunc("@daily", func() {})
+		cron.Archive(archived)
+		if running {
+			cron.Start()
+		}
+
+		seen := make(map[EntryID]bool)
+		cron.EachEntry(func(e Entry) bool {
+			seen[e.ID] = true
+			return true
+		})
+		if len(seen) != n || seen[archived] {
+			t.Errorf("running=%v: expected %d unarchived entries, got %d", running, n, len(seen))
+		}
+
+		visited := 0
+		cron.EachEntry(func(e Entry) bool {
+			visited++
+			return visited < 3
+		})
+		if visited != 3 {
+			t.Errorf("running=%v: expected the iteration to stop after 3 entries, got %d", running, visited)
+		}
+		cron.Stop()
+	}
+}
+
+func TestEachEntryWithoutRanger(t *testing.T) {
+	cron := New(WithStore(plainStore{NewHeapStore()}))
+	const n = entryPageSize + 1
+	for i := 0; i < n; i++ {
+		cron.AddFunc("@daily", func() {})
+	}
+	seen := make(map[EntryID]bool)
+	cron.EachEntry(func(e Entry) bool {
+		seen[e.ID] = true
+		return true
+	})
+	if len(seen) != n {
+		t.Errorf("expected %d entries, got %d", n, len(seen))
+	}
+}
+
+func TestStreamEntries(t *testing.T) {
+	cron := New()
+	for i := 0; i < 10; i++ {
+		cron.AddFunc("@daily", func() {})
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	count := 0
+	for range cron.StreamEntries(context.Background()) {
+		count++
+	}
+	if count != 10 {
+		t.Errorf("expected 10 entries, got %d", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := cron.StreamEntries(ctx)
+	<-stream
+	cancel()
+	for range stream {
+	}
+}
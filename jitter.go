@@ -0,0 +1,32 @@
+package cron
+
+import "time"
+
+// jitterSchedule delays the activations of a schedule by random durations
+// less than max. See WithJitter.
+type jitterSchedule struct {
+	schedule Schedule
+	max      time.Duration
+	rand     *lockedRand
+}
+
+// Next returns the next activation of the underlying schedule after t, plus
+// jitter. Since the jitter is less than the interval between activations, the
+// run at the jittered time is followed by the next activation rather than
+// the same one.
+func (s jitterSchedule) Next(t time.Time) time.Time {
+	return s.jitter(s.schedule.Next(t))
+}
+
+// first returns the first activation of the underlying schedule, plus jitter.
+func (s jitterSchedule) first(now time.Time) time.Time {
+	return s.jitter(firstActivation(s.schedule, now))
+}
+
+// jitter delays the activation by a random duration less than max.
+func (s jitterSchedule) jitter(next time.Time) time.Time {
+	if next.IsZero() || s.rand == nil {
+		return next
+	}
+	return next.Add(s.rand.Duration(s.max))
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	cron := New(WithRandSource(rand.NewSource(1)))
+	id, err := cron.AddFunc("@hourly", func() {}, WithJitter(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := cron.store.Get(id)
+
+	from := time.Date(2026, time.March, 1, 12, 30, 0, 0, time.Local)
+	distinct := make(map[time.Time]bool)
+	for i := 0; i < 20; i++ {
+		next := e.activeSchedule().Next(from)
+		hour := time.Date(2026, time.March, 1, 13, 0, 0, 0, time.Local)
+		if next.Before(hour) || !next.Before(hour.Add(10*time.Minute)) {
+			t.Fatalf("expected next within 10 minutes after %v, got %v", hour, next)
+		}
+		distinct[next] = true
+	}
+	if len(distinct) < 2 {
+		t.Error("expected the activations to be randomized")
+	}
+
+	// A jittered run is followed by the next activation.
+	next := e.activeSchedule().Next(time.Date(2026, time.March, 1, 13, 5, 0, 0, time.Local))
+	if next.Before(time.Date(2026, time.March, 1, 14, 0, 0, 0, time.Local)) {
+		t.Errorf("expected the following hour, got %v", next)
+	}
+}
+
+func TestJitterDeterministic(t *testing.T) {
+	next := func() time.Time {
+		cron := New(WithRandSource(rand.NewSource(42)))
+		id, _ := cron.AddFunc("@hourly", func() {}, WithJitter(time.Hour))
+		from := time.Date(2026, time.March, 1, 12, 30, 0, 0, time.UTC)
+		return cron.store.Get(id).activeSchedule().Next(from)
+	}
+	if a, b := next(), next(); !a.Equal(b) {
+		t.Errorf("expected the same jitter from the same source, got %v and %v", a, b)
+	}
+}
@@ -0,0 +1,172 @@
+package cron
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Journal persists markers for runs in progress, so that runs cut off by a
+// crash (e.g. an OOM kill) can be found when the process restarts. See
+// WithJournal.
+type Journal interface {
+	// Started records that a run of the job identified by key, scheduled for
+	// the given time, started at start. It replaces any earlier marker for key.
+	Started(key string, scheduled, start time.Time) error
+
+	// Finished removes the marker for key.
+	Finished(key string) error
+
+	// Unfinished returns the runs that were started but never finished.
+	Unfinished() ([]InterruptedRun, error)
+}
+
+// InterruptedRun describes a run that started but never finished, most likely
+// because the process died while it was running.
+type InterruptedRun struct {
+	Key       string    `json:"key"`
+	Scheduled time.Time `json:"scheduled"`
+	Started   time.Time `json:"started"`
+}
+
+// RecoveryPolicy determines what happens to interrupted runs found in the
+// journal when a Cron starts.
+type RecoveryPolicy int
+
+const (
+	// ReportInterrupted only logs interrupted runs and makes them available
+	// through Cron.InterruptedRuns.
+	ReportInterrupted RecoveryPolicy = iota
+	// RerunInterrupted also runs the affected entries as soon as the Cron
+	// starts.
+	RerunInterrupted
+)
+
+// ErrRunInterrupted is logged for each interrupted run found in the journal.
+var ErrRunInterrupted = errors.New("cron: run was interrupted before it finished")
+
+// journalConfig is the configuration installed by WithJournal, along with the
+// interrupted runs found at the last start.
+type journalConfig struct {
+	journal Journal
+	policy  RecoveryPolicy
+
+	mu          sync.Mutex
+	interrupted []InterruptedRun
+}
+
+// recoverInterrupted reads the interrupted runs from the journal, logs and
+// records them, and clears their markers. It returns the keys of the entries
+// that should be run again immediately.
+func (c *Cron) recoverInterrupted() map[string]bool {
+	if c.journal == nil {
+		return nil
+	}
+	runs, err := c.journal.journal.Unfinished()
+	if err != nil {
+		c.logger.Error(err, "journal")
+		return nil
+	}
+	c.journal.mu.Lock()
+	c.journal.interrupted = runs
+	c.journal.mu.Unlock()
+
+	rerun := make(map[string]bool)
+	for _, r := range runs {
+		c.logger.Error(ErrRunInterrupted, "interrupted",
+			"key", r.Key, "scheduled", r.Scheduled, "started", r.Started)
+		if c.journal.policy == RerunInterrupted {
+			rerun[r.Key] = true
+		}
+		if err := c.journal.journal.Finished(r.Key); err != nil {
+			c.logger.Error(err, "journal", "key", r.Key)
+		}
+	}
+	return rerun
+}
+
+// InterruptedRuns returns the runs found unfinished in the journal when the
+// cron was last started, or nil if this cron was not configured with
+// WithJournal.
+func (c *Cron) InterruptedRuns() []InterruptedRun {
+	if c.journal == nil {
+		return nil
+	}
+	c.journal.mu.Lock()
+	defer c.journal.mu.Unlock()
+	return append([]InterruptedRun(nil), c.journal.interrupted...)
+}
+
+// FileJournal is a Journal persisted to a file, encoded as JSON unless another
+// Codec is set. It is safe for concurrent
+// use within a process, but the file must not be shared by several processes.
+type FileJournal struct {
+	path string
+
+	mu   sync.Mutex
+	runs map[string]InterruptedRun
+
+	// Codec encodes the file when it is saved. It defaults to JSONCodec.
+	// Files encoded with any registered codec are loaded.
+	Codec Codec
+}
+
+// NewFileJournal returns a FileJournal persisted at the given path, loading
+// the markers already recorded there, if any. As with NewFileOnceStore, older
+// files are migrated and newer ones rejected.
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path, runs: make(map[string]InterruptedRun), Codec: JSONCodec}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := UnmarshalState(StateKindJournal, data, &j.runs); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Started records the marker and persists it before returning.
+func (j *FileJournal) Started(key string, scheduled, start time.Time) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.runs[key] = InterruptedRun{Key: key, Scheduled: scheduled, Started: start}
+	return j.save()
+}
+
+// Finished removes the marker and persists the change before returning.
+func (j *FileJournal) Finished(key string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.runs[key]; !ok {
+		return nil
+	}
+	delete(j.runs, key)
+	return j.save()
+}
+
+// Unfinished returns the recorded markers, ordered by key.
+func (j *FileJournal) Unfinished() ([]InterruptedRun, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	runs := make([]InterruptedRun, 0, len(j.runs))
+	for _, r := range j.runs {
+		runs = append(runs, r)
+	}
+	sort.Slice(runs, func(a, b int) bool { return runs[a].Key < runs[b].Key })
+	return runs, nil
+}
+
+func (j *FileJournal) save() error {
+	data, err := MarshalStateCodec(j.Codec, StateKindJournal, j.runs)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(j.path, data)
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.json")
+
+	j, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduled := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	start := scheduled.Add(time.Second)
+	for _, key := range []string{"sync", "report"} {
+		if err := j.Started(key, scheduled, start); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := j.Finished("report"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new journal on the same file sees the marker left behind.
+	j, err = NewFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs, _ := j.Unfinished()
+	if len(runs) != 1 || runs[0].Key != "sync" || !runs[0].Scheduled.Equal(scheduled) || !runs[0].Started.Equal(start) {
+		t.Errorf("unexpected unfinished runs: %+v", runs)
+	}
+}
+
+func TestWithJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	j, err := NewFileJournal(filepath.Join(dir, "journal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduled := time.Now().Add(-time.Hour)
+	j.Started("sync", scheduled, scheduled)
+	j.Started("gone", scheduled, scheduled)
+
+	var buf syncWriter
+	ran := make(chan struct{}, 1)
+	cron := New(WithLogger(newBufLogger(&buf)), WithJournal(j, RerunInterrupted))
+	cron.AddFunc("@yearly", func() { ran <- struct{}{} }, WithJournalKey("sync"))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(OneSecond):
+		t.Fatal("expected the interrupted entry to run again")
+	}
+	if runs := cron.InterruptedRuns(); len(runs) != 2 || runs[0].Key != "gone" || runs[1].Key != "sync" {
+		t.Errorf("unexpected interrupted runs: %+v", runs)
+	}
+	if !strings.Contains(buf.String(), "interrupted, error=cron: run was interrupted") {
+		t.Errorf("expected the interrupted runs to be logged, got %q", buf.String())
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if runs, _ := j.Unfinished(); len(runs) != 0 {
+		t.Errorf("expected the journal to be cleared, got %+v", runs)
+	}
+}
+
+func TestWithJournalReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	j, err := NewFileJournal(filepath.Join(dir, "journal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.Started("sync", time.Time{}, time.Time{})
+
+	cron := New(WithLogger(DiscardLogger), WithJournal(j, ReportInterrupted))
+	cron.AddFunc("@yearly", func() {}, WithJournalKey("sync"))
+	if rerun := cron.recoverInterrupted(); len(rerun) != 0 {
+		t.Errorf("expected nothing to be rerun, got %v", rerun)
+	}
+	if runs := cron.InterruptedRuns(); len(runs) != 1 {
+		t.Errorf("expected the interrupted run to be reported, got %+v", runs)
+	}
+	if runs, _ := j.Unfinished(); len(runs) != 0 {
+		t.Errorf("expected the journal to be cleared, got %+v", runs)
+	}
+}
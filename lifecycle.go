@@ -0,0 +1,186 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// LifecycleEvent is an event in the life of the scheduler or of a run, as
+// reported to an EventListener: one of SchedulerStarted, SchedulerStopped,
+// EntryScheduled, WakeCoalesced, JobStarted, JobCompleted, JobPanicked,
+// JobSkipped, RunUnacked, RunAbandoned or NonMonotonicNext. Use a type switch
+// to tell them apart.
+type LifecycleEvent interface {
+	lifecycleEvent()
+}
+
+// SchedulerStarted is reported when the scheduler starts.
+type SchedulerStarted struct {
+	Time time.Time
+}
+
+// SchedulerStopped is reported when the scheduler stops. Jobs may still be
+// running.
+type SchedulerStopped struct {
+	Time time.Time
+}
+
+// EntryScheduled is reported when the scheduler computes the next activation
+// of an entry: on start, when the entry is added, after each activation and
+// when its job reschedules it.
+type EntryScheduled struct {
+	Entry EntryID
+	Time  time.Time
+	// Next is the next activation, or the zero time if there is none.
+	Next time.Time
+}
+
+// WakeCoalesced is reported when the scheduler wakes up later than some
+// entries were due, to run them together with others, because of WithPrecision
+// or WithWakeBudget.
+type WakeCoalesced struct {
+	Time time.Time
+	// Shifted holds the delay imposed on each of the entries.
+	Shifted map[EntryID]time.Duration
+}
+
+// JobStarted is reported when a run of an entry's job starts, inside the
+// Cron's chain of wrappers.
+type JobStarted struct {
+	Entry     EntryID
+	Scheduled time.Time
+	Time      time.Time
+}
+
+// JobCompleted is reported when a run of an entry's job returns, or panics.
+type JobCompleted struct {
+	Entry     EntryID
+	Scheduled time.Time
+	// Time is when the run started.
+	Time     time.Time
+	Duration time.Duration
+	// Failed is true if the run panicked or returned an error.
+	Failed bool
+	// Err is the error returned by the run, if any.
+	Err error
+}
+
+// JobPanicked is reported when a run of an entry's job panics, before
+// JobCompleted. The panic then continues to the Cron's chain, e.g. to Recover.
+type JobPanicked struct {
+	Entry     EntryID
+	Scheduled time.Time
+	Time      time.Time
+	// Value is the value the job panicked with.
+	Value interface{}
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+// JobSkipped is reported when an activation of an entry is not run, e.g.
+// because it was stale, its once guard had already claimed it, or a wrapper
+// such as SkipIfStillRunning skipped it.
+type JobSkipped struct {
+	Entry     EntryID
+	Scheduled time.Time
+	Time      time.Time
+	// Reason classifies the skip, e.g. SkipStale or SkipAlreadyRun.
+	Reason SkipReason
+	// Detail gives more context, if any, e.g. "quota exceeded" for a run
+	// skipped with SkipOverloaded, or the reason given by the admission
+	// function.
+	Detail string
+	// Missed is true if the run was dropped for being too late, and counted
+	// as missed rather than skipped in the entry's statistics.
+	Missed bool
+}
+
+func (SchedulerStarted) lifecycleEvent() {}
+func (SchedulerStopped) lifecycleEvent() {}
+func (EntryScheduled) lifecycleEvent()   {}
+func (JobStarted) lifecycleEvent()       {}
+func (JobCompleted) lifecycleEvent()     {}
+func (JobPanicked) lifecycleEvent()      {}
+func (JobSkipped) lifecycleEvent()       {}
+func (WakeCoalesced) lifecycleEvent()    {}
+
+// EventListener receives the lifecycle events of a Cron. See
+// WithEventListener.
+type EventListener interface {
+	// OnEvent is called synchronously, from the scheduler's goroutine or from
+	// the goroutines of runs, so it must be safe for concurrent use and
+	// return quickly.
+	OnEvent(ev LifecycleEvent)
+}
+
+// EventListenerFunc is a func that implements EventListener.
+type EventListenerFunc func(ev LifecycleEvent)
+
+// OnEvent calls f(ev).
+func (f EventListenerFunc) OnEvent(ev LifecycleEvent) { f(ev) }
+
+// notify reports the event to the listeners, if any.
+func (c *Cron) notify(ev LifecycleEvent) {
+	for _, l := range c.listeners {
+		l.OnEvent(ev)
+	}
+}
+
+// scheduled notifies the listeners of the next activation of the entry.
+func (c *Cron) scheduled(e *Entry, now time.Time) {
+	if len(c.listeners) > 0 {
+		c.notify(EntryScheduled{Entry: e.ID, Time: now, Next: e.Next})
+	}
+}
+
+// skipped counts an activation of the entry that was not run, as missed if it
+// was dropped for being late, and notifies the listeners. The skip must have
+// been logged already.
+func (c *Cron) skipped(id EntryID, scheduled time.Time, reason SkipReason, detail string, missed bool) {
+	if missed {
+		c.stats.recordMiss(id, reason)
+	} else {
+		c.stats.recordSkip(id, reason)
+	}
+	c.history.record(id, RunRecord{Scheduled: scheduled, Result: ResultSkipped, SkipReason: reason})
+	if len(c.listeners) > 0 {
+		c.notify(JobSkipped{Entry: id, Scheduled: scheduled, Time: c.now(),
+			Reason: reason, Detail: detail, Missed: missed})
+	}
+}
+
+// scheduledKey is the context key of the time a run was scheduled for.
+type scheduledKey struct{}
+
+// scheduledFrom returns the time the run of ctx was scheduled for, if known.
+func scheduledFrom(ctx context.Context) time.Time {
+	t, _ := ctx.Value(scheduledKey{}).(time.Time)
+	return t
+}
+
+// scheduleKey is the context key of the schedule of the entry of a run.
+type scheduleKey struct{}
+
+// nextFrom returns the activation of the run's entry following the one the
+// run of ctx was scheduled for, if known.
+func nextFrom(ctx context.Context) (time.Time, bool) {
+	schedule, ok := ctx.Value(scheduleKey{}).(Schedule)
+	scheduled := scheduledFrom(ctx)
+	if !ok || scheduled.IsZero() {
+		return time.Time{}, false
+	}
+	return schedule.Next(scheduled), true
+}
+
+// clockKey is the context key of the clock of the Cron running a run (see
+// WithNowFunc).
+type clockKey struct{}
+
+// nowFrom returns the current time on the clock of the Cron running the run
+// of ctx, or on the wall clock outside of a Cron.
+func nowFrom(ctx context.Context) time.Time {
+	if now, ok := ctx.Value(clockKey{}).(func() time.Time); ok {
+		return now()
+	}
+	return time.Now()
+}
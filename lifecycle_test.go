@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder is an EventListener that records the events it receives.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []LifecycleEvent
+}
+
+func (r *eventRecorder) OnEvent(ev LifecycleEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *eventRecorder) recorded() []LifecycleEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LifecycleEvent(nil), r.events...)
+}
+
+func TestEventListenerRuns(t *testing.T) {
+	rec := &eventRecorder{}
+	var buf syncWriter
+	cron := New(WithEventListener(rec),
+		WithChain(Recover(PrintfLogger(log.New(&buf, "", 0)))))
+	ok, _ := cron.AddFunc("@hourly", func() {})
+	panics, _ := cron.AddFunc("@hourly", func() { panic("boom") })
+
+	scheduled := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cron.startJob(cron.store.Get(ok), scheduled)
+	cron.jobWaiter.Wait()
+	cron.startJob(cron.store.Get(panics), scheduled)
+	cron.jobWaiter.Wait()
+
+	events := rec.recorded()
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d: %+v", len(events), events)
+	}
+	if ev, ok := events[0].(JobStarted); !ok || !ev.Scheduled.Equal(scheduled) {
+		t.Errorf("expected JobStarted for %v, got %+v", scheduled, events[0])
+	}
+	if ev, ok := events[1].(JobCompleted); !ok || ev.Failed {
+		t.Errorf("expected a successful JobCompleted, got %+v", events[1])
+	}
+	if ev, ok := events[3].(JobPanicked); !ok || ev.Value != "boom" || len(ev.Stack) == 0 {
+		t.Errorf("expected JobPanicked with the panic, got %+v", events[3])
+	}
+	if ev, ok := events[4].(JobCompleted); !ok || !ev.Failed || ev.Entry != panics {
+		t.Errorf("expected a failed JobCompleted, got %+v", events[4])
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("expected the panic to reach Recover, got %q", buf.String())
+	}
+}
+
+func TestEventListenerScheduler(t *testing.T) {
+	rec := &eventRecorder{}
+	cron := New(WithEventListener(rec), WithStaleThreshold(time.Minute))
+	id, _ := cron.AddFunc("@hourly", func() {})
+	cron.Start()
+	cron.Stop()
+
+	// A stale run is skipped.
+	e := cron.store.Get(id)
+	e.Next = time.Now().Add(-time.Hour)
+	cron.fire(e, time.Now())
+
+	var types []string
+	for _, ev := range rec.recorded() {
+		switch ev := ev.(type) {
+		case SchedulerStarted:
+			types = append(types, "started")
+		case EntryScheduled:
+			if ev.Entry == id && !ev.Next.IsZero() {
+				types = append(types, "scheduled")
+			}
+		case SchedulerStopped:
+			types = append(types, "stopped")
+		case JobSkipped:
+			if ev.Reason == "stale" && ev.Missed {
+				types = append(types, "skipped")
+			}
+		}
+	}
+	if got := fmt.Sprint(types); got != "[started scheduled stopped skipped]" {
+		t.Errorf("unexpected events: %v", got)
+	}
+}
@@ -0,0 +1,313 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Locker is a lock shared by several instances of a service, e.g. backed by
+// Redis or a database. See WithDistributedLock.
+type Locker interface {
+	// Lock tries to acquire the lock with the given key, without waiting. The
+	// lock must expire after ttl even if it is never released, so that a
+	// crashed holder cannot block the others forever. It returns false if
+	// the lock is held elsewhere; otherwise it returns a func that releases
+	// the lock.
+	Lock(key string, ttl time.Duration) (bool, func())
+}
+
+// LeaseLocker is a Locker whose locks can be kept alive by heartbeats. When
+// the locker given to WithDistributedLock implements it, the lock is renewed
+// while the run is in progress, so ttl need only cover a few heartbeats rather
+// than the job's longest run, and the lock of an instance that dies mid-run
+// expires soon after.
+type LeaseLocker interface {
+	Locker
+
+	// Acquire tries to acquire the lock with the given key, without waiting,
+	// for ttl. It returns false if the lock is held elsewhere.
+	Acquire(key string, ttl time.Duration) (Lease, bool)
+}
+
+// Lease is a lock acquired from a LeaseLocker.
+type Lease interface {
+	// Renew extends the lock to expire ttl from now. It returns false if the
+	// lock has been lost, because it expired or was broken.
+	Renew(ttl time.Duration) bool
+
+	// Release releases the lock.
+	Release()
+}
+
+// LockManager is implemented by Lockers that support inspecting and breaking
+// their locks, e.g. from admin tooling when an instance died holding a lock.
+type LockManager interface {
+	// LockedEntries returns the locks currently held.
+	LockedEntries() ([]LockInfo, error)
+
+	// BreakLock releases the lock with the given key, whoever holds it. The
+	// holder's next Renew fails.
+	BreakLock(key string) error
+}
+
+// LockInfo describes a held lock.
+type LockInfo struct {
+	// Key is the key of the lock, which identifies an entry.
+	Key string
+	// Holder identifies the instance holding the lock, if the locker knows it.
+	Holder string
+	// Acquired is when the lock was acquired.
+	Acquired time.Time
+	// Heartbeat is when the lock was last acquired or renewed.
+	Heartbeat time.Time
+	// Expires is when the lock expires unless it is renewed.
+	Expires time.Time
+}
+
+// Stale reports whether the lock has outlived its TTL at the given time, i.e.
+// its holder stopped renewing it, probably because it died. Lockers whose
+// backend does not expire locks by itself may report such locks; they are
+// safe to break.
+func (l LockInfo) Stale(now time.Time) bool {
+	return !l.Expires.IsZero() && !now.Before(l.Expires)
+}
+
+// ErrNoLockManager is returned by the lock methods of a Cron created without
+// WithLockManager.
+var ErrNoLockManager = errors.New("cron: no lock manager configured")
+
+// MemoryLocker is a LeaseLocker and LockManager keeping its locks in memory.
+// It serializes the runs of Crons within a single process, e.g. several
+// tenants' schedulers or tests of WithDistributedLock; replicas in separate
+// processes need a locker backed by shared storage. A lock whose holder
+// stopped renewing it is stale once its ttl has passed, and may be acquired
+// again. The zero value is ready to use.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]*LockInfo
+}
+
+// NewMemoryLocker returns an empty MemoryLocker.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{}
+}
+
+// Lock implements Locker.
+func (l *MemoryLocker) Lock(key string, ttl time.Duration) (bool, func()) {
+	lease, ok := l.Acquire(key, ttl)
+	if !ok {
+		return false, nil
+	}
+	return true, lease.Release
+}
+
+// Acquire implements LeaseLocker. It takes over the lock if it is stale.
+func (l *MemoryLocker) Acquire(key string, ttl time.Duration) (Lease, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if info, ok := l.locks[key]; ok && !info.Stale(now) {
+		return nil, false
+	}
+	if l.locks == nil {
+		l.locks = make(map[string]*LockInfo)
+	}
+	info := &LockInfo{Key: key, Acquired: now, Heartbeat: now, Expires: now.Add(ttl)}
+	l.locks[key] = info
+	return &memoryLease{l, info}, true
+}
+
+// LockedEntries implements LockManager. It returns the locks sorted by key,
+// including stale ones that were not taken over yet.
+func (l *MemoryLocker) LockedEntries() ([]LockInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	locks := make([]LockInfo, 0, len(l.locks))
+	for _, info := range l.locks {
+		locks = append(locks, *info)
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Key < locks[j].Key })
+	return locks, nil
+}
+
+// BreakLock implements LockManager. Breaking a lock that is not held does
+// nothing.
+func (l *MemoryLocker) BreakLock(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.locks, key)
+	return nil
+}
+
+// memoryLease is a lock acquired from a MemoryLocker.
+type memoryLease struct {
+	locker *MemoryLocker
+	info   *LockInfo
+}
+
+func (m *memoryLease) Renew(ttl time.Duration) bool {
+	m.locker.mu.Lock()
+	defer m.locker.mu.Unlock()
+	if m.locker.locks[m.info.Key] != m.info {
+		return false
+	}
+	now := time.Now()
+	m.info.Heartbeat, m.info.Expires = now, now.Add(ttl)
+	return true
+}
+
+func (m *memoryLease) Release() {
+	m.locker.mu.Lock()
+	defer m.locker.mu.Unlock()
+	if m.locker.locks[m.info.Key] == m.info {
+		delete(m.locker.locks, m.info.Key)
+	}
+}
+
+// Locks returns the distributed locks currently held, as reported by the
+// LockManager given to WithLockManager, or ErrNoLockManager.
+func (c *Cron) Locks() ([]LockInfo, error) {
+	if c.locks == nil {
+		return nil, ErrNoLockManager
+	}
+	return c.locks.LockedEntries()
+}
+
+// BreakLock releases the distributed lock with the given key, whoever holds
+// it, e.g. after the instance holding it died. It returns ErrNoLockManager for
+// a Cron created without WithLockManager.
+func (c *Cron) BreakLock(key string) error {
+	if c.locks == nil {
+		return ErrNoLockManager
+	}
+	return c.locks.BreakLock(key)
+}
+
+// BreakStaleLocks releases the distributed locks that are stale at the
+// Cron's current time, i.e. whose holder stopped renewing them, and returns
+// them. It returns ErrNoLockManager for a Cron created without
+// WithLockManager.
+func (c *Cron) BreakStaleLocks() ([]LockInfo, error) {
+	locks, err := c.Locks()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		now    = c.now()
+		broken []LockInfo
+	)
+	for _, info := range locks {
+		if !info.Stale(now) {
+			continue
+		}
+		if err := c.locks.BreakLock(info.Key); err != nil {
+			return broken, err
+		}
+		c.logger.Info("broke stale lock", "key", info.Key, "holder", info.Holder,
+			"heartbeat", info.Heartbeat)
+		broken = append(broken, info)
+	}
+	return broken, nil
+}
+
+// ErrLockLost is logged when the lease of a running job could not be renewed,
+// e.g. because its lock was broken. The run's context is cancelled.
+var ErrLockLost = errors.New("cron: distributed lock lost")
+
+// WithDistributedLock runs the wrapped job only if the lock of its entry can
+// be acquired from locker, so that when several replicas of a service run the
+// same entries, only one of them executes each run. The others skip the run
+// and log it to the given logger at Info level. The lock is released when the
+// run completes, or expires after ttl, which should exceed the job's longest
+// run unless locker is a LeaseLocker. It panics if ttl is not positive.
+//
+// The key of the lock is the given prefix followed by the entry's name, or by
+// its ID and spec if it has none, so that the wrapper may be shared by every
+// entry, e.g. with WithChain. When the wrapped job is run other than by a
+// Cron, the key is the prefix alone. Entries must be named, or added in the
+// same order, on every replica. A replica whose clock lags may fire after the
+// lock was released and run the occurrence again; combine with WithOnceGuard
+// where that matters.
+func WithDistributedLock(locker Locker, prefix string, ttl time.Duration, logger Logger) JobWrapper {
+	if ttl/3 <= 0 {
+		panic(fmt.Sprintf("cron: invalid distributed lock ttl %v", ttl))
+	}
+	return func(j Job) Job {
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			key := prefix
+			if entry, ok := ctx.Value(lockKeyKey{}).(string); ok {
+				key += ":" + entry
+			}
+			if ll, ok := locker.(LeaseLocker); ok {
+				lease, ok := ll.Acquire(key, ttl)
+				if !ok {
+					logger.Info("skip", "reason", "locked elsewhere", "key", key)
+					ReportSkip(ctx, SkipLocked, key)
+					return nil
+				}
+				defer lease.Release()
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				stop := heartbeat(lease, key, ttl, cancel, logger)
+				defer stop()
+				return RunJobE(ctx, j)
+			}
+			ok, unlock := locker.Lock(key, ttl)
+			if !ok {
+				logger.Info("skip", "reason", "locked elsewhere", "key", key)
+				ReportSkip(ctx, SkipLocked, key)
+				return nil
+			}
+			defer unlock()
+			return RunJobE(ctx, j)
+		})
+	}
+}
+
+// lockKeyKey is the context key of the part of the distributed lock key that
+// identifies the entry of a run.
+type lockKeyKey struct{}
+
+// entryLockKey returns the part of the distributed lock key that identifies
+// the entry: its name, which is unique, or else its ID and spec.
+func entryLockKey(e *Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strconv.Itoa(int(e.ID)) + " " + e.ScheduleSpec()
+}
+
+// heartbeat renews the lease every third of its ttl until the returned func
+// is called. If the lease is lost, it logs ErrLockLost and calls cancel.
+func heartbeat(lease Lease, key string, ttl time.Duration, cancel func(), logger Logger) func() {
+	var (
+		ticker = time.NewTicker(ttl / 3)
+		done   = make(chan struct{})
+		exited = make(chan struct{})
+	)
+	go func() {
+		defer close(exited)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !lease.Renew(ttl) {
+					logger.Error(ErrLockLost, "heartbeat", "key", key)
+					cancel()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
@@ -0,0 +1,223 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryLocker is a Locker shared by the "replicas" of a test.
+type memoryLocker struct {
+	mu   sync.Mutex
+	held map[string]time.Duration
+}
+
+func (l *memoryLocker) Lock(key string, ttl time.Duration) (bool, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.held[key]; ok {
+		return false, nil
+	}
+	l.held[key] = ttl
+	return true, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+	}
+}
+
+func TestWithDistributedLock(t *testing.T) {
+	var (
+		buf     bytes.Buffer
+		logger  = VerbosePrintfLogger(log.New(&buf, "", 0))
+		locker  = &memoryLocker{held: make(map[string]time.Duration)}
+		runs    int
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	job := FuncJob(func() {
+		runs++
+		close(started)
+		<-release
+	})
+	replica1 := NewChain(WithDistributedLock(locker, "report", time.Minute, logger)).Then(job)
+	replica2 := NewChain(WithDistributedLock(locker, "report", time.Minute, logger)).Then(job)
+
+	done := make(chan struct{})
+	go func() {
+		replica1.Run()
+		close(done)
+	}()
+	<-started
+	if ttl := locker.held["report"]; ttl != time.Minute {
+		t.Errorf("expected the lock to be held with ttl %v, got %v", time.Minute, ttl)
+	}
+
+	// The other replica skips while the lock is held.
+	replica2.Run()
+	if runs != 1 {
+		t.Errorf("expected one run, got %d", runs)
+	}
+	if !strings.Contains(buf.String(), "locked elsewhere") {
+		t.Errorf("expected the skip to be logged, got %q", buf.String())
+	}
+
+	close(release)
+	<-done
+	if _, ok := locker.held["report"]; ok {
+		t.Error("expected the lock to be released after the run")
+	}
+}
+
+func TestWithDistributedLockPerEntry(t *testing.T) {
+	var (
+		locker  = &memoryLocker{held: make(map[string]time.Duration)}
+		started = make(chan struct{}, 2)
+		release = make(chan struct{})
+	)
+	cron := New(WithChain(WithDistributedLock(locker, "svc", time.Minute, DiscardLogger)))
+	job := func() {
+		started <- struct{}{}
+		<-release
+	}
+	report, _ := cron.AddFunc("@daily", job, WithName("report"))
+	unnamed, _ := cron.AddFunc("@hourly", job)
+	cron.RunNow(report)
+	cron.RunNow(unnamed)
+	defer cron.Stop()
+
+	// Entries sharing the wrapper do not share a lock.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(OneSecond):
+			t.Fatal("expected both entries to run")
+		}
+	}
+	locker.mu.Lock()
+	_, named := locker.held["svc:report"]
+	_, byID := locker.held[fmt.Sprintf("svc:%d @hourly", unnamed)]
+	locker.mu.Unlock()
+	if !named || !byID {
+		t.Errorf("expected locks keyed by entry, got %v", locker.held)
+	}
+	close(release)
+}
+
+func TestWithDistributedLockInvalidTTL(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a ttl too short to heartbeat")
+		}
+	}()
+	WithDistributedLock(NewMemoryLocker(), "report", 2, DiscardLogger)
+}
+
+func TestWithDistributedLockHeartbeat(t *testing.T) {
+	var (
+		buf     syncWriter
+		logger  = VerbosePrintfLogger(log.New(&buf, "", 0))
+		locker  = NewMemoryLocker()
+		ttl     = 30 * time.Millisecond
+		started = make(chan struct{})
+		aborted = make(chan struct{})
+	)
+	job := ContextFuncJob(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(aborted)
+	})
+	go NewChain(WithDistributedLock(locker, "report", ttl, logger)).Then(job).Run()
+	<-started
+
+	// The lock outlives its ttl while the run is alive.
+	time.Sleep(3 * ttl)
+	locks, _ := locker.LockedEntries()
+	if len(locks) != 1 || locks[0].Stale(time.Now()) || !locks[0].Heartbeat.After(locks[0].Acquired) {
+		t.Fatalf("expected a renewed lock, got %+v", locks)
+	}
+	var ran bool
+	NewChain(WithDistributedLock(locker, "report", ttl, logger)).Then(FuncJob(func() { ran = true })).Run()
+	if ran {
+		t.Error("expected the other replica to skip while the lock is renewed")
+	}
+
+	// Breaking the lock aborts the run.
+	locker.BreakLock("report")
+	select {
+	case <-aborted:
+	case <-time.After(OneSecond):
+		t.Fatal("expected the run to be aborted when its lock is broken")
+	}
+	if !strings.Contains(buf.String(), ErrLockLost.Error()) {
+		t.Errorf("expected the lost lock to be logged, got %q", buf.String())
+	}
+}
+
+func TestLockInfoStale(t *testing.T) {
+	now := time.Now()
+	if (LockInfo{Expires: now.Add(time.Second)}).Stale(now) {
+		t.Error("expected a live lock not to be stale")
+	}
+	if !(LockInfo{Expires: now}).Stale(now) {
+		t.Error("expected an expired lock to be stale")
+	}
+	if (LockInfo{}).Stale(now) {
+		t.Error("expected a lock without expiry not to be stale")
+	}
+}
+
+func TestMemoryLockerTakesOverStaleLock(t *testing.T) {
+	locker := NewMemoryLocker()
+	if ok, _ := locker.Lock("report", time.Millisecond); !ok {
+		t.Fatal("expected to acquire the lock")
+	}
+	if ok, _ := locker.Lock("report", time.Minute); ok {
+		t.Fatal("expected the lock to be held")
+	}
+	time.Sleep(5 * time.Millisecond)
+	ok, unlock := locker.Lock("report", time.Minute)
+	if !ok {
+		t.Fatal("expected the stale lock to be taken over")
+	}
+	unlock()
+	if locks, _ := locker.LockedEntries(); len(locks) != 0 {
+		t.Errorf("expected no locks after release, got %+v", locks)
+	}
+}
+
+func TestCronLocks(t *testing.T) {
+	if _, err := New().Locks(); err != ErrNoLockManager {
+		t.Errorf("expected ErrNoLockManager, got %v", err)
+	}
+
+	var (
+		locker = NewMemoryLocker()
+		now    = time.Now()
+	)
+	cron := New(WithLockManager(locker), WithNowFunc(func() time.Time { return now }))
+	locker.Lock("report", time.Minute)
+	locker.Lock("cleanup", time.Hour)
+	locks, err := cron.Locks()
+	if err != nil || len(locks) != 2 || locks[0].Key != "cleanup" || locks[1].Key != "report" {
+		t.Fatalf("expected both locks, got %+v, %v", locks, err)
+	}
+
+	// Only the lock that outlived its ttl is stale.
+	now = now.Add(2 * time.Minute)
+	broken, err := cron.BreakStaleLocks()
+	if err != nil || len(broken) != 1 || broken[0].Key != "report" {
+		t.Fatalf("expected the report lock to be broken, got %+v, %v", broken, err)
+	}
+	if err := cron.BreakLock("cleanup"); err != nil {
+		t.Fatal(err)
+	}
+	if locks, _ := cron.Locks(); len(locks) != 0 {
+		t.Errorf("expected no locks left, got %+v", locks)
+	}
+}
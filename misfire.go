@@ -0,0 +1,109 @@
+package cron
+
+import "time"
+
+// MisfirePolicy determines what happens to a run of an entry when the
+// scheduler wakes up late for it, e.g. after the machine slept, a long GC
+// pause or a VM snapshot restore. See WithMisfirePolicy.
+type MisfirePolicy int
+
+const (
+	// MisfireRunOnce runs a late entry once, immediately, and then resumes
+	// its schedule from now, dropping any other occurrences missed meanwhile.
+	// It is the default.
+	MisfireRunOnce MisfirePolicy = iota
+	// MisfireSkip skips a late run, counting it as missed, and resumes the
+	// schedule from now.
+	MisfireSkip
+	// MisfireRunAll runs a late entry once for every occurrence missed, each
+	// with its own scheduled time, and then resumes the schedule from now.
+	MisfireRunAll
+	// MisfireRunLatest runs a late entry once, immediately, for the latest
+	// occurrence missed, and then resumes the schedule from now. Unlike
+	// MisfireRunOnce, the run's scheduled time is that of the latest
+	// occurrence rather than the earliest.
+	MisfireRunLatest
+)
+
+// MisfireDecision is returned by a misfire handler to choose what happens to
+// the occurrences of an entry that were missed. The zero MisfireDecision runs
+// the entry once, like MisfireRunOnce.
+type MisfireDecision struct {
+	// Policy is applied to the missed occurrences, unless Delay is set.
+	Policy MisfirePolicy
+	// Delay, if positive, postpones the late run to Delay from now instead,
+	// dropping the occurrences that fall due meanwhile. The handler is
+	// consulted again if the postponed run starts late too.
+	Delay time.Duration
+}
+
+// MisfireHandler decides, for each late wake-up, what happens to the missed
+// occurrences of an entry, given in order, earliest first. See OnMisfire.
+type MisfireHandler func(e Entry, missed []time.Time) MisfireDecision
+
+// misfireThreshold is how late a run may start before it is a misfire.
+var misfireThreshold = time.Second
+
+// maxMisfireRuns bounds the runs started for one late wake-up under
+// MisfireRunAll, e.g. for an "@every 1s" entry after a week of sleep.
+const maxMisfireRuns = 1000
+
+// fire starts the run of the given entry that was due at e.Next, applying the
+// entry's MisfirePolicy or misfire handler if now is too late for it, or
+// skipping it altogether if it is older than the stale threshold. It returns
+// true if the handler postponed the run, in which case e.Next has been
+// updated.
+func (c *Cron) fire(e *Entry, now time.Time) (deferred bool) {
+	late := c.lateness(e, now)
+	if c.stale > 0 && late > c.stale {
+		c.logger.Info("skip", "entry", e.ID, "scheduled", e.Next,
+			"reason", "stale", "late", late)
+		c.skipped(e.ID, e.Next, SkipStale, "", true)
+		return false
+	}
+	if late <= misfireThreshold {
+		c.startJob(e, e.Next)
+		return false
+	}
+	missed := missedOccurrences(e, now)
+	policy := e.misfire
+	if e.onMisfire != nil {
+		entry := *e
+		entry.Metadata = copyMetadata(e.Metadata)
+		d := e.onMisfire(entry, missed)
+		if d.Delay > 0 {
+			until := now.Add(d.Delay)
+			c.logger.Info("defer", "entry", e.ID, "scheduled", e.Next, "until", until,
+				"reason", "misfire", "late", late)
+			e.Next = until
+			return true
+		}
+		policy = d.Policy
+	}
+	switch policy {
+	case MisfireSkip:
+		c.logger.Info("skip", "entry", e.ID, "scheduled", e.Next,
+			"reason", "misfire", "late", late)
+		c.skipped(e.ID, e.Next, SkipMisfire, "", true)
+	case MisfireRunAll:
+		for _, t := range missed {
+			c.startJob(e, t)
+		}
+	case MisfireRunLatest:
+		c.startJob(e, missed[len(missed)-1])
+	default:
+		c.startJob(e, e.Next)
+	}
+	return false
+}
+
+// missedOccurrences returns the occurrences of the entry from e.Next up to
+// now, at most maxMisfireRuns of them. It always includes e.Next.
+func missedOccurrences(e *Entry, now time.Time) []time.Time {
+	missed := []time.Time{e.Next}
+	schedule := e.activeSchedule()
+	for t := schedule.Next(e.Next); !t.IsZero() && !t.After(now) && len(missed) < maxMisfireRuns; t = schedule.Next(t) {
+		missed = append(missed, t)
+	}
+	return missed
+}
@@ -0,0 +1,205 @@
+package cron
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMisfirePolicy(t *testing.T) {
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		policy MisfirePolicy
+		late   time.Duration
+		runs   int64
+		missed uint64
+	}{
+		{"on time", MisfireSkip, misfireThreshold, 1, 0},
+		{"run once", MisfireRunOnce, 150 * time.Second, 1, 0},
+		{"skip", MisfireSkip, 150 * time.Second, 0, 1},
+		{"run all", MisfireRunAll, 150 * time.Second, 3, 0},
+		{"run latest", MisfireRunLatest, 150 * time.Second, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int64
+			cron := New(WithChain(), WithLogger(DiscardLogger))
+			id := cron.Schedule(Every(time.Minute), FuncJob(func() { atomic.AddInt64(&runs, 1) }),
+				WithMisfirePolicy(tt.policy))
+			e := cron.store.Get(id)
+			e.Next = due
+
+			cron.fire(e, due.Add(tt.late))
+			cron.jobWaiter.Wait()
+
+			if n := atomic.LoadInt64(&runs); n != tt.runs {
+				t.Errorf("expected %d runs, got %d", tt.runs, n)
+			}
+			if missed := cron.StatsSnapshot()[id].Missed; missed != tt.missed {
+				t.Errorf("expected %d missed runs, got %d", tt.missed, missed)
+			}
+		})
+	}
+}
+
+func TestWithStaleThreshold(t *testing.T) {
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		late   time.Duration
+		runs   int64
+		missed uint64
+	}{
+		{"late", 30 * time.Second, 1, 0},
+		{"stale", 150 * time.Second, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int64
+			cron := New(WithChain(), WithLogger(DiscardLogger), WithStaleThreshold(time.Minute))
+			id := cron.Schedule(Every(time.Minute), FuncJob(func() { atomic.AddInt64(&runs, 1) }),
+				WithMisfirePolicy(MisfireRunAll))
+			e := cron.store.Get(id)
+			e.Next = due
+
+			cron.fire(e, due.Add(tt.late))
+			cron.jobWaiter.Wait()
+
+			if n := atomic.LoadInt64(&runs); n != tt.runs {
+				t.Errorf("expected %d runs, got %d", tt.runs, n)
+			}
+			if missed := cron.StatsSnapshot()[id].Missed; missed != tt.missed {
+				t.Errorf("expected %d missed runs, got %d", tt.missed, missed)
+			}
+		})
+	}
+}
+
+func TestOnMisfire(t *testing.T) {
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		decision  MisfireDecision
+		scheduled []time.Time
+		deferred  bool
+	}{
+		{"run once", MisfireDecision{}, []time.Time{due}, false},
+		{"run all", MisfireDecision{Policy: MisfireRunAll},
+			[]time.Time{due, due.Add(time.Minute), due.Add(2 * time.Minute)}, false},
+		{"run latest", MisfireDecision{Policy: MisfireRunLatest}, []time.Time{due.Add(2 * time.Minute)}, false},
+		{"skip", MisfireDecision{Policy: MisfireSkip}, nil, false},
+		{"delay", MisfireDecision{Policy: MisfireRunAll, Delay: time.Hour}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu        sync.Mutex
+				scheduled []time.Time
+				missed    []time.Time
+			)
+			cron := New(WithChain(), WithLogger(DiscardLogger))
+			id := cron.Schedule(Every(time.Minute), ContextFuncJob(func(ctx context.Context) {
+				mu.Lock()
+				scheduled = append(scheduled, scheduledFrom(ctx))
+				mu.Unlock()
+			}), WithMisfirePolicy(MisfireSkip), OnMisfire(func(e Entry, m []time.Time) MisfireDecision {
+				missed = m
+				return tt.decision
+			}))
+			e := cron.store.Get(id)
+			e.Next = due
+			now := due.Add(150 * time.Second)
+
+			deferred := cron.fire(e, now)
+			cron.jobWaiter.Wait()
+
+			if len(missed) != 3 || !missed[0].Equal(due) || !missed[2].Equal(due.Add(2*time.Minute)) {
+				t.Errorf("expected the handler to get the 3 missed occurrences, got %v", missed)
+			}
+			if deferred != tt.deferred {
+				t.Errorf("expected deferred %v, got %v", tt.deferred, deferred)
+			}
+			if tt.deferred && !e.Next.Equal(now.Add(tt.decision.Delay)) {
+				t.Errorf("expected the run postponed to %v, got %v", now.Add(tt.decision.Delay), e.Next)
+			}
+			sort.Slice(scheduled, func(i, j int) bool { return scheduled[i].Before(scheduled[j]) })
+			if len(scheduled) != len(tt.scheduled) {
+				t.Fatalf("expected runs scheduled at %v, got %v", tt.scheduled, scheduled)
+			}
+			for i := range scheduled {
+				if !scheduled[i].Equal(tt.scheduled[i]) {
+					t.Errorf("expected runs scheduled at %v, got %v", tt.scheduled, scheduled)
+				}
+			}
+		})
+	}
+}
+
+func TestOnMisfireNotConsultedOnTime(t *testing.T) {
+	due := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var called bool
+	cron := New(WithChain(), WithLogger(DiscardLogger))
+	id := cron.Schedule(Every(time.Minute), FuncJob(func() {}),
+		OnMisfire(func(Entry, []time.Time) MisfireDecision {
+			called = true
+			return MisfireDecision{}
+		}))
+	e := cron.store.Get(id)
+	e.Next = due
+
+	cron.fire(e, due.Add(misfireThreshold))
+	cron.jobWaiter.Wait()
+
+	if called {
+		t.Error("expected the misfire handler not to be called for a run on time")
+	}
+}
+
+func TestWithWarmStart(t *testing.T) {
+	var (
+		now       = time.Date(2026, 1, 2, 3, 5, 0, 0, time.UTC)
+		yesterday = time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	)
+	tests := []struct {
+		name   string
+		opts   []Option
+		policy MisfirePolicy
+		runs   int64
+		missed uint64
+	}{
+		{"cold start", nil, MisfireRunOnce, 0, 0},
+		{"run once", []Option{WithWarmStart()}, MisfireRunOnce, 1, 0},
+		{"skip", []Option{WithWarmStart()}, MisfireSkip, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int64
+			opts := append([]Option{WithLocation(time.UTC), WithLogger(DiscardLogger),
+				WithNowFunc(func() time.Time { return now })}, tt.opts...)
+			cron := New(opts...)
+			id, _ := cron.AddFunc("0 3 * * *", func() { atomic.AddInt64(&runs, 1) },
+				WithMisfirePolicy(tt.policy))
+			// As if loaded from a persistent store after running yesterday.
+			cron.store.Get(id).Prev = yesterday
+
+			cron.Start()
+			time.Sleep(50 * time.Millisecond)
+			cron.Stop()
+			cron.jobWaiter.Wait()
+
+			if n := atomic.LoadInt64(&runs); n != tt.runs {
+				t.Errorf("expected %d runs, got %d", tt.runs, n)
+			}
+			if missed := cron.StatsSnapshot()[id].Missed; missed != tt.missed {
+				t.Errorf("expected %d missed runs, got %d", tt.missed, missed)
+			}
+			if next := cron.Entry(id).Next; !next.Equal(yesterday.AddDate(0, 0, 2)) {
+				t.Errorf("expected the entry to resume tomorrow at 03:00, got %v", next)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MonotonicConfig configures WithMonotonicNext.
+type MonotonicConfig struct {
+	// MinStep is how long after the time given to Next an entry whose
+	// schedule returned an earlier or equal time is run instead. It defaults
+	// to one second.
+	MinStep time.Duration
+	// Panic makes such a schedule panic the scheduler instead, with the entry
+	// and the times involved, e.g. while developing a Schedule.
+	Panic bool
+}
+
+// ErrNonMonotonicNext is logged when the schedule of an entry returns a next
+// activation that is not after the time it was given. See WithMonotonicNext.
+var ErrNonMonotonicNext = errors.New("cron: schedule returned a time not after the given time")
+
+// NonMonotonicNext is reported when the schedule of an entry returns a next
+// activation that is not after the time it was given. See WithMonotonicNext.
+type NonMonotonicNext struct {
+	Entry EntryID
+	// After is the time given to the schedule, and Returned what it returned.
+	After, Returned time.Time
+	// Corrected is the activation used instead.
+	Corrected time.Time
+}
+
+func (NonMonotonicNext) lifecycleEvent() {}
+
+// nextActivation returns the activation of the entry following now, according
+// to its active schedule, enforcing WithMonotonicNext if configured.
+func (c *Cron) nextActivation(e *Entry, now time.Time) time.Time {
+	next := e.activeSchedule().Next(now)
+	if c.monotonic == nil || next.IsZero() || next.After(now) {
+		return next
+	}
+	if c.monotonic.Panic {
+		panic(fmt.Sprintf("cron: schedule of entry %d returned %v for %v", e.ID, next, now))
+	}
+	corrected := now.Add(c.monotonic.MinStep)
+	c.logger.Error(ErrNonMonotonicNext, "correct", "entry", e.ID, "now", now,
+		"returned", next, "next", corrected)
+	c.notify(NonMonotonicNext{Entry: e.ID, After: now, Returned: next, Corrected: corrected})
+	return corrected
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMonotonicNext(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rec := &eventRecorder{}
+	cron := New(WithMonotonicNext(MonotonicConfig{}), WithEventListener(rec), WithLogger(DiscardLogger))
+	stuck := cron.store.Get(cron.Schedule(fixedSchedule{now}, FuncJob(func() {})))
+	done := cron.store.Get(cron.Schedule(fixedSchedule{}, FuncJob(func() {})))
+	fine := cron.store.Get(cron.Schedule(fixedSchedule{now.Add(time.Minute)}, FuncJob(func() {})))
+
+	if next := cron.nextActivation(stuck, now); !next.Equal(now.Add(time.Second)) {
+		t.Errorf("expected the activation to be moved a second forward, got %v", next)
+	}
+	if next := cron.nextActivation(done, now); !next.IsZero() {
+		t.Errorf("expected the zero time to be kept, got %v", next)
+	}
+	if next := cron.nextActivation(fine, now); !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected the activation to be kept, got %v", next)
+	}
+
+	var events []NonMonotonicNext
+	for _, ev := range rec.recorded() {
+		if ev, ok := ev.(NonMonotonicNext); ok {
+			events = append(events, ev)
+		}
+	}
+	expected := NonMonotonicNext{Entry: stuck.ID, After: now, Returned: now, Corrected: now.Add(time.Second)}
+	if len(events) != 1 || events[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, events)
+	}
+
+	// Without the option, the schedule is trusted.
+	unguarded := New()
+	e := unguarded.store.Get(unguarded.Schedule(fixedSchedule{now}, FuncJob(func() {})))
+	if next := unguarded.nextActivation(e, now); !next.Equal(now) {
+		t.Errorf("expected the returned time, got %v", next)
+	}
+}
+
+func TestMonotonicNextPanic(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cron := New(WithMonotonicNext(MonotonicConfig{Panic: true}))
+	e := cron.store.Get(cron.Schedule(fixedSchedule{now.Add(-time.Hour)}, FuncJob(func() {})))
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	cron.nextActivation(e, now)
+}
+
+func TestMonotonicNextPreventsHotLoop(t *testing.T) {
+	var runs int64
+	cron := New(WithMonotonicNext(MonotonicConfig{MinStep: 100 * time.Millisecond}),
+		WithLogger(DiscardLogger))
+	cron.Schedule(fixedSchedule{time.Now()}, FuncJob(func() { atomic.AddInt64(&runs, 1) }))
+	cron.Start()
+	time.Sleep(350 * time.Millisecond)
+	cron.Stop()
+	if n := atomic.LoadInt64(&runs); n < 2 || n > 5 {
+		t.Errorf("expected a run every 100ms, got %d runs", n)
+	}
+}
@@ -0,0 +1,71 @@
+package cron
+
+import "errors"
+
+// ErrDuplicateName is returned when adding an entry with the name of an
+// existing entry.
+var ErrDuplicateName = errors.New("cron: an entry with this name already exists")
+
+// WithName names the entry, e.g. "cleanup", so that it can be found with
+// EntryByName and recognized in Entries and status reports. Names must be
+// unique among the entries of a Cron.
+func WithName(name string) EntryOption {
+	return func(e *Entry) {
+		e.Name = name
+	}
+}
+
+// WithLabels attaches the given labels to the entry. It is equivalent to
+// WithMetadata.
+func WithLabels(labels map[string]string) EntryOption {
+	return WithMetadata(labels)
+}
+
+// EntryByName returns a snapshot of the entry with the given name, or the zero
+// Entry if there is none.
+func (c *Cron) EntryByName(name string) Entry {
+	if name == "" {
+		return Entry{}
+	}
+	for _, entry := range c.allEntries() {
+		if entry.Name == name {
+			return entry
+		}
+	}
+	return Entry{}
+}
+
+// reserveName records that the entry with the given ID has the given name. It
+// returns false if another entry has it. Names are tracked apart from the
+// store, since entries are added to and removed from the store by the run
+// loop after AddJob and Remove return.
+func (c *Cron) reserveName(name string, id EntryID) bool {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if other, ok := c.names[name]; ok && other != id {
+		return false
+	}
+	if c.names == nil {
+		c.names = make(map[string]EntryID)
+	}
+	c.names[name] = id
+	return true
+}
+
+// releaseName frees the name of the entry with the given ID, if any.
+func (c *Cron) releaseName(id EntryID) {
+	e := c.store.Get(id)
+	if e == nil || e.Name == "" {
+		return
+	}
+	c.unreserveName(e.Name, id)
+}
+
+// unreserveName frees the name if the entry with the given ID has it.
+func (c *Cron) unreserveName(name string, id EntryID) {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if c.names[name] == id {
+		delete(c.names, name)
+	}
+}
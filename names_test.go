@@ -0,0 +1,50 @@
+package cron
+
+import "testing"
+
+func TestEntryByName(t *testing.T) {
+	cron := New()
+	id, err := cron.AddFunc("@daily", func() {},
+		WithName("cleanup"), WithLabels(map[string]string{"team": "storage"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron.AddFunc("@hourly", func() {})
+
+	e := cron.EntryByName("cleanup")
+	if e.ID != id || e.Metadata["team"] != "storage" {
+		t.Errorf("expected entry %d with its labels, got %+v", id, e)
+	}
+	if e := cron.EntryByName("missing"); e.Valid() {
+		t.Errorf("expected no entry, got %+v", e)
+	}
+	if e := cron.EntryByName(""); e.Valid() {
+		t.Errorf("expected no entry for the empty name, got %+v", e)
+	}
+	for _, e := range cron.Entries() {
+		if e.ID == id && e.Name != "cleanup" {
+			t.Errorf("expected the name in Entries, got %q", e.Name)
+		}
+	}
+}
+
+func TestDuplicateName(t *testing.T) {
+	cron := New()
+	cron.Start()
+	defer cron.Stop()
+
+	id, _ := cron.AddFunc("@daily", func() {}, WithName("cleanup"))
+	if _, err := cron.AddFunc("@daily", func() {}, WithName("cleanup")); err != ErrDuplicateName {
+		t.Errorf("expected ErrDuplicateName, got %v", err)
+	}
+
+	// A name is free as soon as its entry is removed.
+	cron.Remove(id)
+	id, err := cron.AddFunc("@daily", func() {}, WithName("cleanup"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := cron.EntryByName("cleanup"); e.ID != id {
+		t.Errorf("expected entry %d, got %d", id, e.ID)
+	}
+}
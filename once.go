@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// OnceStore records which scheduled occurrences of a job have fired, so that
+// an occurrence is run at most once even if the process restarts around its
+// scheduled time. See WithOnceGuard.
+type OnceStore interface {
+	// Claim atomically records that the occurrence of the job identified by
+	// key that was scheduled for the given time has fired. It returns false if
+	// that occurrence (or a later one) had already been claimed.
+	Claim(key string, scheduled time.Time) (bool, error)
+}
+
+// onceGuard is the configuration installed by WithOnceGuard.
+type onceGuard struct {
+	store OnceStore
+	key   string
+}
+
+// WithOnceGuard claims each scheduled occurrence of the entry in the given
+// store before running it, and skips the run if the occurrence was already
+// claimed, e.g. by the same process before a quick restart. The key must
+// identify the job stably across restarts; entry IDs do not. If the store
+// fails, the run is skipped and the error logged.
+func WithOnceGuard(store OnceStore, key string) EntryOption {
+	return func(e *Entry) {
+		e.once = &onceGuard{store, key}
+	}
+}
+
+// FileOnceStore is a OnceStore persisted to a file, encoded as JSON unless
+// another Codec is set. It records the latest
+// claimed occurrence of each key. It is safe for concurrent use within a
+// process, but the file must not be shared by several processes.
+type FileOnceStore struct {
+	path string
+
+	mu     sync.Mutex
+	claims map[string]time.Time
+
+	// Codec encodes the file when it is saved. It defaults to JSONCodec.
+	// Files encoded with any registered codec are loaded.
+	Codec Codec
+}
+
+// NewFileOnceStore returns a FileOnceStore persisted at the given path,
+// loading the claims already recorded there, if any. Files written by older
+// versions of this package are migrated; files written by newer versions are
+// rejected with a *StateVersionError.
+func NewFileOnceStore(path string) (*FileOnceStore, error) {
+	s := &FileOnceStore{path: path, claims: make(map[string]time.Time), Codec: JSONCodec}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := UnmarshalState(StateKindOnce, data, &s.claims); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Claim records the occurrence and persists it before returning.
+func (s *FileOnceStore) Claim(key string, scheduled time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.claims[key]; ok && !scheduled.After(last) {
+		return false, nil
+	}
+	prev, existed := s.claims[key]
+	s.claims[key] = scheduled
+	if err := s.save(); err != nil {
+		if existed {
+			s.claims[key] = prev
+		} else {
+			delete(s.claims, key)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// save atomically replaces the file with the current claims.
+func (s *FileOnceStore) save() error {
+	data, err := MarshalStateCodec(s.Codec, StateKindOnce, s.claims)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// writeFileAtomic replaces the file at path with data, so that readers see
+// either the old or the new contents even if the process crashes midway.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
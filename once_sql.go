@@ -0,0 +1,77 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SQLOnceStore is a OnceStore backed by a SQL database, which may be shared
+// by several processes. It requires a table of the following shape:
+//
+//	CREATE TABLE cron_once (
+//		entry_key    VARCHAR(255) PRIMARY KEY,
+//		scheduled_ns BIGINT NOT NULL
+//	)
+type SQLOnceStore struct {
+	db    *sql.DB
+	table string
+
+	// Placeholder returns the bind parameter for the n-th (1-based) argument
+	// of a query. It defaults to "?"; use e.g. "$1" for PostgreSQL.
+	Placeholder func(n int) string
+
+	// TimeQuery returns the database's current time, for WithMaxClockSkew. It
+	// defaults to "SELECT CURRENT_TIMESTAMP"; the driver must be able to scan
+	// its result into a time.Time.
+	TimeQuery string
+}
+
+// NewSQLOnceStore returns a SQLOnceStore recording claims in the given table.
+func NewSQLOnceStore(db *sql.DB, table string) *SQLOnceStore {
+	return &SQLOnceStore{
+		db:          db,
+		table:       table,
+		Placeholder: func(int) string { return "?" },
+		TimeQuery:   "SELECT CURRENT_TIMESTAMP",
+	}
+}
+
+// StoreTime returns the database's current time.
+func (s *SQLOnceStore) StoreTime() (time.Time, error) {
+	var t time.Time
+	err := s.db.QueryRow(s.TimeQuery).Scan(&t)
+	return t, err
+}
+
+// Claim records the occurrence, unless it or a later one was already claimed.
+func (s *SQLOnceStore) Claim(key string, scheduled time.Time) (bool, error) {
+	p := s.Placeholder
+	ns := scheduled.UnixNano()
+
+	// Advance an existing claim, if it is older.
+	res, err := s.db.Exec(fmt.Sprintf(
+		"UPDATE %s SET scheduled_ns = %s WHERE entry_key = %s AND scheduled_ns < %s",
+		s.table, p(1), p(2), p(3)), ns, key, ns)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 1 {
+		return n == 1, err
+	}
+
+	// Otherwise record the first claim for the key. If a claim exists, it is
+	// at least as late as this occurrence.
+	res, err = s.db.Exec(fmt.Sprintf(
+		"INSERT INTO %s (entry_key, scheduled_ns) SELECT %s, %s WHERE NOT EXISTS "+
+			"(SELECT 1 FROM %s WHERE entry_key = %s)",
+		s.table, p(1), p(2), s.table, p(3)), key, ns, key)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -0,0 +1,58 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron_test
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestSQLOnceStore(t *testing.T) {
+	db, err := sql.Open("fakesql", fmt.Sprint(atomic.AddInt64(&fakeDBs, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	store := cron.NewSQLOnceStore(db, "cron_once")
+	if ok, err := store.Claim("nightly", daily); !ok || err != nil {
+		t.Fatalf("expected the first claim to succeed, got %v, %v", ok, err)
+	}
+	if ok, err := store.Claim("nightly", daily); ok || err != nil {
+		t.Errorf("expected a repeated claim to fail, got %v, %v", ok, err)
+	}
+	if ok, _ := store.Claim("other", daily); !ok {
+		t.Error("expected claims to be tracked per key")
+	}
+
+	// Another process sharing the database sees the claims, and takes over
+	// the expired claim of an earlier occurrence for the next one.
+	other := cron.NewSQLOnceStore(db, "cron_once")
+	if ok, _ := other.Claim("nightly", daily); ok {
+		t.Error("expected the claim to be shared")
+	}
+	if ok, _ := other.Claim("nightly", daily.Add(-24*time.Hour)); ok {
+		t.Error("expected an earlier occurrence to be rejected")
+	}
+	if ok, err := other.Claim("nightly", daily.Add(24*time.Hour)); !ok || err != nil {
+		t.Errorf("expected the next occurrence to be claimed, got %v, %v", ok, err)
+	}
+	if ok, _ := store.Claim("nightly", daily.Add(24*time.Hour)); ok {
+		t.Error("expected the next occurrence to be claimed once")
+	}
+}
+
+func TestSQLOnceStoreTime(t *testing.T) {
+	db, err := sql.Open("fakesql", fmt.Sprint(atomic.AddInt64(&fakeDBs, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if now, err := cron.NewSQLOnceStore(db, "cron_once").StoreTime(); err != nil || !now.Equal(fakeNow) {
+		t.Errorf("expected the database time %v, got %v, %v", fakeNow, now, err)
+	}
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileOnceStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "once.json")
+
+	daily := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	store, err := NewFileOnceStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := store.Claim("nightly", daily); !ok || err != nil {
+		t.Fatalf("expected the first claim to succeed, got %v, %v", ok, err)
+	}
+	if ok, _ := store.Claim("nightly", daily); ok {
+		t.Error("expected a repeated claim to fail")
+	}
+	if ok, _ := store.Claim("other", daily); !ok {
+		t.Error("expected claims to be tracked per key")
+	}
+
+	// Claims survive a restart.
+	store, err = NewFileOnceStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := store.Claim("nightly", daily); ok {
+		t.Error("expected the claim to be persisted")
+	}
+	if ok, _ := store.Claim("nightly", daily.Add(-24*time.Hour)); ok {
+		t.Error("expected an earlier occurrence to be rejected")
+	}
+	if ok, _ := store.Claim("nightly", daily.Add(24*time.Hour)); !ok {
+		t.Error("expected the next occurrence to be claimed")
+	}
+}
+
+// memoryOnceStore is a OnceStore for tests.
+type memoryOnceStore struct {
+	mu     sync.Mutex
+	claims map[string]time.Time
+}
+
+func (s *memoryOnceStore) Claim(key string, scheduled time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.claims[key]; ok && !scheduled.After(last) {
+		return false, nil
+	}
+	s.claims[key] = scheduled
+	return true, nil
+}
+
+func TestWithOnceGuard(t *testing.T) {
+	var calls int64
+	store := &memoryOnceStore{claims: make(map[string]time.Time)}
+	newCron := func() *Cron {
+		cron := New(WithParser(secondParser), WithChain(), WithLogger(DiscardLogger))
+		cron.AddFunc("* * * * * ?", func() { atomic.AddInt64(&calls, 1) },
+			WithOnceGuard(store, "job"))
+		return cron
+	}
+
+	// Two "processes" running the same job claim each occurrence once.
+	a, b := newCron(), newCron()
+	a.Start()
+	b.Start()
+	time.Sleep(OneSecond)
+	a.Stop()
+	b.Stop()
+
+	if n := atomic.LoadInt64(&calls); n != 1 {
+		t.Errorf("expected the occurrence to run once, got %d runs", n)
+	}
+	skipped := a.StatsSnapshot()[1].Skipped + b.StatsSnapshot()[1].Skipped
+	if skipped != 1 {
+		t.Errorf("expected one skipped run, got %d", skipped)
+	}
+}
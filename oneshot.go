@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoActivation is returned when adding an entry whose schedule has a
+// limited number of activations and none left, e.g. an At time that has
+// already passed.
+var ErrNoActivation = errors.New("cron: schedule has no activation")
+
+// OneShotSchedule activates once, at a given time. Its entry is removed after
+// that activation.
+type OneShotSchedule struct {
+	At time.Time
+}
+
+// At returns a Schedule that activates once, at the given time, e.g. for a
+// reminder. If the time has already passed when the entry is added, adding it
+// fails with ErrNoActivation; if it passes while the Cron is stopped, the entry
+// is removed when the Cron starts.
+func At(t time.Time) OneShotSchedule {
+	return OneShotSchedule{At: t}
+}
+
+// Next returns the time of the activation if it is later than the given time,
+// and the zero time otherwise.
+func (s OneShotSchedule) Next(t time.Time) time.Time {
+	if s.At.After(t) {
+		return s.At
+	}
+	return time.Time{}
+}
+
+func (OneShotSchedule) finite() {}
+
+// finiteSchedule is implemented by schedules with a limited number of
+// activations, such as OneShotSchedule. An entry whose finite schedule has no
+// activation left is rejected when it is added, and removed when the Cron
+// starts. Other schedules may also have no activation, e.g. a spec for
+// February 30th, and their entries are kept.
+type finiteSchedule interface {
+	finite()
+}
+
+// exhausted returns true if the entry's schedule is finite and next, its
+// next activation, is the zero time.
+func exhausted(e *Entry, next time.Time) bool {
+	_, ok := e.Schedule.(finiteSchedule)
+	return ok && next.IsZero()
+}
+
+// retire removes an entry whose schedule has no activation left after the one
+// just consumed, whether it ran or was skipped. Runs of the entry already
+// started complete normally, but a next run they request is ignored.
+func (c *Cron) retire(e *Entry) {
+	c.logger.Info("retired", "entry", e.ID)
+	c.removeEntry(e.ID, InitiatorScheduler)
+}
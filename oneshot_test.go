@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOneShotRemovedAfterRun(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []Event
+		ran    = make(chan struct{}, 2)
+	)
+	cron := New(WithEventHandler(func(ev Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}))
+	id := cron.Schedule(At(time.Now().Add(100*time.Millisecond)), FuncJob(func() { ran <- struct{}{} }))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the job to run")
+	}
+	// Entries is served by the run loop, so the entry has been retired by the
+	// time it answers.
+	if entries := cron.Entries(); len(entries) != 0 {
+		t.Errorf("expected the entry to be removed, got %v", entries)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	last := events[len(events)-1]
+	if last.Type != EventRemoved || last.Entry != id || last.Initiator != InitiatorScheduler {
+		t.Errorf("expected the scheduler to remove entry %d, got %+v", id, last)
+	}
+}
+
+func TestOneShotPassed(t *testing.T) {
+	now := time.Now()
+	if next := At(now).Next(now); !next.IsZero() {
+		t.Errorf("expected the zero time, got %v", next)
+	}
+	if next := At(now).Next(now.Add(-time.Second)); !next.Equal(now) {
+		t.Errorf("expected %v, got %v", now, next)
+	}
+}
+
+func TestExhaustedScheduleRejected(t *testing.T) {
+	cron := New(WithParser(NewParser(Minute | Hour | Dom | Month | Dow | ISO8601)))
+	if id := cron.Schedule(At(time.Now().Add(-time.Minute)), FuncJob(func() {})); id != 0 {
+		t.Errorf("expected a passed one-shot entry to be rejected, got entry %d", id)
+	}
+	if _, err := cron.AddFunc("R2/2020-01-01T00:00:00Z/PT1H", func() {}); err != ErrNoActivation {
+		t.Errorf("expected ErrNoActivation, got %v", err)
+	}
+	// A spec that matches no time is kept, as it always was.
+	if _, err := cron.AddFunc("0 0 30 2 *", func() {}); err != nil {
+		t.Error(err)
+	}
+	if entries := cron.Entries(); len(entries) != 1 {
+		t.Errorf("expected only the spec entry, got %v", entries)
+	}
+}
+
+func TestExhaustedScheduleRemovedOnStart(t *testing.T) {
+	cron := New()
+	cron.Schedule(At(time.Now().Add(10*time.Millisecond)), FuncJob(func() {}))
+	time.Sleep(20 * time.Millisecond)
+	cron.Start()
+	defer cron.Stop()
+	if entries := cron.Entries(); len(entries) != 0 {
+		t.Errorf("expected the passed one-shot entry to be removed, got %v", entries)
+	}
+}
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -14,6 +15,103 @@ func WithLocation(loc *time.Location) Option {
 	}
 }
 
+// WithNowFunc replaces time.Now as the cron's source of the current time,
+// e.g. to freeze time in integration tests or to simulate another time zone's
+// wall clock. It is used wherever the scheduler reads the current time, such as
+// when computing the first activation of added entries, recording statistics
+// and checking for misfires. Timers still measure real durations.
+func WithNowFunc(now func() time.Time) Option {
+	return func(c *Cron) {
+		c.nowFunc = now
+	}
+}
+
+// WithStaleThreshold skips runs that are due more than d in the past when the
+// scheduler wakes up, whatever the entry's MisfirePolicy, and counts them as
+// missed. This prevents bogus runs after the process is resumed from a long
+// suspension, e.g. an OS snapshot restore or laptop sleep. The entries resume
+// their schedules from the current time.
+func WithStaleThreshold(d time.Duration) Option {
+	return func(c *Cron) {
+		c.stale = d
+	}
+}
+
+// WithEventHandler calls handler with an Event for every change to the
+// entries of the cron: entries being created, updated, paused, resumed,
+// archived, restored, removed or triggered. The handler is called synchronously as each change is
+// applied, so it must return quickly and must not call the Cron's methods.
+func WithEventHandler(handler func(Event)) Option {
+	return func(c *Cron) {
+		c.onEvent = handler
+	}
+}
+
+// WithCipher encrypts the payloads of entries (see WithPayload) with the given
+// Cipher as they are added, and decrypts them when their runs are dispatched.
+func WithCipher(cipher Cipher) Option {
+	return func(c *Cron) {
+		c.cipher = cipher
+	}
+}
+
+// WithAllocSampling measures the heap allocations of the given fraction of
+// runs (between 0 and 1), and adds them to the AllocSamples and AllocBytes
+// statistics of their entries. Measuring briefly stops the world, so keep the
+// rate low for frequent jobs. Allocations are those of the whole process while
+// the run is in progress, so they are only indicative when runs overlap.
+func WithAllocSampling(rate float64) Option {
+	return func(c *Cron) {
+		c.allocRate = rate
+	}
+}
+
+// WithEventListener reports the lifecycle events of the scheduler and of runs,
+// such as JobStarted and JobCompleted, to the given listener, e.g. to export
+// metrics or update a UI without parsing logs. It may be given several times.
+func WithEventListener(l EventListener) Option {
+	return func(c *Cron) {
+		c.listeners = append(c.listeners, l)
+	}
+}
+
+// WithIdleCallback calls f from the scheduler's goroutine whenever the
+// scheduler becomes idle, with nothing scheduled, and whenever it leaves idle;
+// call IsIdle to tell which. A supervisor may use it to scale down or suspend
+// a process whose scheduler is empty. f must return quickly, and must not call
+// methods that wait for the scheduler, such as Entries.
+func WithIdleCallback(f func()) Option {
+	return func(c *Cron) {
+		c.onIdle = f
+	}
+}
+
+// WithInlineExecution runs jobs synchronously in the scheduler's goroutine,
+// one at a time and in schedule order, rather than each in its own goroutine.
+// This suits simulations, tests and platforms without threads, where runs
+// must be deterministic. Groups and worker pools are bypassed.
+//
+// A job that runs long delays all the activations that follow it, and Stop
+// waits for it to return. WithHardDeadline cannot abandon inline runs.
+func WithInlineExecution() Option {
+	return func(c *Cron) {
+		c.inline = true
+	}
+}
+
+// WithWarmStart makes the Cron resume entries that have run before, e.g. those
+// loaded from a persistent Store, from their Prev time when it starts, rather
+// than from the current time. An activation that fell due while the process
+// was down, such as a daily 03:00 run missed by a restart at 03:05, is then
+// recognized as missed and handled by the entry's MisfirePolicy, rather than
+// silently scheduled for the following day. WithStaleThreshold limits how old
+// such a run may be.
+func WithWarmStart() Option {
+	return func(c *Cron) {
+		c.warmStart = true
+	}
+}
+
 // WithSeconds overrides the parser used for interpreting job schedules to
 // include a seconds field as the first one.
 func WithSeconds() Option {
@@ -43,3 +141,351 @@ func WithLogger(logger Logger) Option {
 		c.logger = logger
 	}
 }
+
+// WithQuota limits the number of entries and the rate of job runs of this cron
+// to the given Quota. Entries beyond the limit are rejected by AddJob with a
+// *QuotaError, and runs beyond the limit are skipped and logged.
+func WithQuota(q *Quota) Option {
+	return func(c *Cron) {
+		c.quota = q
+	}
+}
+
+// WithHistory keeps the latest n activations of each entry, as returned by
+// History, so that e.g. when an entry last ran and whether it succeeded can be
+// answered. A history is kept only if n is positive.
+func WithHistory(n int) Option {
+	return func(c *Cron) {
+		if n > 0 {
+			c.history = newHistory(n)
+		}
+	}
+}
+
+// WithStore uses the provided Store to hold the entries of this cron.
+// Entries already present in the store are scheduled when the cron starts.
+func WithStore(store Store) Option {
+	return func(c *Cron) {
+		c.store = store
+	}
+}
+
+// WithHardDeadline cancels the context of each run (see JobWithContext) once
+// timeout has elapsed since it started, and abandons the runs that are still
+// in progress once grace has elapsed after that. The scheduler stops waiting
+// for an abandoned run, so the context returned by Stop is not held up by jobs
+// that ignore their context; the run itself is not interrupted. The
+// abandonment is logged with ErrRunAbandoned, recorded in the entry's history
+// (see WithHistory) and reported with a RunAbandoned lifecycle event.
+func WithHardDeadline(timeout, grace time.Duration) Option {
+	return func(c *Cron) {
+		c.deadline, c.deadlineGrace = timeout, grace
+	}
+}
+
+// WithWakeBudget limits the scheduler to the given number of wake-ups per
+// hour, for battery-powered and edge devices where each wake-up has a power
+// cost. Entries that fall due less than an hour/maxWakesPerHour after the
+// previous wake-up wait for the next one, and run together with the other
+// entries due by then. Such runs are not misfires; the entries they delayed
+// are logged and reported in a WakeCoalesced event. Entries never run early.
+func WithWakeBudget(maxWakesPerHour int) Option {
+	return func(c *Cron) {
+		if maxWakesPerHour > 0 {
+			c.minWake = time.Hour / time.Duration(maxWakesPerHour)
+		}
+	}
+}
+
+// WithMaxDispatchPerWake limits each wake-up of the scheduler to dispatching
+// the n earliest due entries. If more are due, the scheduler wakes up again
+// immediately for the remainder, after handling any pending requests such as
+// Stop or AddFunc. This keeps the scheduler responsive when very many entries
+// fall due at once, e.g. after the process was suspended. Stores implementing
+// ReadyLimiter, such as HeapStore, then only collect the entries dispatched.
+func WithMaxDispatchPerWake(n int) Option {
+	return func(c *Cron) {
+		c.maxDispatch = n
+	}
+}
+
+// WithMonotonicNext guards against schedules whose Next method returns a time
+// that is not after the time it was given, other than the zero time. Such a
+// schedule, e.g. a hand-written one meant to run once at startup that keeps
+// returning its start time, would otherwise make the scheduler run its entry
+// again and again without pause. Each violation is logged with
+// ErrNonMonotonicNext, reported with a NonMonotonicNext lifecycle event, and
+// corrected by running the entry MinStep after the given time instead, unless
+// the configuration asks to panic.
+//
+// Only the activations following runs and changes of state, such as Resume,
+// are checked; the first activation of an entry may be the current time.
+func WithMonotonicNext(config MonotonicConfig) Option {
+	return func(c *Cron) {
+		if config.MinStep <= 0 {
+			config.MinStep = time.Second
+		}
+		c.monotonic = &config
+	}
+}
+
+// WithLockManager lets the Cron inspect and break the distributed locks of
+// the given manager, usually the Locker given to WithDistributedLock. See
+// Cron.Locks, Cron.BreakLock and Cron.BreakStaleLocks.
+func WithLockManager(m LockManager) Option {
+	return func(c *Cron) {
+		c.locks = m
+	}
+}
+
+// WithReloader keeps the entries of the Cron in line with the given source,
+// e.g. a table of schedules in a database, without restarting it. The source
+// is read when the scheduler starts and then every interval while it runs,
+// or only when Reload is called if the interval is not positive. See Reload
+// for how the entries are matched.
+func WithReloader(src EntrySource, interval time.Duration) Option {
+	return func(c *Cron) {
+		c.reloader = &reloader{src: src, interval: interval}
+	}
+}
+
+// WithRandSource uses the provided source for all randomized behavior of this
+// cron, e.g. jitter. It may be used to make such behavior deterministic in
+// tests, or to supply a cryptographically secure source. The source is only
+// used while holding a lock, so it need not be safe for concurrent use.
+func WithRandSource(src rand.Source) Option {
+	return func(c *Cron) {
+		c.rand = newLockedRand(src)
+	}
+}
+
+// WithPrecision rounds the scheduler's wake-ups up to the given granularity,
+// trading punctuality for fewer wake-ups. For example, with a precision of one
+// minute, all entries due within a minute run together at the end of that
+// minute, which is useful on battery-powered devices. Entries never run early.
+// By default, the scheduler wakes exactly when the next entry is due.
+func WithPrecision(d time.Duration) Option {
+	return func(c *Cron) {
+		c.precision = d
+	}
+}
+
+// WithWorkerPool runs jobs on at most size goroutines at a time. Runs that
+// are due while all workers are busy wait in a queue, and start in order of
+// their entries' Priority. See also WithPriorityAging and Cron.PoolStats.
+func WithWorkerPool(size int) Option {
+	return func(c *Cron) {
+		c.poolSize = size
+	}
+}
+
+// WithPriorityAging raises the effective priority of a run waiting for a
+// worker by one for every interval it has spent in the queue, so that runs of
+// low priority entries are not starved by a saturated worker pool.
+func WithPriorityAging(interval time.Duration) Option {
+	return func(c *Cron) {
+		c.aging = interval
+	}
+}
+
+// WithAdmission installs a function that is consulted just before each run is
+// dispatched, with a snapshot of the entry and the run's scheduled time. It may
+// allow the run, skip it, or defer it to a later time; see AllowRun, SkipRun
+// and DeferRun. The function is called from the scheduler's goroutine, so it
+// should return quickly.
+func WithAdmission(admit func(e Entry, scheduled time.Time) Decision) Option {
+	return func(c *Cron) {
+		c.admission = admit
+	}
+}
+
+// WithStartStagger spreads out the first runs of entries that are due within
+// window of the cron starting, delaying each by a random amount less than
+// window. This prevents every entry (e.g. all "@every 1m" jobs) from firing at
+// once after a deploy. Entries added while the cron is running are not
+// affected.
+func WithStartStagger(window time.Duration) Option {
+	return func(c *Cron) {
+		c.staggerIn = window
+	}
+}
+
+// WithJournal records a marker in the given journal while each run of an
+// entry with a journal key (see WithJournalKey) is in progress. When the cron
+// starts, runs whose markers were left behind, e.g. by a crash, are logged and
+// made available through InterruptedRuns; with RerunInterrupted, their entries
+// also run again immediately.
+func WithJournal(j Journal, policy RecoveryPolicy) Option {
+	return func(c *Cron) {
+		c.journal = &journalConfig{journal: j, policy: policy}
+	}
+}
+
+// WithMaxClockSkew compares the local clock with the clock of the once store
+// of each guarded entry (see WithOnceGuard) before claiming a run, if the store
+// implements StoreClock. A skew larger than max is logged as ErrClockSkew; if
+// refuse is true, the run is also skipped rather than claimed, so that a badly
+// synchronized host cannot claim occurrences that other hosts consider
+// unclaimed and fire them twice.
+func WithMaxClockSkew(max time.Duration, refuse bool) Option {
+	return func(c *Cron) {
+		c.skew = &skewLimit{max: max, refuse: refuse}
+	}
+}
+
+// WithOwnerIsolation pauses all entries of an owner (see WithOwner) once runs
+// of that owner's entries have failed the given number of times in a row, so
+// that one team's broken jobs cannot keep consuming a shared process. Entries
+// of other owners are not affected. Paused entries may be resumed with
+// ResumeOwner.
+func WithOwnerIsolation(maxConsecutiveFailures int) Option {
+	return func(c *Cron) {
+		c.isolation = &ownerIsolation{
+			limit:    maxConsecutiveFailures,
+			failures: make(map[string]int),
+		}
+	}
+}
+
+// EntryOption represents a modification to an entry added to a Cron.
+type EntryOption func(*Entry)
+
+// WithDegradedSchedule runs the entry on the given schedule instead of its
+// normal one after a run of its job fails, until a later run succeeds. For
+// example, a sync may poll every minute normally, but every 10 seconds while
+// it is failing. A run fails if the job panics or returns an error (see
+// ErrorJob).
+func WithDegradedSchedule(schedule Schedule) EntryOption {
+	return func(e *Entry) {
+		e.DegradedSchedule = schedule
+	}
+}
+
+// WithDescription sets a human-readable description of the entry.
+func WithDescription(description string) EntryOption {
+	return func(e *Entry) {
+		e.Description = description
+	}
+}
+
+// WithMetadata attaches the given key/value pairs to the entry, e.g.
+// {"owner": "payments-team", "runbook": "https://..."}. The map is copied.
+func WithMetadata(metadata map[string]string) EntryOption {
+	return func(e *Entry) {
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string, len(metadata))
+		}
+		for k, v := range metadata {
+			e.Metadata[k] = v
+		}
+	}
+}
+
+// WithOwner sets the owner of the entry.
+func WithOwner(owner string) EntryOption {
+	return func(e *Entry) {
+		e.Owner = owner
+	}
+}
+
+// WithPriority sets the priority of the entry's runs when waiting for a
+// worker. See WithWorkerPool.
+func WithPriority(priority int) EntryOption {
+	return func(e *Entry) {
+		e.Priority = priority
+	}
+}
+
+// MaxQueueDelay drops a run of the entry that cannot start within d of its
+// scheduled time because all workers are busy, counting it as missed in the
+// entry's statistics rather than running it uselessly late. It only has an
+// effect with WithWorkerPool.
+func MaxQueueDelay(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.maxQueueDelay = d
+	}
+}
+
+// WithJournalKey identifies the entry in the journal configured with
+// WithJournal. The key must identify the job stably across restarts; entry IDs
+// do not. Entries without a key are not journaled.
+func WithJournalKey(key string) EntryOption {
+	return func(e *Entry) {
+		e.journalKey = key
+	}
+}
+
+// WithJobName names the entry's job, so that a persistent store such as
+// SQLStore can save the entry and resolve the name back to the job when the
+// process restarts. Entries without a name are not persisted.
+func WithJobName(name string) EntryOption {
+	return func(e *Entry) {
+		e.JobName = name
+	}
+}
+
+// WithMisfirePolicy sets what happens to a run of the entry when the scheduler
+// wakes up more than a second after the run was due: it may be run once (the
+// default), skipped, or run once for every occurrence that was missed.
+func WithMisfirePolicy(policy MisfirePolicy) EntryOption {
+	return func(e *Entry) {
+		e.misfire = policy
+	}
+}
+
+// OnMisfire sets a handler that decides what happens to the missed occurrences
+// of the entry whenever the scheduler wakes up more than a second after a run
+// was due, overriding its MisfirePolicy. The handler runs on the scheduler's
+// goroutine, so it must be fast; it may e.g. backfill month-end runs while
+// skipping the others. It is not consulted for runs past the stale threshold.
+func OnMisfire(handler MisfireHandler) EntryOption {
+	return func(e *Entry) {
+		e.onMisfire = handler
+	}
+}
+
+// WithDeadlineUntilNext sets the deadline of the context of each run of the
+// entry to the entry's following activation, so that a job that honors its
+// context cannot overrun into its own next slot. Combined with
+// SkipIfStillRunning, this gives each run exactly one slot. See
+// JobWithContext.
+func WithDeadlineUntilNext() EntryOption {
+	return func(e *Entry) {
+		e.deadlineUntilNext = true
+	}
+}
+
+// WithRunTimeout limits each run of the entry to the given duration, as the
+// WithTimeout JobWrapper does, logging to the Cron's logger. The timeout is
+// applied inside the entry's other wrappers, so time spent waiting in them
+// (e.g. for a Budget) does not count.
+func WithRunTimeout(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.timeout = d
+	}
+}
+
+// WithJitter delays each activation of the entry by a random duration of up
+// to max, so that many processes running the same schedule (e.g. "@hourly")
+// do not all hit a shared backend at the same second. Activations are never
+// moved earlier. max should be shorter than the interval between activations.
+// The random numbers come from the Cron's source; see WithRandSource.
+func WithJitter(max time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.jitter = max
+	}
+}
+
+// WithWrappers decorates the entry's job with the given JobWrappers, in
+// addition to those configured on the Cron with WithChain. The Cron's chain is
+// applied outermost. For example, to limit a heavy job to one OS thread from a
+// shared budget:
+//
+//	c.AddFunc("@every 1m", render, cron.WithWrappers(
+//		cron.LockOSThread(),
+//		cron.WithinBudget(gpuBudget)))
+func WithWrappers(wrappers ...JobWrapper) EntryOption {
+	return func(e *Entry) {
+		e.wrappers = append(e.wrappers, wrappers...)
+	}
+}
@@ -1,8 +1,11 @@
 package cron
 
 import (
+	"context"
 	"log"
+	"math/rand"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -40,3 +43,245 @@ func TestWithVerboseLogger(t *testing.T) {
 		t.Error("expected to see some actions, got:", out)
 	}
 }
+
+func TestWithDegradedSchedule(t *testing.T) {
+	var calls int64
+	ran := make(chan struct{}, 10)
+	cron := New(WithChain(Recover(DiscardLogger)), WithLogger(DiscardLogger))
+	id := cron.Schedule(EveryImmediate(time.Hour), FuncJob(func() {
+		defer func() { ran <- struct{}{} }()
+		if atomic.AddInt64(&calls, 1) == 1 {
+			panic("sync failed")
+		}
+	}), WithDegradedSchedule(Every(time.Second)))
+	cron.Start()
+	defer cron.Stop()
+
+	// The first run fails, switching the entry to its degraded schedule.
+	<-ran
+	time.Sleep(10 * time.Millisecond)
+	if e := cron.Entry(id); !e.Degraded || e.Next.After(time.Now().Add(OneSecond)) {
+		t.Fatalf("expected entry to be degraded and due within a second, got %+v", e)
+	}
+
+	// The second run succeeds, switching it back.
+	select {
+	case <-ran:
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the job to run on the degraded schedule")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if e := cron.Entry(id); e.Degraded || e.Next.Before(time.Now().Add(59*time.Minute)) {
+		t.Errorf("expected entry to be back on its normal schedule, got %+v", e)
+	}
+}
+
+func TestWithDescriptionAndMetadata(t *testing.T) {
+	metadata := map[string]string{"owner": "payments-team"}
+	cron := New()
+	id, _ := cron.AddFunc("@daily", func() {},
+		WithDescription("Reconcile payments"),
+		WithMetadata(metadata),
+		WithMetadata(map[string]string{"runbook": "https://example.com/runbook"}))
+	metadata["owner"] = "someone-else"
+
+	entry := cron.Entry(id)
+	if entry.Description != "Reconcile payments" {
+		t.Errorf("unexpected description: %q", entry.Description)
+	}
+	if entry.Metadata["owner"] != "payments-team" || entry.Metadata["runbook"] != "https://example.com/runbook" {
+		t.Errorf("unexpected metadata: %v", entry.Metadata)
+	}
+
+	// Snapshots may not modify the entry.
+	entry.Metadata["owner"] = "someone-else"
+	if cron.Entry(id).Metadata["owner"] != "payments-team" {
+		t.Error("expected metadata snapshot to be a copy")
+	}
+}
+
+func TestWithHardDeadline(t *testing.T) {
+	var buf syncWriter
+	release := make(chan struct{})
+	defer close(release)
+
+	cron := New(WithParser(secondParser), WithChain(), WithLogger(newBufLogger(&buf)),
+		WithHardDeadline(100*time.Millisecond, 100*time.Millisecond))
+	cron.AddFunc("* * * * * ?", func() { <-release })
+	cron.Start()
+	time.Sleep(OneSecond)
+
+	ctx := cron.Stop()
+	select {
+	case <-ctx.Done():
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("expected Stop to stop waiting for the abandoned run")
+	}
+	if !strings.Contains(buf.String(), "abandoned") {
+		t.Error("expected the abandoned run to be logged")
+	}
+}
+
+func TestWithHardDeadlineCancelsAtTimeout(t *testing.T) {
+	rec := &eventRecorder{}
+	release := make(chan struct{})
+	defer close(release)
+	cancelled := make(chan time.Duration, 1)
+
+	cron := New(WithChain(), WithLogger(DiscardLogger), WithHistory(5), WithEventListener(rec),
+		WithHardDeadline(50*time.Millisecond, 200*time.Millisecond))
+	id, _ := cron.AddFuncCtx("@daily", func(ctx context.Context) {
+		start := time.Now()
+		<-ctx.Done()
+		cancelled <- time.Since(start)
+		<-release
+	})
+	cron.Start()
+	defer cron.Stop()
+	cron.RunNow(id)
+
+	select {
+	case d := <-cancelled:
+		if d >= 200*time.Millisecond {
+			t.Errorf("expected the context to be cancelled at the timeout, got %v", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the run's context to be cancelled")
+	}
+	var abandoned []RunAbandoned
+	for deadline := time.Now().Add(time.Second); len(abandoned) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		for _, ev := range rec.recorded() {
+			if ev, ok := ev.(RunAbandoned); ok {
+				abandoned = append(abandoned, ev)
+			}
+		}
+	}
+	if len(abandoned) != 1 || abandoned[0].Entry != id || abandoned[0].Duration < 250*time.Millisecond {
+		t.Fatalf("expected the run to be abandoned after timeout and grace, got %+v", abandoned)
+	}
+	if runs := cron.History(id); len(runs) != 1 || runs[0].Result != ResultAbandoned {
+		t.Errorf("expected the abandonment in the history, got %+v", runs)
+	}
+}
+
+func TestWithPrecision(t *testing.T) {
+	tests := []struct {
+		precision time.Duration
+		next      string
+		expected  string
+	}{
+		{0, "Mon Jul 9 14:45:30 2012", "Mon Jul 9 14:45:30 2012"},
+		{time.Second, "Mon Jul 9 14:45:30 2012", "Mon Jul 9 14:45:30 2012"},
+		{time.Minute, "Mon Jul 9 14:45:30 2012", "Mon Jul 9 14:46 2012"},
+		{time.Minute, "Mon Jul 9 14:46 2012", "Mon Jul 9 14:46 2012"},
+		{time.Minute, "Mon Jul 9 23:59:01 2012", "Tue Jul 10 00:00 2012"},
+	}
+	for _, test := range tests {
+		cron := New(WithLocation(time.UTC), WithPrecision(test.precision))
+		next := getTime(test.next)
+		if actual, expected := cron.wakeAt(next), getTime(test.expected); !actual.Equal(expected) {
+			t.Errorf("precision %v, next %s: expected wake at %v, got %v",
+				test.precision, test.next, expected, actual)
+		}
+	}
+}
+
+func TestMaxQueueDelay(t *testing.T) {
+	release := make(chan struct{})
+	cron := New(WithParser(secondParser), WithChain(), WithWorkerPool(1))
+	blocker, _ := cron.AddFunc("* * * * * ?", func() { <-release }, WithPriority(1))
+	reminder, _ := cron.AddFunc("* * * * * ?", func() {}, MaxQueueDelay(100*time.Millisecond))
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(OneSecond)
+	close(release)
+	time.Sleep(100 * time.Millisecond)
+
+	stats := cron.StatsSnapshot()
+	if stats[reminder].Missed == 0 || stats[reminder].Missed != stats[reminder].Skipped {
+		t.Errorf("expected the reminder's late runs to be missed, got %+v", stats[reminder])
+	}
+	if stats[blocker].Missed != 0 {
+		t.Errorf("expected no missed runs without MaxQueueDelay, got %+v", stats[blocker])
+	}
+}
+
+func TestWithStartStagger(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cron := New(WithStartStagger(time.Minute), WithRandSource(rand.NewSource(1)))
+
+	seen := make(map[time.Time]bool)
+	for i := 0; i < 10; i++ {
+		next := cron.stagger(now, now)
+		if next.Before(now) || !next.Before(now.Add(time.Minute)) {
+			t.Fatalf("expected %v to be within the stagger window", next)
+		}
+		seen[next] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("expected first activations to be spread out, got %v", seen)
+	}
+
+	// Entries due after the window are left alone.
+	later := now.Add(time.Hour)
+	if next := cron.stagger(later, now); !next.Equal(later) {
+		t.Errorf("expected %v, got %v", later, next)
+	}
+	if next := New().stagger(now, now); !next.Equal(now) {
+		t.Errorf("expected no stagger by default, got %v", next)
+	}
+}
+
+func TestWithNowFunc(t *testing.T) {
+	frozen := time.Date(2030, 6, 1, 8, 30, 0, 0, time.UTC)
+	c := New(WithLocation(time.UTC), WithNowFunc(func() time.Time { return frozen }))
+	id, _ := c.AddFunc("0 9 * * *", func() {})
+	c.Start()
+	defer c.Stop()
+
+	expected := time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC)
+	if next := c.Entry(id).Next; !next.Equal(expected) {
+		t.Errorf("expected next %v, got %v", expected, next)
+	}
+	if since := c.StatsSnapshot()[id].Since; !since.Equal(frozen) {
+		t.Errorf("expected statistics since %v, got %v", frozen, since)
+	}
+}
+
+func TestWithInlineExecution(t *testing.T) {
+	var (
+		order   []string
+		running int32
+		done    = make(chan struct{})
+	)
+	job := func(name string) func() {
+		return func() {
+			if atomic.AddInt32(&running, 1) > 1 {
+				t.Error("expected runs not to overlap")
+			}
+			defer atomic.AddInt32(&running, -1)
+			order = append(order, name)
+			time.Sleep(10 * time.Millisecond)
+			if len(order) == 4 {
+				close(done)
+			}
+		}
+	}
+	cron := newWithSeconds()
+	WithInlineExecution()(cron)
+	cron.AddFunc("* * * * * ?", job("a"), WithDegradedSchedule(Every(time.Hour)))
+	cron.AddFunc("* * * * * ?", job("b"))
+	cron.Start()
+
+	select {
+	case <-done:
+	case <-time.After(3 * OneSecond):
+		t.Fatal("expected 4 runs")
+	}
+	cron.Stop()
+	if order[0] == order[1] || order[2] == order[3] {
+		t.Errorf("expected both entries to run at each activation, got %v", order)
+	}
+}
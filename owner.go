@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// ownerIsolation counts the consecutive failed runs of each owner's entries.
+type ownerIsolation struct {
+	limit int
+
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// record counts the outcome of a run of an entry of the given owner. It
+// returns true if the owner has reached the limit of consecutive failures, in
+// which case its count starts over.
+func (o *ownerIsolation) record(owner string, failed bool) bool {
+	if o == nil || owner == "" || o.limit <= 0 {
+		return false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !failed {
+		o.failures[owner] = 0
+		return false
+	}
+	o.failures[owner]++
+	if o.failures[owner] < o.limit {
+		return false
+	}
+	o.failures[owner] = 0
+	return true
+}
+
+// pauseOwner pauses every entry of the given owner.
+func (c *Cron) pauseOwner(owner string, now time.Time) {
+	var paused []EntryID
+	for _, e := range c.store.Entries() {
+		if e.Owner != owner || e.Paused {
+			continue
+		}
+		e.Paused = true
+		e.Next = time.Time{}
+		c.store.Update(e)
+		paused = append(paused, e.ID)
+		c.emit(Event{Type: EventPaused, Entry: e.ID, Spec: e.Spec,
+			Reason: "consecutive failures", Initiator: InitiatorScheduler})
+	}
+	c.logger.Info("pause", "now", now, "owner", owner, "reason", "consecutive failures",
+		"entries", paused)
+}
+
+// EntriesByOwner returns a snapshot of the entries of the given owner,
+// excluding archived ones.
+func (c *Cron) EntriesByOwner(owner string) []Entry {
+	var entries []Entry
+	for _, e := range c.Entries() {
+		if e.Owner == owner {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// PauseOwner pauses all entries of the given owner. It returns the IDs of the
+// entries that were paused.
+func (c *Cron) PauseOwner(owner string) []EntryID {
+	return c.PauseMany(c.ownerIDs(owner))
+}
+
+// ResumeOwner resumes all paused entries of the given owner, e.g. after they
+// were paused by WithOwnerIsolation. It returns the IDs of the entries that
+// were resumed.
+func (c *Cron) ResumeOwner(owner string) []EntryID {
+	return c.ResumeMany(c.ownerIDs(owner))
+}
+
+// ownerIDs returns the IDs of all entries of the given owner.
+func (c *Cron) ownerIDs(owner string) []EntryID {
+	var ids []EntryID
+	for _, e := range c.allEntries() {
+		if e.Owner == owner {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithOwnerIsolation(t *testing.T) {
+	var healthyRuns int32
+	cron := New(WithParser(secondParser), WithChain(Recover(DiscardLogger)), WithOwnerIsolation(2))
+	broken, _ := cron.AddFunc("* * * * * ?", func() { panic("broken") }, WithOwner("a"))
+	sibling, _ := cron.AddFunc("0 0 0 1 1 ?", func() {}, WithOwner("a"))
+	other, _ := cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&healthyRuns, 1) }, WithOwner("b"))
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(2*OneSecond + 100*time.Millisecond)
+	for _, id := range []EntryID{broken, sibling} {
+		if e := cron.Entry(id); !e.Paused {
+			t.Errorf("expected entry %d of the failing owner to be paused", id)
+		}
+	}
+	if e := cron.Entry(other); e.Paused {
+		t.Error("expected the other owner's entry to keep running")
+	}
+	if n := atomic.LoadInt32(&healthyRuns); n < 2 {
+		t.Errorf("expected the other owner's entry to run, got %d runs", n)
+	}
+
+	if resumed := cron.ResumeOwner("a"); len(resumed) != 2 {
+		t.Errorf("expected both entries to be resumed, got %v", resumed)
+	}
+	if e := cron.Entry(broken); e.Paused || e.Next.IsZero() {
+		t.Errorf("expected the entry to be scheduled again, got %+v", e)
+	}
+}
+
+func TestEntriesByOwner(t *testing.T) {
+	cron := New()
+	a, _ := cron.AddFunc("@daily", func() {}, WithOwner("a"))
+	cron.AddFunc("@daily", func() {}, WithOwner("b"))
+	archived, _ := cron.AddFunc("@daily", func() {}, WithOwner("a"))
+	cron.Archive(archived)
+
+	entries := cron.EntriesByOwner("a")
+	if len(entries) != 1 || entries[0].ID != a {
+		t.Errorf("expected only entry %d, got %v", a, entries)
+	}
+	if paused := cron.PauseOwner("a"); len(paused) != 2 {
+		t.Errorf("expected both entries of the owner to be paused, got %v", paused)
+	}
+}
@@ -24,6 +24,9 @@ const (
 	Dow                                    // Day of week field, default *
 	DowOptional                            // Optional day of week field, default *
 	Descriptor                             // Allow descriptors such as @monthly, @weekly, etc.
+	Quartz                                 // Quartz syntax: seconds to day of week, optional year, L, W, # and ?
+	Hashed                                 // Allow Jenkins-style hashed values such as H or H(0-7)
+	ISO8601                                // Allow ISO 8601 repeating intervals such as R5/2024-01-01T00:00:00Z/PT1H
 )
 
 var places = []ParseOption{
@@ -89,6 +92,12 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 	if len(spec) == 0 {
 		return nil, fmt.Errorf("empty spec string")
 	}
+	original := spec
+
+	// ISO 8601 repeating intervals carry their own time zone
+	if p.options&ISO8601 > 0 && strings.HasPrefix(spec, "R") {
+		return ParseISO8601(spec)
+	}
 
 	// Extract timezone if present
 	var loc = time.Local
@@ -113,6 +122,11 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 	// Split on whitespace.
 	fields := strings.Fields(spec)
 
+	// Quartz specs have their own fields and schedule
+	if p.options&Quartz > 0 {
+		return parseQuartz(original, fields, loc)
+	}
+
 	// Validate & fill in any omitted or optional fields
 	var err error
 	fields, err = normalizeFields(fields, p.options)
@@ -120,6 +134,21 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 		return nil, err
 	}
 
+	// Hashed values are resolved for each entry
+	if p.options&Hashed > 0 && hasHashedValues(fields) {
+		return newHashedSchedule(original, fields, loc)
+	}
+
+	schedule, err := specFromFields(fields, loc)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// specFromFields returns the schedule given by the normalized fields of a spec.
+func specFromFields(fields []string, loc *time.Location) (*SpecSchedule, error) {
+	var err error
 	field := func(field string, r bounds) uint64 {
 		if err != nil {
 			return 0
@@ -133,10 +162,16 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 		second     = field(fields[0], seconds)
 		minute     = field(fields[1], minutes)
 		hour       = field(fields[2], hours)
-		dayofmonth = field(fields[3], dom)
+		dayofmonth uint64
 		month      = field(fields[4], months)
-		dayofweek  = field(fields[5], dow)
+		dayofweek  uint64
 	)
+	if err == nil {
+		dayofmonth, err = getDomField(fields[3])
+	}
+	if err == nil {
+		dayofweek, err = getDowField(fields[5])
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +187,319 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 	}, nil
 }
 
+// SpecIssue is a problem found in a spec by ValidateSpec.
+type SpecIssue struct {
+	// Field is the index of the offending field among the fields of the spec
+	// as written, not counting a time zone prefix, or -1 if the issue concerns
+	// the spec as a whole, e.g. its time zone or number of fields.
+	Field int
+	// Name is the name of the offending field, e.g. "day of week", or "".
+	Name string
+	// Token is the offending part of the spec, e.g. "mon-xyz" in the day of
+	// week field "1,mon-xyz", or the time zone or descriptor.
+	Token string
+	// Message describes the problem.
+	Message string
+	// Suggestion, if not empty, describes what would be accepted instead.
+	Suggestion string
+}
+
+// Error returns the message, prefixed with the name of the field if any.
+func (i SpecIssue) Error() string {
+	if i.Name == "" {
+		return i.Message
+	}
+	return i.Name + " field: " + i.Message
+}
+
+// ValidateSpec checks the given spec as a Parser configured with the given
+// options would, and returns every problem found, or nil if the spec is valid.
+// Unlike Parse, which stops at the first invalid field, it validates each
+// field separately, so that all of them may be reported at once, e.g. to
+// highlight them in a form.
+func ValidateSpec(spec string, options ParseOption) []SpecIssue {
+	if len(spec) == 0 {
+		return []SpecIssue{{Field: -1, Message: "empty spec string",
+			Suggestion: "use " + expectedFields(options)}}
+	}
+	if options&ISO8601 > 0 && strings.HasPrefix(spec, "R") {
+		if _, err := ParseISO8601(spec); err != nil {
+			return []SpecIssue{{Field: -1, Token: spec, Message: err.Error(),
+				Suggestion: "use a repeating interval such as R5/2024-01-01T00:00:00Z/PT1H"}}
+		}
+		return nil
+	}
+
+	var issues []SpecIssue
+	var loc = time.Local
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return []SpecIssue{{Field: -1, Token: spec,
+				Message:    fmt.Sprintf("missing schedule after time zone: %s", spec),
+				Suggestion: "follow the time zone with " + expectedFields(options)}}
+		}
+		eq := strings.Index(spec, "=")
+		var err error
+		if loc, err = time.LoadLocation(spec[eq+1 : i]); err != nil {
+			issues = append(issues, SpecIssue{Field: -1, Token: spec[eq+1 : i],
+				Message:    fmt.Sprintf("provided bad location %s: %v", spec[eq+1:i], err),
+				Suggestion: "use an IANA time zone name, e.g. America/New_York"})
+			loc = time.Local
+		}
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		if options&Descriptor == 0 {
+			return append(issues, SpecIssue{Field: -1, Token: spec,
+				Message:    fmt.Sprintf("parser does not accept descriptors: %v", spec),
+				Suggestion: "use " + expectedFields(options)})
+		}
+		if _, err := parseDescriptor(spec, loc); err != nil {
+			issue := SpecIssue{Field: -1, Token: spec, Message: err.Error(),
+				Suggestion: "use one of " + strings.Join(descriptors, ", ")}
+			if strings.HasPrefix(spec, "@every ") {
+				issue.Suggestion = "use a duration such as 1h30m"
+			}
+			issues = append(issues, issue)
+		}
+		return issues
+	}
+
+	written := strings.Fields(spec)
+	if options&Quartz > 0 {
+		_, quartzIssues := quartzFields(written)
+		return append(issues, quartzIssues...)
+	}
+	fields, err := normalizeFields(written, options)
+	if err != nil {
+		return append(issues, SpecIssue{Field: -1, Token: spec, Message: err.Error(),
+			Suggestion: "use " + expectedFields(options)})
+	}
+	for i, n := range writtenFields(options, len(written)) {
+		if n < 0 {
+			continue
+		}
+		r := fieldBounds[i]
+		for _, expr := range strings.FieldsFunc(fields[i], func(r rune) bool { return r == ',' }) {
+			var (
+				err        error
+				suggestion = fieldSuggestion(r)
+			)
+			if options&Hashed > 0 && strings.HasPrefix(expr, "H") {
+				var resolved string
+				if resolved, err = expandHashed(expr, i, 0); err == nil {
+					_, err = getRange(resolved, r)
+				}
+				suggestion += ", or H, H(low-high) or H/step for a hashed value"
+			} else if places[i] == Dom {
+				_, err = getDomRange(expr)
+				suggestion += ", or L-n for n days before the last day of the month"
+			} else if places[i] == Dow {
+				_, err = getDowRange(expr)
+				suggestion += ", or 7 for Sunday"
+			} else {
+				_, err = getRange(expr, r)
+			}
+			if err != nil {
+				issues = append(issues, SpecIssue{Field: n, Name: fieldNames[i], Token: expr,
+					Message: err.Error(), Suggestion: suggestion})
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// writtenFields returns, for each of the places, the index of its field in a
+// spec of count fields accepted by the given options, or -1 if the field is
+// not written and takes its default.
+func writtenFields(options ParseOption, count int) []int {
+	max := 0
+	for _, place := range places {
+		if options&place > 0 || place == Second && options&SecondOptional > 0 ||
+			place == Dow && options&DowOptional > 0 {
+			max++
+		}
+	}
+	omitted := ParseOption(0)
+	if count < max {
+		omitted = Second | Dow
+	}
+	written := make([]int, len(places))
+	n := 0
+	for i, place := range places {
+		written[i] = -1
+		switch {
+		case options&place > 0:
+		case place == Second && options&SecondOptional > 0 && omitted&Second == 0:
+		case place == Dow && options&DowOptional > 0 && omitted&Dow == 0:
+		default:
+			continue
+		}
+		written[i] = n
+		n++
+	}
+	return written
+}
+
+// expectedFields describes the fields of a spec accepted by the options.
+func expectedFields(options ParseOption) string {
+	var names []string
+	for i, place := range places {
+		switch {
+		case options&place > 0:
+			names = append(names, fieldNames[i])
+		case place == Second && options&SecondOptional > 0, place == Dow && options&DowOptional > 0:
+			names = append(names, "optional "+fieldNames[i])
+		}
+	}
+	s := "the fields " + strings.Join(names, ", ")
+	if options&Descriptor > 0 {
+		s += ", or a descriptor such as @daily"
+	}
+	return s
+}
+
+// fieldSuggestion describes the values accepted by a field with the bounds.
+func fieldSuggestion(r bounds) string {
+	s := fmt.Sprintf("use values from %d to %d", r.min, r.max)
+	var first, last string
+	for name, value := range r.names {
+		switch value {
+		case r.min:
+			first = name
+		case r.max:
+			last = name
+		}
+	}
+	if first != "" && last != "" {
+		s += fmt.Sprintf(" or names from %s to %s", strings.ToUpper(first), strings.ToUpper(last))
+	}
+	return s + ", separated by commas, as ranges (a-b), steps (*/n) or *"
+}
+
+// fieldNames holds the names of the fields in places, in order.
+var fieldNames = []string{
+	"second",
+	"minute",
+	"hour",
+	"day of month",
+	"month",
+	"day of week",
+}
+
+// Capabilities describes exactly what a Parser accepts, so that front-ends can
+// build pickers and validators that match it.
+type Capabilities struct {
+	// Options are the ParseOptions the parser was created with.
+	Options ParseOption
+
+	// Fields describes the fields of a spec, in order. Fields the parser does
+	// not accept are omitted.
+	Fields []FieldCapabilities
+
+	// Seconds is true if a seconds field is accepted, possibly optionally.
+	Seconds bool
+
+	// Descriptors lists the accepted descriptors, such as "@daily", or is
+	// empty if descriptors are not enabled. "@every" takes a duration,
+	// optionally followed by "immediately" or by "from" and an RFC 3339 time.
+	// Any descriptor may be restricted to a window of time, as in "@hourly
+	// between 09:00 and 17:00".
+	Descriptors []string
+
+	// TimeZones is true if a spec may be prefixed with CRON_TZ= or TZ=.
+	TimeZones bool
+
+	// Hash, Last and Weekday report support for the "#" (nth weekday of the
+	// month), "L" (last day, or "L-n" for n days before it) and "W" (nearest
+	// weekday) extensions.
+	Hash, Last, Weekday bool
+
+	// Hashed is true if fields may have hashed values, such as "H" or
+	// "H(0-7)". See Hashed.
+	Hashed bool
+
+	// ISO8601 is true if ISO 8601 repeating intervals are accepted. See
+	// ParseISO8601.
+	ISO8601 bool
+}
+
+// FieldCapabilities describes a single field accepted by a Parser.
+type FieldCapabilities struct {
+	// Name is the name of the field, e.g. "day of week".
+	Name string
+	// Min and Max are the bounds of the numeric values of the field.
+	Min, Max uint
+	// Names maps the accepted names of values (e.g. "jan") to their numeric
+	// values. It is nil if the field has no names.
+	Names map[string]uint
+	// Optional is true if the field may be omitted.
+	Optional bool
+}
+
+// fieldBounds holds the bounds of the fields in places, in order.
+var fieldBounds = []bounds{seconds, minutes, hours, dom, months, dow}
+
+// descriptors lists the descriptors accepted by parseDescriptor.
+var descriptors = []string{
+	"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly", "@every",
+}
+
+// Capabilities returns a description of the specs accepted by the parser.
+func (p Parser) Capabilities() Capabilities {
+	options := p.options
+	if options&DowOptional > 0 {
+		options |= Dow
+	}
+	if options&SecondOptional > 0 {
+		options |= Second
+	}
+	c := Capabilities{
+		Options:   p.options,
+		Seconds:   options&Second > 0,
+		TimeZones: true,
+		Last:      options&Dom > 0,
+		Hashed:    p.options&Hashed > 0 && p.options&Quartz == 0,
+		ISO8601:   p.options&ISO8601 > 0,
+	}
+	if p.options&Descriptor > 0 {
+		c.Descriptors = append([]string(nil), descriptors...)
+	}
+	if p.options&Quartz > 0 {
+		return quartzCapabilities(c)
+	}
+	for i, place := range places {
+		if options&place == 0 {
+			continue
+		}
+		b := fieldBounds[i]
+		f := FieldCapabilities{
+			Name:     fieldNames[i],
+			Min:      b.min,
+			Max:      b.max,
+			Optional: place == Second && p.options&SecondOptional > 0 || place == Dow && p.options&DowOptional > 0,
+		}
+		f.Names = copyNames(b.names)
+		c.Fields = append(c.Fields, f)
+	}
+	return c
+}
+
+// copyNames returns a copy of the names of a field, or nil if it has none.
+func copyNames(names map[string]uint) map[string]uint {
+	if names == nil {
+		return nil
+	}
+	copied := make(map[string]uint, len(names))
+	for name, value := range names {
+		copied[name] = value
+	}
+	return copied
+}
+
 // normalizeFields takes a subset set of the time fields and returns the full set
 // with defaults (zeroes) populated for unset fields.
 //
@@ -246,6 +594,105 @@ func getField(field string, r bounds) (uint64, error) {
 	return bits, nil
 }
 
+// getDomField returns the bits indicated by the given day of month field.
+func getDomField(field string) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		bit, err := getDomRange(expr)
+		if err != nil {
+			return 0, err
+		}
+		bits |= bit
+	}
+	return bits, nil
+}
+
+// getDomRange returns the bits indicated by an expression of the day of month
+// field, which is either a range as accepted by getRange, or "L" or "L-n" for
+// the last day of the month or n days before it (see lastDomShift).
+func getDomRange(expr string) (uint64, error) {
+	if !strings.HasPrefix(expr, "L") {
+		return getRange(expr, dom)
+	}
+	var offset uint
+	if expr != "L" {
+		if !strings.HasPrefix(expr, "L-") {
+			return 0, fmt.Errorf("expected L or L-n: %s", expr)
+		}
+		var err error
+		if offset, err = mustParseInt(expr[2:]); err != nil {
+			return 0, err
+		}
+		if offset > lastDom.max {
+			return 0, fmt.Errorf("offset from the last day (%d) above maximum (%d): %s", offset, lastDom.max, expr)
+		}
+	}
+	return 1 << (lastDomShift + offset), nil
+}
+
+// getDowField returns the bits indicated by the given day of week field.
+func getDowField(field string) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		bit, err := getDowRange(expr)
+		if err != nil {
+			return 0, err
+		}
+		bits |= bit
+	}
+	return bits, nil
+}
+
+// getDowRange returns the bits indicated by an expression of the day of week
+// field. As in Vixie cron, 7 is Sunday too, and a range may cross Sunday, as
+// in "FRI-MON" or "5-1". Other expressions are handled by getRange.
+func getDowRange(expr string) (uint64, error) {
+	var (
+		rangeAndStep = strings.Split(expr, "/")
+		lowAndHigh   = strings.Split(rangeAndStep[0], "-")
+	)
+	if len(lowAndHigh) != 2 || len(rangeAndStep) > 2 {
+		if lowAndHigh[0] == "7" {
+			expr = "0" + expr[1:]
+		}
+		return getRange(expr, dow)
+	}
+	start, err := parseIntOrName(lowAndHigh[0], dow.names)
+	if err != nil {
+		return 0, err
+	}
+	end, err := parseIntOrName(lowAndHigh[1], dow.names)
+	if err != nil {
+		return 0, err
+	}
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		if step, err = mustParseInt(rangeAndStep[1]); err != nil {
+			return 0, err
+		}
+	}
+	switch {
+	case start > 7:
+		return 0, fmt.Errorf("beginning of range (%d) above maximum (7): %s", start, expr)
+	case end > 7:
+		return 0, fmt.Errorf("end of range (%d) above maximum (7): %s", end, expr)
+	case step == 0:
+		return 0, fmt.Errorf("step of range should be a positive number: %s", expr)
+	}
+	if start == 7 {
+		start = 0
+	}
+	if end < start {
+		// The range crosses Sunday.
+		end += 7
+	}
+	var bits uint64
+	for day := start; day <= end; day += step {
+		bits |= 1 << (day % 7)
+	}
+	return bits, nil
+}
+
 // getRange returns the bits indicated by the given expression:
 //   number | number "-" number [ "/" number ]
 // or error parsing range.
@@ -421,13 +868,17 @@ func parseDescriptor(descriptor string, loc *time.Location) (Schedule, error) {
 
 	}
 
+	if strings.Contains(descriptor, " between ") {
+		return parseWindow(descriptor, loc)
+	}
+
 	const every = "@every "
 	if strings.HasPrefix(descriptor, every) {
-		duration, err := time.ParseDuration(descriptor[len(every):])
+		schedule, err := parseEvery(descriptor[len(every):])
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
 		}
-		return Every(duration), nil
+		return schedule, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized descriptor: %s", descriptor)
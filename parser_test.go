@@ -57,6 +57,62 @@ func TestRange(t *testing.T) {
 	}
 }
 
+func TestDowRange(t *testing.T) {
+	sun, mon, tue, wed, thu, fri, sat := uint64(1<<0), uint64(1<<1), uint64(1<<2),
+		uint64(1<<3), uint64(1<<4), uint64(1<<5), uint64(1<<6)
+	ranges := []struct {
+		expr     string
+		expected uint64
+		err      string
+	}{
+		{"7", sun, ""},
+		{"0", sun, ""},
+		{"1-7", mon | tue | wed | thu | fri | sat | sun, ""},
+		{"5-7", fri | sat | sun, ""},
+		{"5-1", fri | sat | sun | mon, ""},
+		{"FRI-MON", fri | sat | sun | mon, ""},
+		{"sat-sun", sat | sun, ""},
+		{"7-1", sun | mon, ""},
+		{"4-2/2", thu | sat | mon, ""},
+		{"1-5", mon | tue | wed | thu | fri, ""},
+		{"mon", mon, ""},
+
+		{"8", 0, "above maximum"},
+		{"1-8", 0, "above maximum"},
+		{"9-1", 0, "above maximum"},
+		{"5-1/0", 0, "should be a positive number"},
+		{"fri-x", 0, "failed to parse int from"},
+	}
+	for _, c := range ranges {
+		actual, err := getDowRange(c.expr)
+		if len(c.err) != 0 && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s => expected %v, got %v", c.expr, c.err, err)
+		}
+		if len(c.err) == 0 && err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+		}
+		if actual != c.expected {
+			t.Errorf("%s => expected %b, got %b", c.expr, c.expected, actual)
+		}
+	}
+
+	// Wrapping ranges run on the days they cover.
+	sched, err := ParseStandard("0 9 * * FRI-MON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := getTime("Mon Jul 9 10:00 2012")
+	for _, expected := range []string{"Fri Jul 13 09:00 2012", "Sat Jul 14 09:00 2012",
+		"Sun Jul 15 09:00 2012", "Mon Jul 16 09:00 2012", "Fri Jul 20 09:00 2012"} {
+		if next = sched.Next(next); !next.Equal(getTime(expected)) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+	}
+	if issues := ValidateSpec("0 9 * * 7,5-1", Minute|Hour|Dom|Month|Dow); len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+}
+
 func TestField(t *testing.T) {
 	fields := []struct {
 		expr     string
@@ -77,6 +133,62 @@ func TestField(t *testing.T) {
 	}
 }
 
+func TestNamedField(t *testing.T) {
+	bits := func(values ...uint) uint64 {
+		var b uint64
+		for _, v := range values {
+			b |= 1 << v
+		}
+		return b
+	}
+	fields := []struct {
+		expr     string
+		r        bounds
+		expected uint64
+	}{
+		{"JAN-MAR,SEP-NOV", months, bits(1, 2, 3, 9, 10, 11)},
+		{"jan-mar,Sep-nOV", months, bits(1, 2, 3, 9, 10, 11)},
+		{"Jan-Dec/4", months, bits(1, 5, 9)},
+		{"jan/3", months, bits(1, 4, 7, 10)},
+		{"*/3,DEC", months, bits(1, 4, 7, 10, 12)},
+		{"FEB-4,oct", months, bits(2, 3, 4, 10)},
+		{"MON-FRI/2", dow, bits(1, 3, 5)},
+		{"mon-fri/2,SUN", dow, bits(0, 1, 3, 5)},
+		{"Tue/2", dow, bits(2, 4, 6)},
+	}
+	for _, c := range fields {
+		actual, err := getField(c.expr, c.r)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+		}
+		if actual != c.expected {
+			t.Errorf("%s => expected %b, got %b", c.expr, c.expected, actual)
+		}
+	}
+
+	// Named ranges with steps are accepted in full specs too, and by Quartz.
+	for _, test := range []struct {
+		parser Parser
+		spec   string
+	}{
+		{standardParser, "0 0 * JAN-MAR,SEP-NOV MON-FRI/2"},
+		{NewParser(Quartz), "0 0 0 ? jan-mar,sep-nov mon-fri/2"},
+	} {
+		sched, err := test.parser.Parse(test.spec)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", test.spec, err)
+			continue
+		}
+		next := sched.Next(getTime("Mon Mar 26 12:00 2012"))
+		if expected := getTime("Wed Mar 28 00:00 2012"); !next.Equal(expected) {
+			t.Errorf("%s => expected %v, got %v", test.spec, expected, next)
+		}
+		if next = sched.Next(getTime("Fri Mar 30 12:00 2012")); !next.Equal(getTime("Mon Sep 3 00:00 2012")) {
+			t.Errorf("%s => expected to skip to September, got %v", test.spec, next)
+		}
+	}
+}
+
 func TestAll(t *testing.T) {
 	allBits := []struct {
 		r        bounds
@@ -124,6 +236,8 @@ func TestParseScheduleErrors(t *testing.T) {
 		{"@every Xm", "failed to parse duration"},
 		{"@unrecognized", "unrecognized descriptor"},
 		{"* * * *", "expected 5 to 6 fields"},
+		{"0 0 0 L-31 * *", "offset from the last day (31) above maximum (30)"},
+		{"0 0 0 LW * *", "expected L or L-n"},
 		{"", "empty spec string"},
 	}
 	for _, c := range tests {
@@ -381,3 +495,130 @@ func annual(loc *time.Location) *SpecSchedule {
 		Location: loc,
 	}
 }
+
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		options  ParseOption
+		expected []string
+	}{
+		{"* * * * *", Minute | Hour | Dom | Month | Dow, nil},
+		{"@daily", Minute | Hour | Dom | Month | Dow | Descriptor, nil},
+		{"", Minute | Hour | Dom | Month | Dow, []string{"empty spec string"}},
+		{"60 25 * 13 *", Minute | Hour | Dom | Month | Dow, []string{
+			"minute field: end of range (60) above maximum (59): 60",
+			"hour field: end of range (25) above maximum (23): 25",
+			"month field: end of range (13) above maximum (12): 13",
+		}},
+		{"* * 0 * mon-xyz", Minute | Hour | Dom | Month | Dow, []string{
+			"day of month field: beginning of range (0) below minimum (1): 0",
+			"day of week field: failed to parse int from xyz",
+		}},
+		{"CRON_TZ=Bogus/Zone 99 * * * *", Minute | Hour | Dom | Month | Dow, []string{
+			"provided bad location Bogus/Zone",
+			"minute field: end of range (99) above maximum (59): 99",
+		}},
+		{"* * *", Minute | Hour | Dom | Month | Dow, []string{"expected exactly 5 fields, found 3"}},
+		{"@daily", Minute | Hour | Dom | Month | Dow, []string{"parser does not accept descriptors"}},
+		{"@every bogus", Minute | Hour | Dom | Month | Dow | Descriptor, []string{"failed to parse duration"}},
+	}
+
+	for _, test := range tests {
+		errs := ValidateSpec(test.spec, test.options)
+		if len(errs) != len(test.expected) {
+			t.Errorf("%q: expected %d errors, got %v", test.spec, len(test.expected), errs)
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), test.expected[i]) {
+				t.Errorf("%q: expected error %d to contain %q, got %q", test.spec, i, test.expected[i], err)
+			}
+		}
+	}
+}
+
+func TestValidateSpecIssues(t *testing.T) {
+	tests := []struct {
+		spec     string
+		options  ParseOption
+		expected []SpecIssue
+	}{
+		{"* * 0 * 1,mon-xyz", Minute | Hour | Dom | Month | Dow, []SpecIssue{
+			{Field: 2, Name: "day of month", Token: "0"},
+			{Field: 4, Name: "day of week", Token: "mon-xyz"},
+		}},
+		{"0 60 * * *", SecondOptional | Minute | Hour | Dom | Month | Dow, []SpecIssue{
+			{Field: 1, Name: "hour", Token: "60"},
+		}},
+		{"5 0 60 * * *", SecondOptional | Minute | Hour | Dom | Month | Dow, []SpecIssue{
+			{Field: 2, Name: "hour", Token: "60"},
+		}},
+		{"* */0", Dom | Month | DowOptional, []SpecIssue{
+			{Field: 1, Name: "month", Token: "*/0"},
+		}},
+		{"CRON_TZ=Bogus/Zone * * * * *", Minute | Hour | Dom | Month | Dow, []SpecIssue{
+			{Field: -1, Token: "Bogus/Zone"},
+		}},
+		{"* * *", Minute | Hour | Dom | Month | Dow, []SpecIssue{
+			{Field: -1, Token: "* * *"},
+		}},
+	}
+
+	for _, test := range tests {
+		issues := ValidateSpec(test.spec, test.options)
+		if len(issues) != len(test.expected) {
+			t.Errorf("%q: expected %d issues, got %v", test.spec, len(test.expected), issues)
+			continue
+		}
+		for i, issue := range issues {
+			expected := test.expected[i]
+			if issue.Field != expected.Field || issue.Name != expected.Name || issue.Token != expected.Token {
+				t.Errorf("%q: expected issue %d in field %d (%q) at %q, got %+v",
+					test.spec, i, expected.Field, expected.Name, expected.Token, issue)
+			}
+			if issue.Message == "" || issue.Suggestion == "" {
+				t.Errorf("%q: expected a message and a suggestion, got %+v", test.spec, issue)
+			}
+		}
+	}
+
+	issues := ValidateSpec("* * * * xyz", Minute|Hour|Dom|Month|Dow)
+	if len(issues) != 1 || !strings.Contains(issues[0].Suggestion, "SUN to SAT") {
+		t.Errorf("expected a suggestion listing day names, got %+v", issues)
+	}
+}
+
+func TestParserCapabilities(t *testing.T) {
+	caps := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor).Capabilities()
+	if !caps.Seconds || !caps.TimeZones || caps.Hash || !caps.Last || caps.Weekday {
+		t.Errorf("unexpected capabilities: %+v", caps)
+	}
+	if len(caps.Descriptors) == 0 || caps.Descriptors[len(caps.Descriptors)-1] != "@every" {
+		t.Errorf("expected descriptors ending in @every, got %v", caps.Descriptors)
+	}
+	var names []string
+	for _, f := range caps.Fields {
+		names = append(names, f.Name)
+	}
+	expected := []string{"second", "minute", "hour", "day of month", "month", "day of week"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected fields %v, got %v", expected, names)
+	}
+	if f := caps.Fields[0]; !f.Optional || f.Min != 0 || f.Max != 59 {
+		t.Errorf("unexpected seconds field: %+v", f)
+	}
+	if f := caps.Fields[4]; f.Optional || f.Min != 1 || f.Max != 12 || f.Names["dec"] != 12 {
+		t.Errorf("unexpected month field: %+v", f)
+	}
+
+	// The returned names may be modified without affecting the parser.
+	caps.Fields[4].Names["dec"] = 0
+	if months.names["dec"] != 12 {
+		t.Error("expected Capabilities to return a copy of the names")
+	}
+
+	caps = NewParser(Dom | Month | DowOptional).Capabilities()
+	if caps.Seconds || len(caps.Descriptors) != 0 || len(caps.Fields) != 3 || !caps.Fields[2].Optional {
+		t.Errorf("unexpected capabilities: %+v", caps)
+	}
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"context"
+	"errors"
+)
+
+// Cipher encrypts the payloads of entries (see WithPayload), so that secrets
+// among job parameters are kept encrypted in memory and in persistent stores,
+// and decrypted only when a run is dispatched. Implementations may hold
+// several keys, to support key rotation: ciphertexts are tagged with the ID
+// of the key that encrypted them.
+type Cipher interface {
+	// KeyID returns the ID of the key that Encrypt currently uses.
+	KeyID() string
+	// Encrypt encrypts plaintext with the current key.
+	Encrypt(plaintext []byte) ([]byte, error)
+	// Decrypt decrypts ciphertext that was encrypted with the given key.
+	Decrypt(keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// ErrNoCipher is returned when an encrypted payload is found but the cron has
+// no Cipher to decrypt it with.
+var ErrNoCipher = errors.New("cron: encrypted payload but no cipher configured")
+
+// WithPayload attaches parameters to the entry, which its job reads with
+// PayloadFromContext. If the cron has a Cipher (see WithCipher), the payload
+// is encrypted when the entry is added.
+func WithPayload(payload []byte) EntryOption {
+	return func(e *Entry) {
+		e.Payload = payload
+	}
+}
+
+// payloadKey is the context key of the decrypted payload of a run.
+type payloadKey struct{}
+
+// PayloadFromContext returns the decrypted payload of the entry whose run ctx
+// belongs to, or nil if it has none.
+func PayloadFromContext(ctx context.Context) []byte {
+	payload, _ := ctx.Value(payloadKey{}).([]byte)
+	return payload
+}
+
+// encryptPayload encrypts the plaintext payload of a new entry, if the cron
+// has a Cipher and the payload is not already encrypted, e.g. by ImportJSON.
+func (c *Cron) encryptPayload(e *Entry) error {
+	if c.cipher == nil || len(e.Payload) == 0 || e.PayloadKey != "" {
+		return nil
+	}
+	ciphertext, err := c.cipher.Encrypt(e.Payload)
+	if err != nil {
+		return err
+	}
+	e.Payload, e.PayloadKey = ciphertext, c.cipher.KeyID()
+	return nil
+}
+
+// decryptPayload returns the plaintext payload of the entry.
+func (c *Cron) decryptPayload(e *Entry) ([]byte, error) {
+	if e.PayloadKey == "" {
+		return e.Payload, nil
+	}
+	if c.cipher == nil {
+		return nil, ErrNoCipher
+	}
+	return c.cipher.Decrypt(e.PayloadKey, e.Payload)
+}
+
+// rotatePayload re-encrypts the payload of the entry with the current key. It
+// returns false if the payload is already encrypted with it.
+func (c *Cron) rotatePayload(e *Entry) (bool, error) {
+	if c.cipher == nil {
+		return false, ErrNoCipher
+	}
+	if e.PayloadKey == "" || e.PayloadKey == c.cipher.KeyID() {
+		return false, nil
+	}
+	plaintext, err := c.decryptPayload(e)
+	if err != nil {
+		return false, err
+	}
+	ciphertext, err := c.cipher.Encrypt(plaintext)
+	if err != nil {
+		return false, err
+	}
+	e.Payload, e.PayloadKey = ciphertext, c.cipher.KeyID()
+	return true, nil
+}
+
+// RotatePayloadKeys re-encrypts with the Cipher's current key the payloads of
+// all entries encrypted with an older key, and saves them to the store, so
+// that old keys may be retired. It returns the IDs of the entries that were
+// re-encrypted, and the first error encountered, if any.
+func (c *Cron) RotatePayloadKeys() ([]EntryID, error) {
+	var ids []EntryID
+	for _, e := range c.allEntries() {
+		ids = append(ids, e.ID)
+	}
+	errs := make(map[EntryID]error)
+	rotated := c.batchErrs(opRotate, ids, errs)
+	for _, id := range ids {
+		if err := errs[id]; err != nil {
+			return rotated, err
+		}
+	}
+	return rotated, nil
+}
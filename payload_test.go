@@ -0,0 +1,127 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// xorCipher is a toy Cipher with single-byte keys.
+type xorCipher struct {
+	current string
+	keys    map[string]byte
+}
+
+func (c *xorCipher) KeyID() string { return c.current }
+
+func (c *xorCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	return c.xor(c.current, plaintext)
+}
+
+func (c *xorCipher) Decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	return c.xor(keyID, ciphertext)
+}
+
+func (c *xorCipher) xor(keyID string, data []byte) ([]byte, error) {
+	key, ok := c.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = b ^ key
+	}
+	return out, nil
+}
+
+func TestEncryptedPayload(t *testing.T) {
+	var (
+		secret   = []byte("password=hunter2")
+		cipher   = &xorCipher{current: "k1", keys: map[string]byte{"k1": 0x2a, "k2": 0x55}}
+		received []byte
+	)
+	cron := New(WithChain(), WithCipher(cipher))
+	id, err := cron.AddFuncCtx("@daily", func(ctx context.Context) {
+		received = PayloadFromContext(ctx)
+	}, WithPayload(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := cron.Entry(id)
+	if e.PayloadKey != "k1" || bytes.Equal(e.Payload, secret) {
+		t.Fatalf("expected the payload to be encrypted with k1, got %q with key %q", e.Payload, e.PayloadKey)
+	}
+	run := func() {
+		received = nil
+		cron.startJob(cron.store.Get(id), time.Now())
+		cron.jobWaiter.Wait()
+	}
+	run()
+	if !bytes.Equal(received, secret) {
+		t.Errorf("expected the job to receive the decrypted payload, got %q", received)
+	}
+
+	// Rotate to k2; the payload still decrypts.
+	cipher.current = "k2"
+	rotated, err := cron.RotatePayloadKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 1 || rotated[0] != id || cron.Entry(id).PayloadKey != "k2" {
+		t.Fatalf("expected entry %d to be rotated to k2, got %v", id, rotated)
+	}
+	delete(cipher.keys, "k1")
+	run()
+	if !bytes.Equal(received, secret) {
+		t.Errorf("expected the job to receive the decrypted payload after rotation, got %q", received)
+	}
+	if rotated, _ := cron.RotatePayloadKeys(); len(rotated) != 0 {
+		t.Errorf("expected nothing left to rotate, got %v", rotated)
+	}
+}
+
+func TestPayloadWithoutCipher(t *testing.T) {
+	var received []byte
+	cron := New(WithChain(), WithLogger(DiscardLogger))
+	id, _ := cron.AddFuncCtx("@daily", func(ctx context.Context) {
+		received = PayloadFromContext(ctx)
+	}, WithPayload([]byte("plain")))
+	cron.startJob(cron.store.Get(id), time.Now())
+	cron.jobWaiter.Wait()
+	if string(received) != "plain" {
+		t.Errorf("expected the plain payload, got %q", received)
+	}
+
+	// An encrypted payload cannot be run without a cipher.
+	e := cron.store.Get(id)
+	e.PayloadKey = "k1"
+	received = nil
+	cron.startJob(e, time.Now())
+	cron.jobWaiter.Wait()
+	if received != nil || cron.StatsSnapshot()[id].Skipped != 1 {
+		t.Errorf("expected the run to be skipped, got payload %q", received)
+	}
+	if _, err := cron.RotatePayloadKeys(); err != ErrNoCipher {
+		t.Errorf("expected ErrNoCipher, got %v", err)
+	}
+}
+
+func TestRedisStorePayload(t *testing.T) {
+	client := newFakeRedis()
+	jobs := map[string]Job{"report": FuncJob(func() {})}
+	store, _ := NewRedisStore(client, "cron", nil, jobs)
+	store.Add(&Entry{ID: 1, Spec: "@daily", JobName: "report", Job: jobs["report"],
+		Payload: []byte{1, 2, 3}, PayloadKey: "k1"})
+
+	other, err := NewRedisStore(client, "cron", nil, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := other.Get(1)
+	if !bytes.Equal(e.Payload, []byte{1, 2, 3}) || e.PayloadKey != "k1" {
+		t.Errorf("expected the payload to be saved, got %v with key %q", e.Payload, e.PayloadKey)
+	}
+}
@@ -0,0 +1,154 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// PoolStats describes the activity of a worker pool configured with
+// WithWorkerPool.
+type PoolStats struct {
+	// Size is the maximum number of concurrently running jobs.
+	Size int
+	// Running is the number of jobs currently running.
+	Running int
+	// Queued is the number of runs waiting for a free worker.
+	Queued int
+	// Dispatched is the number of runs that have been handed to a worker.
+	Dispatched uint64
+	// TotalWait is the cumulative time dispatched runs spent queued.
+	TotalWait time.Duration
+	// MaxWait is the longest time a dispatched run spent queued.
+	MaxWait time.Duration
+	// Dropped is the number of runs that were dropped instead of dispatched
+	// because they exceeded their entry's MaxQueueDelay.
+	Dropped uint64
+}
+
+// AverageWait returns the mean time dispatched runs spent queued.
+func (s PoolStats) AverageWait() time.Duration {
+	if s.Dispatched == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Dispatched)
+}
+
+// queuedRun is a run waiting in a workerPool.
+type queuedRun struct {
+	entry    EntryID
+	priority int
+	enqueued time.Time
+	run      func()
+
+	// If maxDelay is positive, the run is dropped by calling drop if it could
+	// not be dispatched within maxDelay of its scheduled time.
+	scheduled time.Time
+	maxDelay  time.Duration
+	drop      func(late time.Duration)
+}
+
+// workerPool runs submitted jobs on at most size goroutines, starting runs of
+// higher priority entries first. Workers are started on demand and exit when
+// the queue is empty.
+type workerPool struct {
+	size  int
+	aging time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	queue   []*queuedRun
+	workers int
+	stats   PoolStats
+}
+
+func newWorkerPool(size int, aging time.Duration, now func() time.Time) *workerPool {
+	return &workerPool{size: size, aging: aging, now: now}
+}
+
+// submit queues the given run.
+func (p *workerPool) submit(r *queuedRun) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r.enqueued = p.now()
+	p.queue = append(p.queue, r)
+	if p.workers < p.size {
+		p.workers++
+		go p.work()
+	}
+}
+
+// work runs queued runs until the queue is empty.
+func (p *workerPool) work() {
+	for {
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			p.workers--
+			p.mu.Unlock()
+			return
+		}
+		now := p.now()
+		r := p.pop(now)
+		if late := now.Sub(r.scheduled); r.maxDelay > 0 && late > r.maxDelay {
+			p.stats.Dropped++
+			p.mu.Unlock()
+			r.drop(late)
+			continue
+		}
+		wait := now.Sub(r.enqueued)
+		p.stats.Dispatched++
+		p.stats.TotalWait += wait
+		if wait > p.stats.MaxWait {
+			p.stats.MaxWait = wait
+		}
+		p.stats.Running++
+		p.mu.Unlock()
+
+		r.run()
+
+		p.mu.Lock()
+		p.stats.Running--
+		p.mu.Unlock()
+	}
+}
+
+// pop removes and returns the queued run with the highest effective priority,
+// preferring the one queued first on ties. It must be called with p.mu held.
+func (p *workerPool) pop(now time.Time) *queuedRun {
+	best := 0
+	for i := 1; i < len(p.queue); i++ {
+		if p.effectivePriority(p.queue[i], now) > p.effectivePriority(p.queue[best], now) {
+			best = i
+		}
+	}
+	r := p.queue[best]
+	p.queue = append(p.queue[:best], p.queue[best+1:]...)
+	return r
+}
+
+// effectivePriority returns the priority of the queued run, raised by one for
+// every aging interval it has spent waiting.
+func (p *workerPool) effectivePriority(r *queuedRun, now time.Time) int {
+	if p.aging <= 0 {
+		return r.priority
+	}
+	return r.priority + int(now.Sub(r.enqueued)/p.aging)
+}
+
+// snapshot returns the current statistics of the pool.
+func (p *workerPool) snapshot() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.Size = p.size
+	stats.Queued = len(p.queue)
+	return stats
+}
+
+// PoolStats returns statistics about the worker pool, or the zero PoolStats
+// if this cron was not configured with WithWorkerPool.
+func (c *Cron) PoolStats() PoolStats {
+	if c.pool == nil {
+		return PoolStats{}
+	}
+	return c.pool.snapshot()
+}
@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolPriority(t *testing.T) {
+	pool := newWorkerPool(1, 0, time.Now)
+
+	var (
+		mu    sync.Mutex
+		order []EntryID
+		wg    sync.WaitGroup
+	)
+	record := func(id EntryID) func() {
+		return func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}
+	}
+
+	// Occupy the only worker while the other runs are queued.
+	started, release := make(chan struct{}), make(chan struct{})
+	wg.Add(4)
+	pool.submit(&queuedRun{entry: 1, run: func() {
+		defer wg.Done()
+		close(started)
+		<-release
+	}})
+	<-started
+	pool.submit(&queuedRun{entry: 2, priority: 0, run: record(2)})
+	pool.submit(&queuedRun{entry: 3, priority: 5, run: record(3)})
+	pool.submit(&queuedRun{entry: 4, priority: 5, run: record(4)})
+	if stats := pool.snapshot(); stats.Queued != 3 || stats.Running != 1 {
+		t.Errorf("expected 3 queued and 1 running, got %+v", stats)
+	}
+	close(release)
+	wg.Wait()
+
+	expected := []EntryID{3, 4, 2}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected runs in order %v, got %v", expected, order)
+		}
+	}
+	if stats := pool.snapshot(); stats.Dispatched != 4 || stats.Queued != 0 || stats.MaxWait <= 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWorkerPoolAging(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := newWorkerPool(1, time.Minute, func() time.Time { return now })
+	pool.queue = []*queuedRun{
+		{entry: 1, priority: 0, enqueued: now.Add(-10 * time.Minute)},
+		{entry: 2, priority: 5, enqueued: now.Add(-time.Minute)},
+	}
+
+	// The low priority run has aged past the high priority one.
+	if r := pool.pop(now); r.entry != 1 {
+		t.Errorf("expected the aged run to be dispatched first, got entry %d", r.entry)
+	}
+
+	// Without aging, priority wins.
+	pool.aging = 0
+	pool.queue = []*queuedRun{
+		{entry: 1, priority: 0, enqueued: now.Add(-10 * time.Minute)},
+		{entry: 2, priority: 5, enqueued: now.Add(-time.Minute)},
+	}
+	if r := pool.pop(now); r.entry != 2 {
+		t.Errorf("expected the high priority run to be dispatched first, got entry %d", r.entry)
+	}
+}
+
+func TestWithWorkerPool(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	cron := New(WithParser(secondParser), WithChain(), WithWorkerPool(1))
+	cron.AddFunc("* * * * * ?", func() { wg.Done() }, WithPriority(1))
+	cron.AddFunc("* * * * * ?", func() { wg.Done() })
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(OneSecond):
+		t.Fatal("expected both jobs to run on the pool")
+	case <-wait(&wg):
+	}
+	if stats := cron.PoolStats(); stats.Size != 1 || stats.Dispatched < 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWorkerPoolMaxQueueDelay(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := newWorkerPool(1, 0, func() time.Time { return now })
+
+	var ran, dropped []EntryID
+	var wg sync.WaitGroup
+	wg.Add(2)
+	late := now.Add(-time.Minute)
+	pool.queue = []*queuedRun{
+		{entry: 1, scheduled: late, maxDelay: 30 * time.Second, enqueued: late,
+			run:  func() { ran = append(ran, 1); wg.Done() },
+			drop: func(time.Duration) { dropped = append(dropped, 1); wg.Done() }},
+		{entry: 2, scheduled: late, maxDelay: 2 * time.Minute, enqueued: late,
+			run:  func() { ran = append(ran, 2); wg.Done() },
+			drop: func(time.Duration) { dropped = append(dropped, 2); wg.Done() }},
+	}
+	pool.workers = 1
+	go pool.work()
+	wg.Wait()
+
+	if len(ran) != 1 || ran[0] != 2 || len(dropped) != 1 || dropped[0] != 1 {
+		t.Errorf("expected entry 1 dropped and entry 2 run, got ran=%v dropped=%v", ran, dropped)
+	}
+	if stats := pool.snapshot(); stats.Dropped != 1 || stats.Dispatched != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
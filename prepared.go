@@ -0,0 +1,53 @@
+package cron
+
+import "context"
+
+// PreparedJob is a Job whose runs happen in two phases. When a job submitted to
+// Cron implements it, Prepare is called by the scheduler itself when the run
+// is due, before the run is dispatched to its goroutine, group or worker pool.
+// If Prepare returns an error, the run is skipped; otherwise the returned
+// execute func is run in place of Run, inside the entry's wrappers.
+//
+// Prepare suits cheap precondition checks and acquiring leases before heavy
+// work starts. It blocks the scheduler, so it must return quickly.
+type PreparedJob interface {
+	Job
+
+	// Prepare readies a run, or vetoes it by returning an error. The context
+	// is that of the run. If execute returns an error, the run counts as
+	// failed.
+	Prepare(ctx context.Context) (execute func(ctx context.Context) error, err error)
+}
+
+// PrepareFunc is a wrapper that turns a func with the signature of Prepare
+// into a PreparedJob.
+type PrepareFunc func(ctx context.Context) (func(ctx context.Context) error, error)
+
+// Prepare calls the function.
+func (f PrepareFunc) Prepare(ctx context.Context) (func(ctx context.Context) error, error) {
+	return f(ctx)
+}
+
+// Run prepares and executes a run with context.Background(), discarding any
+// error.
+func (f PrepareFunc) Run() {
+	ctx := context.Background()
+	if execute, err := f(ctx); err == nil {
+		execute(ctx)
+	}
+}
+
+// executeKey is the context key of the execute func of a prepared run.
+type executeKey struct{}
+
+// withExecute returns a copy of ctx carrying the execute func of a prepared
+// run, for observe to call in place of the job.
+func withExecute(ctx context.Context, execute func(context.Context) error) context.Context {
+	return context.WithValue(ctx, executeKey{}, execute)
+}
+
+// executeFrom returns the execute func carried by ctx, if any.
+func executeFrom(ctx context.Context) (func(context.Context) error, bool) {
+	execute, ok := ctx.Value(executeKey{}).(func(context.Context) error)
+	return execute, ok
+}
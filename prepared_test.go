@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPreparedJob(t *testing.T) {
+	errNotReady := errors.New("not ready")
+	tests := []struct {
+		name     string
+		prepare  error
+		execute  error
+		executed bool
+		runs     uint64
+		failures uint64
+		skipped  uint64
+	}{
+		{"executed", nil, nil, true, 1, 0, 0},
+		{"execute failed", nil, errors.New("boom"), true, 1, 1, 0},
+		{"vetoed", errNotReady, nil, false, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				executed bool
+				wrapped  bool
+			)
+			job := PrepareFunc(func(ctx context.Context) (func(context.Context) error, error) {
+				if tt.prepare != nil {
+					return nil, tt.prepare
+				}
+				return func(ctx context.Context) error {
+					executed = true
+					return tt.execute
+				}, nil
+			})
+			spy := func(j Job) Job {
+				return ContextFuncJob(func(ctx context.Context) {
+					wrapped = true
+					RunJob(ctx, j)
+				})
+			}
+			cron := New(WithChain(), WithLogger(DiscardLogger))
+			id, _ := cron.AddJob("@daily", job, WithWrappers(spy))
+
+			cron.startJob(cron.store.Get(id), time.Now())
+			cron.jobWaiter.Wait()
+
+			if executed != tt.executed || wrapped != tt.executed {
+				t.Errorf("expected executed and wrapped %v, got %v and %v", tt.executed, executed, wrapped)
+			}
+			stats := cron.StatsSnapshot()[id]
+			if stats.Runs != tt.runs || stats.Failures != tt.failures || stats.Skipped != tt.skipped {
+				t.Errorf("expected %d runs, %d failures and %d skipped, got %+v",
+					tt.runs, tt.failures, tt.skipped, stats)
+			}
+		})
+	}
+}
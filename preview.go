@@ -0,0 +1,29 @@
+package cron
+
+import "time"
+
+// NextN returns the next n activation times of the schedule after from, in
+// order, e.g. to show the upcoming runs of a spec before saving it. It returns
+// fewer if the schedule has no more activations.
+func NextN(schedule Schedule, from time.Time, n int) []time.Time {
+	var times []time.Time
+	for t := from; len(times) < n; {
+		if t = schedule.Next(t); t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// NextRuns returns the next n activation times of the given entry, starting
+// with its Next time, or nil if the entry does not exist or is not scheduled,
+// e.g. because it is paused. The times after the first follow from the
+// entry's current schedule, without the random delay of WithJitter.
+func (c *Cron) NextRuns(id EntryID, n int) []time.Time {
+	e := c.Entry(id)
+	if !e.Valid() || e.Next.IsZero() || n <= 0 {
+		return nil
+	}
+	return append([]time.Time{e.Next}, NextN(e.plainSchedule(), e.Next, n-1)...)
+}
@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNextN(t *testing.T) {
+	from := time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC)
+	schedule, _ := NewParser(Minute | Hour | Dom | Month | Dow).Parse("CRON_TZ=UTC 0 9 31 * *")
+	expected := []time.Time{
+		time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 9, 0, 0, 0, time.UTC),
+	}
+	if actual := NextN(schedule, from, 3); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if actual := NextN(schedule, from, 0); len(actual) != 0 {
+		t.Errorf("expected no times, got %v", actual)
+	}
+
+	// A schedule that runs out of activations yields fewer times.
+	once := onceSchedule{from.Add(time.Hour)}
+	if actual := NextN(once, from, 3); len(actual) != 1 {
+		t.Errorf("expected a single time, got %v", actual)
+	}
+}
+
+// onceSchedule activates once, at the given time.
+type onceSchedule struct{ at time.Time }
+
+func (s onceSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.at) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+func TestCronNextRuns(t *testing.T) {
+	cron := New(WithLogger(DiscardLogger))
+	id := cron.Schedule(Every(time.Minute), FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+
+	next := cron.Entry(id).Next
+	expected := []time.Time{next, next.Add(time.Minute), next.Add(2 * time.Minute)}
+	if actual := cron.NextRuns(id, 3); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if actual := cron.NextRuns(id+1, 3); actual != nil {
+		t.Errorf("expected no runs for an unknown entry, got %v", actual)
+	}
+
+	cron.PauseMany([]EntryID{id})
+	if actual := cron.NextRuns(id, 3); actual != nil {
+		t.Errorf("expected no runs for a paused entry, got %v", actual)
+	}
+}
@@ -0,0 +1,471 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The bounds of the Quartz fields that differ from the standard ones. Quartz
+// numbers the days of the week from 1 (SUN) to 7 (SAT), and supports years
+// from 1970 to 2099.
+var (
+	quartzDow = bounds{1, 7, map[string]uint{
+		"sun": 1,
+		"mon": 2,
+		"tue": 3,
+		"wed": 4,
+		"thu": 5,
+		"fri": 6,
+		"sat": 7,
+	}}
+	quartzYears = bounds{1970, 2099, nil}
+)
+
+// quartzFieldNames holds the names of the fields of a Quartz spec, in order.
+var quartzFieldNames = []string{
+	"second",
+	"minute",
+	"hour",
+	"day of month",
+	"month",
+	"day of week",
+	"year",
+}
+
+// quartzYearLimit is how many years ahead Next searches for an activation, so
+// that e.g. the fifth Sunday of February, which occurs every 28 years, is
+// found.
+const quartzYearLimit = 30
+
+// quartzSchedule is a schedule parsed from a Quartz cron expression. Days are
+// selected either by day of month or by day of week, never both.
+type quartzSchedule struct {
+	spec                        string
+	second, minute, hour, month uint64
+	location                    *time.Location
+	years                       []bool // indexed from quartzYears.min; nil for every year
+	byDow                       bool   // days are selected by day of week
+
+	// By day of month: a set of days, L-lastOffset, LW or nearestWeekday W.
+	dom            uint64
+	lastDay        bool
+	lastOffset     int
+	lastWeekday    bool
+	nearestWeekday int
+
+	// By day of week: a set of days, the last weekday of the month (nL), or
+	// the nthDow-th weekday of the month (n#k).
+	dow     uint64 // by time.Weekday
+	lastDow bool
+	nthDow  int
+	weekday time.Weekday
+}
+
+// quartzCapabilities completes the capabilities of a Quartz parser.
+func quartzCapabilities(c Capabilities) Capabilities {
+	c.Seconds, c.Hash, c.Last, c.Weekday = true, true, true, true
+	for i, b := range []bounds{seconds, minutes, hours, dom, months, quartzDow, quartzYears} {
+		c.Fields = append(c.Fields, FieldCapabilities{
+			Name:     quartzFieldNames[i],
+			Min:      b.min,
+			Max:      b.max,
+			Names:    copyNames(b.names),
+			Optional: i == 6,
+		})
+	}
+	return c
+}
+
+// parseQuartz parses the fields of a Quartz spec, with the time zone already
+// removed.
+func parseQuartz(spec string, fields []string, loc *time.Location) (Schedule, error) {
+	s, issues := quartzFields(fields)
+	if len(issues) > 0 {
+		return nil, issues[0]
+	}
+	s.spec = spec
+	s.location = loc
+	return s, nil
+}
+
+// quartzFields parses the fields of a Quartz spec, reporting every problem.
+func quartzFields(fields []string) (*quartzSchedule, []SpecIssue) {
+	if len(fields) < 6 || len(fields) > 7 {
+		return nil, []SpecIssue{{Field: -1, Token: strings.Join(fields, " "),
+			Message:    fmt.Sprintf("expected 6 to 7 fields, found %d: %s", len(fields), fields),
+			Suggestion: "use the fields " + strings.Join(quartzFieldNames[:6], ", ") + " and optional year"}}
+	}
+	var (
+		s      = &quartzSchedule{}
+		issues []SpecIssue
+	)
+	issue := func(i int, token string, err error, r bounds) {
+		issues = append(issues, SpecIssue{Field: i, Name: quartzFieldNames[i], Token: token,
+			Message: err.Error(), Suggestion: fieldSuggestion(r)})
+	}
+	for i, f := range []struct {
+		bits *uint64
+		r    bounds
+	}{{&s.second, seconds}, {&s.minute, minutes}, {&s.hour, hours}, {nil, dom}, {&s.month, months}} {
+		if f.bits == nil {
+			continue
+		}
+		bits, token, err := quartzBits(fields[i], f.r)
+		if err != nil {
+			issue(i, token, err, f.r)
+		}
+		*f.bits = bits
+	}
+
+	domField, dowField := fields[3], fields[5]
+	switch {
+	case domField == "?" && dowField == "?":
+		issues = append(issues, SpecIssue{Field: 5, Name: quartzFieldNames[5], Token: dowField,
+			Message:    "? may not be used in both day of month and day of week",
+			Suggestion: "use ? in only one of them"})
+	case domField != "?" && dowField != "?":
+		issues = append(issues, SpecIssue{Field: 5, Name: quartzFieldNames[5], Token: dowField,
+			Message:    "specifying both a day of month and a day of week is not supported",
+			Suggestion: "use ? in one of them"})
+	case dowField == "?":
+		if token, err := s.parseDom(domField); err != nil {
+			issue(3, token, err, dom)
+		}
+	default:
+		s.byDow = true
+		if token, err := s.parseDow(dowField); err != nil {
+			issue(5, token, err, quartzDow)
+		}
+	}
+
+	if len(fields) == 7 && fields[6] != "*" {
+		years, token, err := parseYears(fields[6])
+		if err != nil {
+			issue(6, token, err, quartzYears)
+		}
+		s.years = years
+	}
+	return s, issues
+}
+
+// quartzBits returns the bits of a field without special characters, or the
+// offending range expression and an error.
+func quartzBits(field string, r bounds) (uint64, string, error) {
+	var bits uint64
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		if strings.HasPrefix(expr, "?") {
+			return 0, expr, fmt.Errorf("? may only be used alone, in day of month or day of week: %s", expr)
+		}
+		bit, err := getRange(expr, r)
+		if err != nil {
+			return 0, expr, err
+		}
+		bits |= bit
+	}
+	return bits, field, nil
+}
+
+// parseDom parses the day of month field: L, L-n, LW, nW, or a list of days.
+func (s *quartzSchedule) parseDom(field string) (string, error) {
+	switch {
+	case field == "L":
+		s.lastDay = true
+	case field == "LW":
+		s.lastWeekday = true
+	case strings.HasPrefix(field, "L-"):
+		n, err := mustParseInt(field[2:])
+		if err != nil {
+			return field, err
+		}
+		if n > 30 {
+			return field, fmt.Errorf("offset from the last day must be at most 30: %s", field)
+		}
+		s.lastDay, s.lastOffset = true, int(n)
+	case strings.HasSuffix(field, "W"):
+		n, err := mustParseInt(field[:len(field)-1])
+		if err != nil {
+			return field, err
+		}
+		if n < dom.min || n > dom.max {
+			return field, fmt.Errorf("day (%d) out of range %d-%d: %s", n, dom.min, dom.max, field)
+		}
+		s.nearestWeekday = int(n)
+	case strings.ContainsAny(field, "LW"):
+		return field, fmt.Errorf("L and W may not be combined with other values: %s", field)
+	default:
+		bits, token, err := quartzBits(field, dom)
+		if err != nil {
+			return token, err
+		}
+		s.dom = bits
+	}
+	return field, nil
+}
+
+// parseDow parses the day of week field: L, nL, n#k, or a list of days.
+func (s *quartzSchedule) parseDow(field string) (string, error) {
+	switch {
+	case field == "L":
+		// As in Quartz, L alone is the last day of the week, Saturday.
+		s.dow = 1 << uint(time.Saturday)
+	case strings.HasSuffix(field, "L"):
+		d, err := parseIntOrName(field[:len(field)-1], quartzDow.names)
+		if err != nil {
+			return field, err
+		}
+		if d < quartzDow.min || d > quartzDow.max {
+			return field, fmt.Errorf("day of week (%d) out of range 1-7: %s", d, field)
+		}
+		s.lastDow, s.weekday = true, time.Weekday(d-1)
+	case strings.Contains(field, "#"):
+		parts := strings.Split(field, "#")
+		if len(parts) != 2 {
+			return field, fmt.Errorf("too many #: %s", field)
+		}
+		d, err := parseIntOrName(parts[0], quartzDow.names)
+		if err != nil {
+			return field, err
+		}
+		if d < quartzDow.min || d > quartzDow.max {
+			return field, fmt.Errorf("day of week (%d) out of range 1-7: %s", d, field)
+		}
+		n, err := mustParseInt(parts[1])
+		if err != nil {
+			return field, err
+		}
+		if n < 1 || n > 5 {
+			return field, fmt.Errorf("occurrence (%d) out of range 1-5: %s", n, field)
+		}
+		s.nthDow, s.weekday = int(n), time.Weekday(d-1)
+	case strings.ContainsAny(field, "L#"):
+		return field, fmt.Errorf("L and # may not be combined with other values: %s", field)
+	default:
+		bits, token, err := quartzBits(field, quartzDow)
+		if err != nil {
+			return token, err
+		}
+		// Shift from Quartz's numbering (1 = SUN) to time.Weekday (0 = SUN).
+		s.dow = bits >> 1
+	}
+	return field, nil
+}
+
+// parseYears parses the year field into a set indexed from quartzYears.min.
+func parseYears(field string) ([]bool, string, error) {
+	years := make([]bool, quartzYears.max-quartzYears.min+1)
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		var (
+			rangeAndStep = strings.Split(expr, "/")
+			lowAndHigh   = strings.Split(rangeAndStep[0], "-")
+			start, end   = quartzYears.min, quartzYears.max
+			step         = uint(1)
+			err          error
+		)
+		if lowAndHigh[0] != "*" {
+			if start, err = mustParseInt(lowAndHigh[0]); err != nil {
+				return nil, expr, err
+			}
+			switch len(lowAndHigh) {
+			case 1:
+				if len(rangeAndStep) == 1 {
+					end = start
+				}
+			case 2:
+				if end, err = mustParseInt(lowAndHigh[1]); err != nil {
+					return nil, expr, err
+				}
+			default:
+				return nil, expr, fmt.Errorf("too many hyphens: %s", expr)
+			}
+		}
+		switch len(rangeAndStep) {
+		case 1:
+		case 2:
+			if step, err = mustParseInt(rangeAndStep[1]); err != nil {
+				return nil, expr, err
+			}
+		default:
+			return nil, expr, fmt.Errorf("too many slashes: %s", expr)
+		}
+		switch {
+		case start < quartzYears.min:
+			return nil, expr, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, quartzYears.min, expr)
+		case end > quartzYears.max:
+			return nil, expr, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, quartzYears.max, expr)
+		case start > end:
+			return nil, expr, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+		case step == 0:
+			return nil, expr, fmt.Errorf("step of range should be a positive number: %s", expr)
+		}
+		for y := start; y <= end; y += step {
+			years[y-quartzYears.min] = true
+		}
+	}
+	return years, field, nil
+}
+
+// Next returns the next activation time, later than the given time, or the
+// zero time if there is none within the supported years.
+func (s *quartzSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	loc := s.location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	t = t.In(loc)
+
+	// The first day is searched for times after t, the others from midnight.
+	after := [3]int{t.Hour(), t.Minute(), t.Second()}
+	limit := t.Year() + quartzYearLimit
+	for d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc); d.Year() <= limit; {
+		switch {
+		case !s.matchYear(d.Year()):
+			d = time.Date(d.Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+		case s.month&(1<<uint(d.Month())) == 0:
+			d = time.Date(d.Year(), d.Month()+1, 1, 0, 0, 0, 0, loc)
+		default:
+			if s.matchDay(d.Year(), d.Month(), d.Day()) {
+				if next, ok := s.timeOfDay(d, after, loc); ok {
+					return next.In(origLocation)
+				}
+			}
+			d = time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+		}
+		after = [3]int{-1, -1, -1}
+	}
+	return time.Time{}
+}
+
+// timeOfDay returns the earliest time of the schedule on the day of d whose
+// wall clock is after the given hour, minute and second, skipping times that
+// do not exist because of daylight saving time.
+func (s *quartzSchedule) timeOfDay(d time.Time, after [3]int, loc *time.Location) (time.Time, bool) {
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 || h < after[0] {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if s.minute&(1<<uint(m)) == 0 || h == after[0] && m < after[1] {
+				continue
+			}
+			for sec := 0; sec < 60; sec++ {
+				if s.second&(1<<uint(sec)) == 0 || h == after[0] && m == after[1] && sec <= after[2] {
+					continue
+				}
+				t := time.Date(d.Year(), d.Month(), d.Day(), h, m, sec, 0, loc)
+				if t.Hour() == h && t.Minute() == m && t.Day() == d.Day() {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s *quartzSchedule) matchYear(year int) bool {
+	if s.years == nil {
+		return true
+	}
+	i := year - int(quartzYears.min)
+	return i >= 0 && i < len(s.years) && s.years[i]
+}
+
+// matchDay reports whether the schedule runs on the given day.
+func (s *quartzSchedule) matchDay(year int, month time.Month, day int) bool {
+	last := daysIn(year, month)
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	if s.byDow {
+		switch {
+		case s.lastDow:
+			return weekday == s.weekday && day+7 > last
+		case s.nthDow > 0:
+			return weekday == s.weekday && (day-1)/7+1 == s.nthDow
+		}
+		return s.dow&(1<<uint(weekday)) > 0
+	}
+	switch {
+	case s.lastDay:
+		return day == last-s.lastOffset
+	case s.lastWeekday:
+		return day == nearestWeekday(year, month, last)
+	case s.nearestWeekday > 0:
+		return s.nearestWeekday <= last && day == nearestWeekday(year, month, s.nearestWeekday)
+	}
+	return s.dom&(1<<uint(day)) > 0
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) nearest to the given
+// day, without leaving its month.
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := daysIn(year, month)
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// daysIn returns the number of days in the month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// String returns the spec the schedule was parsed from.
+func (s *quartzSchedule) String() string {
+	return s.spec
+}
+
+// Spec returns the spec the schedule was parsed from.
+func (s *quartzSchedule) Spec() string {
+	return s.spec
+}
+
+// describeQuartz describes the schedule like describeSpec.
+func describeQuartz(s *quartzSchedule) string {
+	parts := []string{describeTime(
+		fieldValues(s.second, seconds), fieldValues(s.minute, minutes), fieldValues(s.hour, hours))}
+	switch {
+	case s.lastDow:
+		parts = append(parts, "on the last "+s.weekday.String()+" of the month")
+	case s.nthDow > 0:
+		parts = append(parts, "on the "+ordinal(s.nthDow)+" "+s.weekday.String()+" of the month")
+	case s.byDow && !isAll(s.dow, dow):
+		parts = append(parts, "on "+describeValues(fieldValues(s.dow, dow), dayName))
+	case s.lastDay:
+		parts = append(parts, "on "+describeLastDay(uint(s.lastOffset)))
+	case s.lastWeekday:
+		parts = append(parts, "on the last weekday of the month")
+	case s.nearestWeekday > 0:
+		parts = append(parts, "on the weekday nearest day "+strconv.Itoa(s.nearestWeekday)+" of the month")
+	case !s.byDow && !isAll(s.dom, dom):
+		parts = append(parts, "on day "+describeValues(fieldValues(s.dom, dom), number)+" of the month")
+	}
+	if !isAll(s.month, months) {
+		parts = append(parts, "in "+describeValues(fieldValues(s.month, months), monthName))
+	}
+	if s.years != nil {
+		var years []uint
+		for i, ok := range s.years {
+			if ok {
+				years = append(years, quartzYears.min+uint(i))
+			}
+		}
+		parts = append(parts, "in "+describeValues(years, number))
+	}
+	return strings.Join(parts, ", ") + describeLocation(s.location)
+}
+
+// ordinal returns the English ordinal of n, from 1 to 5.
+func ordinal(n int) string {
+	return [...]string{"", "first", "second", "third", "fourth", "fifth"}[n]
+}
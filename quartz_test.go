@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestQuartzNext(t *testing.T) {
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		// Seconds and rollover.
+		{"Mon Jul 9 15:00 2012", "30 * * ? * *", "Mon Jul 9 15:00:30 2012"},
+		{"Mon Jul 9 23:59:59 2012", "0 0 0 ? * *", "Tue Jul 10 00:00 2012"},
+
+		// Last day of the month, with an offset, or the last weekday.
+		{"Mon Jul 9 15:00 2012", "0 0 0 L * ?", "Tue Jul 31 00:00 2012"},
+		{"Wed Feb 1 00:00 2012", "0 0 0 L * ?", "Wed Feb 29 00:00 2012"},
+		{"Mon Jul 9 15:00 2012", "0 0 0 L-2 * ?", "Sun Jul 29 00:00 2012"},
+		{"Sat Sep 1 00:00 2012", "0 0 0 LW * ?", "Fri Sep 28 00:00 2012"},
+
+		// Nearest weekday, without leaving the month.
+		{"Mon Jul 9 15:00 2012", "0 0 0 15W * ?", "Mon Jul 16 00:00 2012"},
+		{"Fri Aug 31 12:00 2012", "0 0 0 1W * ?", "Mon Sep 3 00:00 2012"},
+		{"Sat Sep 1 00:00 2012", "0 0 0 30W * ?", "Fri Sep 28 00:00 2012"},
+		{"Wed Feb 1 00:00 2012", "0 0 0 30W * ?", "Fri Mar 30 00:00 2012"},
+
+		// Days of week, numbered from 1 (SUN) to 7 (SAT).
+		{"Mon Jul 9 15:00 2012", "0 0 0 ? * 1,7", "Sat Jul 14 00:00 2012"},
+		{"Fri Jul 13 12:00 2012", "0 0 0 ? * MON-FRI", "Mon Jul 16 00:00 2012"},
+		{"Mon Jul 9 15:00 2012", "0 0 0 ? * L", "Sat Jul 14 00:00 2012"},
+		{"Mon Jul 9 15:00 2012", "0 15 10 ? * 6L", "Fri Jul 27 10:15 2012"},
+		{"Mon Jul 9 15:00 2012", "0 0 12 ? * 2#1", "Mon Aug 6 12:00 2012"},
+		{"Mon Jul 9 15:00 2012", "0 0 12 ? * MON#1", "Mon Aug 6 12:00 2012"},
+		{"Mon Jul 9 15:00 2012", "0 0 12 ? * 1#5", "Sun Jul 29 12:00 2012"},
+
+		// Years.
+		{"Mon Jul 9 15:00 2012", "0 0 0 1 1 ? 2015", "Thu Jan 1 00:00 2015"},
+		{"Thu Jan 1 00:00 2015", "0 0 0 1 1 ? 2015", ""},
+		{"Mon Jul 9 15:00 2012", "0 0 0 1 1 ? 2020/5", "Wed Jan 1 00:00 2020"},
+		{"Mon Jul 9 15:00 2012", "0 0 0 1 1 ? 2010-2011,2014", "Wed Jan 1 00:00 2014"},
+		{"Tue Jan 1 00:00 2013", "0 0 0 29 2 ?", "Mon Feb 29 00:00 2016"},
+
+		// Nonexistent times are skipped.
+		{"TZ=America/New_York 2012-03-10T03:00:00-0500", "0 30 2 * * ?", "2012-03-12T02:30:00-0400"},
+	}
+
+	parser := NewParser(Quartz)
+	for _, c := range runs {
+		sched, err := parser.Parse(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestQuartzErrors(t *testing.T) {
+	tests := []struct {
+		spec, err string
+	}{
+		{"0 0 0 * *", "expected 6 to 7 fields"},
+		{"0 0 0 ? * ?", "? may not be used in both"},
+		{"0 0 0 1 * MON", "specifying both"},
+		{"? 0 0 1 * ?", "second field: ? may only be used alone"},
+		{"0 0 0 L-31 * ?", "day of month field: offset"},
+		{"0 0 0 32W * ?", "day of month field: day (32) out of range"},
+		{"0 0 0 1,L * ?", "day of month field: L and W may not be combined"},
+		{"0 0 0 ? * 8", "day of week field: end of range (8) above maximum (7)"},
+		{"0 0 0 ? * 2#6", "day of week field: occurrence (6) out of range"},
+		{"0 0 0 1 * ? 1969", "year field: beginning of range (1969) below minimum (1970)"},
+		{"@daily", "parser does not accept descriptors"},
+	}
+	parser := NewParser(Quartz)
+	for _, c := range tests {
+		_, err := parser.Parse(c.spec)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s => expected error containing %q, got %v", c.spec, c.err, err)
+		}
+	}
+}
+
+func TestQuartzDescriptorsAndTimeZones(t *testing.T) {
+	parser := NewParser(Quartz | Descriptor)
+	if _, err := parser.Parse("@daily"); err != nil {
+		t.Error(err)
+	}
+	sched, err := parser.Parse("CRON_TZ=America/New_York 0 15 10 ? * 6L")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := sched.(fmt.Stringer).String(); s != "CRON_TZ=America/New_York 0 15 10 ? * 6L" {
+		t.Errorf("unexpected String: %q", s)
+	}
+	if d := Describe(sched); d != "At 10:15, on the last Friday of the month (America/New_York)" {
+		t.Errorf("unexpected description: %q", d)
+	}
+}
+
+func TestQuartzDescribe(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 0 12 ? * MON-FRI", "At 12:00, on Monday through Friday"},
+		{"0 0 12 ? * 2#1", "At 12:00, on the first Monday of the month"},
+		{"0 0 0 L-2 * ?", "At 00:00, on the last day of the month minus 2 days"},
+		{"0 0 0 LW * ?", "At 00:00, on the last weekday of the month"},
+		{"0 0 0 15W 1 ? 2030", "At 00:00, on the weekday nearest day 15 of the month, in January, in 2030"},
+	}
+	parser := NewParser(Quartz)
+	for _, test := range tests {
+		if actual := Describe(mustParse(t, parser, test.spec)); actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.spec, test.expected, actual)
+		}
+	}
+}
+
+func TestQuartzValidateAndCapabilities(t *testing.T) {
+	issues := ValidateSpec("0 0 0 ? * ? 1969", Quartz)
+	if len(issues) != 2 || issues[0].Field != 5 || issues[1].Field != 6 || issues[1].Token != "1969" {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+	if issues := ValidateSpec("0 15 10 ? * 6L 2030", Quartz); issues != nil {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+
+	c := NewParser(Quartz).Capabilities()
+	if !c.Seconds || !c.Hash || !c.Last || !c.Weekday || len(c.Fields) != 7 {
+		t.Fatalf("unexpected capabilities: %+v", c)
+	}
+	if f := c.Fields[5]; f.Min != 1 || f.Max != 7 || f.Names["sun"] != 1 {
+		t.Errorf("unexpected day of week field: %+v", f)
+	}
+	if f := c.Fields[6]; f.Name != "year" || !f.Optional || f.Min != 1970 || f.Max != 2099 {
+		t.Errorf("unexpected year field: %+v", f)
+	}
+}
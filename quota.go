@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// QuotaError is returned when an operation would exceed a limit imposed by a
+// Quota.
+type QuotaError struct {
+	// Resource names the exhausted limit, e.g. "entries" or "runs per minute".
+	Resource string
+	// Limit is the configured maximum for the resource.
+	Limit int
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("cron: quota exceeded: at most %d %s allowed", e.Limit, e.Resource)
+}
+
+// Quota limits the number of entries and the rate of job runs of the Cron
+// instances it is installed into. A single Quota may be shared by several Cron
+// instances (e.g. all schedulers belonging to one tenant) to enforce the
+// limits across the whole group.
+//
+// A limit of zero or less disables the corresponding check. A nil *Quota
+// imposes no limits.
+type Quota struct {
+	maxEntries       int
+	maxRunsPerMinute int
+
+	mu      sync.Mutex
+	entries int
+	window  time.Time
+	runs    int
+}
+
+// NewQuota returns a Quota allowing at most maxEntries registered entries and
+// maxRunsPerMinute job runs in any calendar minute.
+func NewQuota(maxEntries, maxRunsPerMinute int) *Quota {
+	return &Quota{
+		maxEntries:       maxEntries,
+		maxRunsPerMinute: maxRunsPerMinute,
+	}
+}
+
+// acquireEntry reserves room for a new entry, or returns a *QuotaError if the
+// entry limit has been reached.
+func (q *Quota) acquireEntry() error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.maxEntries > 0 && q.entries >= q.maxEntries {
+		return &QuotaError{Resource: "entries", Limit: q.maxEntries}
+	}
+	q.entries++
+	return nil
+}
+
+// releaseEntry returns the room held by a removed entry.
+func (q *Quota) releaseEntry() {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.entries > 0 {
+		q.entries--
+	}
+}
+
+// acquireRun records a job run at the given time, or returns a *QuotaError if
+// the run would exceed the per-minute limit.
+func (q *Quota) acquireRun(now time.Time) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if window := now.Truncate(time.Minute); !window.Equal(q.window) {
+		q.window = window
+		q.runs = 0
+	}
+	if q.maxRunsPerMinute > 0 && q.runs >= q.maxRunsPerMinute {
+		return &QuotaError{Resource: "runs per minute", Limit: q.maxRunsPerMinute}
+	}
+	q.runs++
+	return nil
+}
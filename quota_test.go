@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuotaMaxEntries(t *testing.T) {
+	cron := New(WithQuota(NewQuota(2, 0)))
+	if _, err := cron.AddFunc("@every 1m", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	id, err := cron.AddFunc("@every 1m", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cron.AddFunc("@every 1m", func() {})
+	if qerr, ok := err.(*QuotaError); !ok || qerr.Limit != 2 {
+		t.Fatalf("expected a *QuotaError with limit 2, got %v", err)
+	}
+	if cron.Schedule(Every(time.Minute), FuncJob(func() {})) != 0 {
+		t.Error("expected Schedule to reject the entry")
+	}
+
+	// Removing an entry frees up room for another.
+	cron.Remove(id)
+	if _, err := cron.AddFunc("@every 1m", func() {}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuotaSharedAcrossCrons(t *testing.T) {
+	quota := NewQuota(1, 0)
+	a := New(WithQuota(quota))
+	b := New(WithQuota(quota))
+	if _, err := a.AddFunc("@every 1m", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.AddFunc("@every 1m", func() {}); err == nil {
+		t.Error("expected the shared quota to reject the second entry")
+	}
+}
+
+func TestQuotaMaxRunsPerMinute(t *testing.T) {
+	quota := NewQuota(0, 2)
+	now := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if err := quota.acquireRun(now.Add(time.Duration(i) * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := quota.acquireRun(now.Add(30 * time.Second)); err == nil {
+		t.Error("expected the third run in the same minute to be rejected")
+	}
+	if err := quota.acquireRun(now.Add(time.Minute)); err != nil {
+		t.Errorf("expected the limit to reset in the next minute, got %v", err)
+	}
+}
+
+func TestQuotaSkipsRuns(t *testing.T) {
+	var calls int64
+	cron := New(WithParser(secondParser), WithChain(),
+		WithLogger(DiscardLogger), WithQuota(NewQuota(0, 1)))
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt64(&calls, 1) })
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt64(&calls, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	<-time.After(OneSecond)
+	if atomic.LoadInt64(&calls) != 1 {
+		t.Errorf("called %d times, expected 1", calls)
+	}
+}
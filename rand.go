@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedRand is a source of random numbers that is safe for concurrent use.
+// It backs every randomized feature of a Cron, so that they may be made
+// deterministic with WithRandSource.
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// newLockedRand returns a lockedRand drawing from the given source, or from a
+// time-seeded source if it is nil.
+func newLockedRand(src rand.Source) *lockedRand {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	return &lockedRand{r: rand.New(src)}
+}
+
+// Int63n returns a non-negative pseudo-random number in [0,n).
+// It returns 0 if n <= 0.
+func (r *lockedRand) Int63n(n int64) int64 {
+	if n <= 0 {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Int63n(n)
+}
+
+// Duration returns a pseudo-random duration in [0,max).
+func (r *lockedRand) Duration(max time.Duration) time.Duration {
+	return time.Duration(r.Int63n(int64(max)))
+}
+
+// Float64 returns a pseudo-random number in [0.0,1.0).
+func (r *lockedRand) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Float64()
+}
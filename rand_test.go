@@ -0,0 +1,29 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestWithRandSource(t *testing.T) {
+	a := New(WithRandSource(rand.NewSource(42)))
+	b := New(WithRandSource(rand.NewSource(42)))
+	for i := 0; i < 10; i++ {
+		if x, y := a.rand.Duration(time.Hour), b.rand.Duration(time.Hour); x != y {
+			t.Fatalf("expected identical sequences from identical sources, got %v and %v", x, y)
+		}
+	}
+}
+
+func TestLockedRandBounds(t *testing.T) {
+	r := newLockedRand(nil)
+	if r.Int63n(0) != 0 || r.Duration(-time.Second) != 0 {
+		t.Error("expected non-positive bounds to yield 0")
+	}
+	for i := 0; i < 100; i++ {
+		if d := r.Duration(time.Second); d < 0 || d >= time.Second {
+			t.Fatalf("duration %v out of range", d)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WrapperFactory builds a JobWrapper from its configuration, e.g. as decoded
+// from a JSON configuration file.
+type WrapperFactory func(config map[string]interface{}) (JobWrapper, error)
+
+var (
+	wrappersMu sync.RWMutex
+	wrappers   = make(map[string]WrapperFactory)
+)
+
+// RegisterWrapper makes a JobWrapper available by name to WrappersFromConfig,
+// so that wrappers can be attached to entries from configuration rather than
+// code. It is meant to be called from init functions. If RegisterWrapper is
+// called twice with the same name or if factory is nil, it panics.
+//
+// The following wrappers are registered by default, logging to DefaultLogger:
+//
+//	recover         Recover
+//	skipIfRunning   SkipIfStillRunning
+//	delayIfRunning  DelayIfStillRunning
+//	queueIfRunning  QueueIfStillRunning; config "maxQueued", default 1
+//	timeout         WithTimeout; config "duration", e.g. "30s"
+//	retry           RetryWithBackoff; config "attempts", "initialDelay",
+//	                "multiplier", "maxDelay" and "jitter", as in RetryConfig
+func RegisterWrapper(name string, factory WrapperFactory) {
+	wrappersMu.Lock()
+	defer wrappersMu.Unlock()
+	if factory == nil {
+		panic("cron: RegisterWrapper factory is nil")
+	}
+	if _, dup := wrappers[name]; dup {
+		panic("cron: RegisterWrapper called twice for wrapper " + name)
+	}
+	wrappers[name] = factory
+}
+
+// Wrappers returns the sorted names of the registered wrappers.
+func Wrappers() []string {
+	wrappersMu.RLock()
+	defer wrappersMu.RUnlock()
+	names := make([]string, 0, len(wrappers))
+	for name := range wrappers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WrapperConfig selects a registered wrapper and configures it. In JSON:
+//
+//	{"name": "timeout", "config": {"duration": "30s"}}
+type WrapperConfig struct {
+	Name   string                 `json:"name"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// WrappersFromConfig builds the configured wrappers, in order, for use with
+// WithWrappers or NewChain. It returns an error if a wrapper is not
+// registered or its configuration is invalid.
+func WrappersFromConfig(configs []WrapperConfig) ([]JobWrapper, error) {
+	wrappersMu.RLock()
+	defer wrappersMu.RUnlock()
+	built := make([]JobWrapper, 0, len(configs))
+	for _, config := range configs {
+		factory, ok := wrappers[config.Name]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown wrapper %q", config.Name)
+		}
+		w, err := factory(config.Config)
+		if err != nil {
+			return nil, fmt.Errorf("cron: wrapper %q: %v", config.Name, err)
+		}
+		built = append(built, w)
+	}
+	return built, nil
+}
+
+// ConfigFloat returns the number under key in a wrapper configuration, or def
+// if the key is absent.
+func ConfigFloat(config map[string]interface{}, key string, def float64) (float64, error) {
+	switch v := config[key].(type) {
+	case nil:
+		return def, nil
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("%s: expected a number, got %v", key, v)
+	}
+}
+
+// ConfigDuration returns the duration under key in a wrapper configuration.
+// It may be given as a string such as "30s", or as a time.Duration. It
+// returns def if the key is absent.
+func ConfigDuration(config map[string]interface{}, key string, def time.Duration) (time.Duration, error) {
+	switch v := config[key].(type) {
+	case nil:
+		return def, nil
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %v", key, err)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("%s: expected a duration such as \"30s\", got %v", key, v)
+	}
+}
+
+func init() {
+	RegisterWrapper("recover", func(map[string]interface{}) (JobWrapper, error) {
+		return Recover(DefaultLogger), nil
+	})
+	RegisterWrapper("skipIfRunning", func(map[string]interface{}) (JobWrapper, error) {
+		return SkipIfStillRunning(DefaultLogger), nil
+	})
+	RegisterWrapper("delayIfRunning", func(map[string]interface{}) (JobWrapper, error) {
+		return DelayIfStillRunning(DefaultLogger), nil
+	})
+	RegisterWrapper("queueIfRunning", func(config map[string]interface{}) (JobWrapper, error) {
+		n, err := ConfigFloat(config, "maxQueued", 1)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n != float64(int(n)) {
+			return nil, fmt.Errorf("maxQueued must be a non-negative integer")
+		}
+		return QueueIfStillRunning(int(n), DefaultLogger), nil
+	})
+	RegisterWrapper("timeout", func(config map[string]interface{}) (JobWrapper, error) {
+		d, err := ConfigDuration(config, "duration", 0)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("duration must be positive")
+		}
+		return WithTimeout(d, DefaultLogger), nil
+	})
+	RegisterWrapper("retry", func(config map[string]interface{}) (JobWrapper, error) {
+		var (
+			rc       RetryConfig
+			attempts float64
+			err      error
+		)
+		if attempts, err = ConfigFloat(config, "attempts", 0); err != nil {
+			return nil, err
+		}
+		rc.MaxAttempts = int(attempts)
+		if rc.InitialDelay, err = ConfigDuration(config, "initialDelay", 0); err != nil {
+			return nil, err
+		}
+		if rc.MaxDelay, err = ConfigDuration(config, "maxDelay", 0); err != nil {
+			return nil, err
+		}
+		if rc.Multiplier, err = ConfigFloat(config, "multiplier", 0); err != nil {
+			return nil, err
+		}
+		if rc.Jitter, err = ConfigFloat(config, "jitter", 0); err != nil {
+			return nil, err
+		}
+		return RetryWithBackoff(rc, DefaultLogger), nil
+	})
+}
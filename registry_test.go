@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWrappersFromConfig(t *testing.T) {
+	var configs []WrapperConfig
+	err := json.Unmarshal([]byte(`[
+		{"name": "skipIfRunning"},
+		{"name": "timeout", "config": {"duration": "30s"}}
+	]`), &configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrappers, err := WrappersFromConfig(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wrappers) != 2 {
+		t.Fatalf("expected 2 wrappers, got %d", len(wrappers))
+	}
+
+	var runs int32
+	NewChain(wrappers...).Then(FuncJob(func() { atomic.AddInt32(&runs, 1) })).Run()
+	if runs != 1 {
+		t.Errorf("expected the wrapped job to run once, got %d", runs)
+	}
+}
+
+func TestWrappersFromConfigErrors(t *testing.T) {
+	tests := []struct {
+		config WrapperConfig
+		err    string
+	}{
+		{WrapperConfig{Name: "nope"}, `unknown wrapper "nope"`},
+		{WrapperConfig{Name: "timeout"}, "duration must be positive"},
+		{WrapperConfig{Name: "timeout", Config: map[string]interface{}{"duration": "soon"}}, "duration: "},
+		{WrapperConfig{Name: "timeout", Config: map[string]interface{}{"duration": 30.0}}, "expected a duration"},
+	}
+	for _, test := range tests {
+		_, err := WrappersFromConfig([]WrapperConfig{test.config})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%+v: expected error containing %q, got %v", test.config, test.err, err)
+		}
+	}
+}
+
+func TestRegisterWrapper(t *testing.T) {
+	var tagged []string
+	RegisterWrapper("test.tag", func(config map[string]interface{}) (JobWrapper, error) {
+		tag, _ := config["tag"].(string)
+		return func(j Job) Job {
+			return FuncJob(func() {
+				tagged = append(tagged, tag)
+				j.Run()
+			})
+		}, nil
+	})
+	defer func() {
+		wrappersMu.Lock()
+		delete(wrappers, "test.tag")
+		wrappersMu.Unlock()
+	}()
+
+	wrappers, err := WrappersFromConfig([]WrapperConfig{{Name: "test.tag", Config: map[string]interface{}{"tag": "a"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron := New(WithChain())
+	id, _ := cron.AddFunc("@every 1h", func() {}, WithWrappers(wrappers...))
+	cron.startJob(cron.store.Get(id), cron.now())
+	cron.jobWaiter.Wait()
+	if len(tagged) != 1 || tagged[0] != "a" {
+		t.Errorf("expected the registered wrapper to run, got %v", tagged)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected registering a wrapper twice to panic")
+			}
+		}()
+		RegisterWrapper("test.tag", func(map[string]interface{}) (JobWrapper, error) { return nil, nil })
+	}()
+}
@@ -0,0 +1,183 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoReloader is returned by Reload for a Cron created without
+// WithReloader.
+var ErrNoReloader = errors.New("cron: no reloader configured")
+
+// JobSpec describes an entry: the job to run, the spec of its schedule and
+// the options it is added with.
+type JobSpec struct {
+	// Name identifies the entry. It is required by Reload, which matches
+	// entries by name, and optional elsewhere.
+	Name    string
+	Spec    string
+	Job     Job
+	Options []EntryOption
+}
+
+// options returns the options of the job spec, naming the entry after it.
+func (js JobSpec) options() []EntryOption {
+	opts := js.Options[:len(js.Options):len(js.Options)]
+	if js.Name != "" {
+		opts = append(opts, WithName(js.Name))
+	}
+	return opts
+}
+
+// EntrySource provides the entries a Cron should have. See WithReloader.
+type EntrySource interface {
+	Entries() ([]JobSpec, error)
+}
+
+// EntrySourceFunc is an EntrySource that calls the function.
+type EntrySourceFunc func() ([]JobSpec, error)
+
+// Entries returns the result of the function.
+func (f EntrySourceFunc) Entries() ([]JobSpec, error) { return f() }
+
+// reloader tracks the entries added from an EntrySource.
+type reloader struct {
+	src      EntrySource
+	interval time.Duration
+
+	mu      sync.Mutex // serializes reloads
+	managed map[string]reloaded
+}
+
+// reloaded is an entry added by the reloader.
+type reloaded struct {
+	id   EntryID
+	spec string
+}
+
+// Reload reads the entries of the source given to WithReloader and applies
+// the differences with those it added before, matched by name:
+//
+//   - an entry that is new, or was removed since, is added;
+//   - an entry whose spec changed is given the new schedule and job, keeping
+//     its ID, Prev time and statistics;
+//   - an entry no longer in the source is removed.
+//
+// Entries whose spec did not change are left as they are, as are those added
+// otherwise than by Reload. If the source fails, the entries are left as they
+// are and its error returned. Otherwise each entry is applied on its own: one
+// that fails, e.g. because its spec is invalid, is logged and skipped, and the
+// first such error returned.
+//
+// Reload may be called at any time, e.g. to reload on SIGHUP:
+//
+//	hup := make(chan os.Signal, 1)
+//	signal.Notify(hup, syscall.SIGHUP)
+//	go func() {
+//		for range hup {
+//			c.Reload()
+//		}
+//	}()
+func (c *Cron) Reload() error {
+	r := c.reloader
+	if r == nil {
+		return ErrNoReloader
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	specs, err := r.src.Entries()
+	if err != nil {
+		c.logger.Error(err, "reload")
+		return err
+	}
+	if r.managed == nil {
+		r.managed = make(map[string]reloaded)
+	}
+
+	var (
+		firstErr                error
+		added, updated, removed int
+		desired                 = make(map[string]bool, len(specs))
+	)
+	fail := func(name string, err error) {
+		c.logger.Error(err, "reload", "name", name)
+		if firstErr == nil {
+			firstErr = fmt.Errorf("cron: reload %q: %v", name, err)
+		}
+	}
+	for _, js := range specs {
+		switch {
+		case js.Name == "":
+			fail(js.Name, errors.New("entry has no name"))
+			continue
+		case desired[js.Name]:
+			fail(js.Name, ErrDuplicateName)
+			continue
+		}
+		desired[js.Name] = true
+		cur, ok := r.managed[js.Name]
+		if ok && c.Entry(cur.id).Valid() {
+			if cur.spec == js.Spec {
+				continue
+			}
+			schedule, err := c.parser.Parse(js.Spec)
+			if err == nil {
+				err = c.updateEntry(cur.id, entryUpdate{spec: js.Spec, schedule: schedule, job: js.Job})
+			}
+			if err != nil {
+				fail(js.Name, err)
+				continue
+			}
+			r.managed[js.Name] = reloaded{id: cur.id, spec: js.Spec}
+			updated++
+			continue
+		}
+		opts := append([]EntryOption{WithSource("reload")}, js.options()...)
+		id, err := c.AddJob(js.Spec, js.Job, opts...)
+		if err != nil {
+			delete(r.managed, js.Name)
+			fail(js.Name, err)
+			continue
+		}
+		r.managed[js.Name] = reloaded{id: id, spec: js.Spec}
+		added++
+	}
+	for name, cur := range r.managed {
+		if !desired[name] {
+			c.Remove(cur.id)
+			delete(r.managed, name)
+			removed++
+		}
+	}
+	c.logger.Info("reload", "added", added, "updated", updated, "removed", removed)
+	return firstErr
+}
+
+// startReloader reloads the entries at once and then every interval of the
+// reloader, if any, until the scheduler stops. It must be called with
+// runningMu held, once jobCtx is set.
+func (c *Cron) startReloader() {
+	r := c.reloader
+	if r == nil {
+		return
+	}
+	go func(ctx context.Context) {
+		c.Reload()
+		if r.interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Reload()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}(c.jobCtx)
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSource is an EntrySource whose entries may be changed by tests.
+type testSource struct {
+	mu    sync.Mutex
+	specs []JobSpec
+	err   error
+}
+
+func (s *testSource) Entries() ([]JobSpec, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]JobSpec(nil), s.specs...), s.err
+}
+
+func (s *testSource) set(specs []JobSpec, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.specs, s.err = specs, err
+}
+
+func TestReload(t *testing.T) {
+	job := FuncJob(func() {})
+	src := &testSource{specs: []JobSpec{
+		{Name: "report", Spec: "@daily", Job: job},
+		{Name: "cleanup", Spec: "@hourly", Job: job, Options: []EntryOption{WithOwner("ops")}},
+	}}
+	cron := New(WithReloader(src, 0))
+	manual, _ := cron.AddFunc("@weekly", func() {}, WithName("manual"))
+	if err := cron.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	report, cleanup := cron.EntryByName("report"), cron.EntryByName("cleanup")
+	if !report.Valid() || !cleanup.Valid() || cleanup.Owner != "ops" {
+		t.Fatalf("expected the entries to be added, got %v", cron.Entries())
+	}
+
+	src.set([]JobSpec{{Name: "report", Spec: "@monthly", Job: job}}, nil)
+	if err := cron.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	updated := cron.EntryByName("report")
+	if updated.ID != report.ID || updated.Spec != "@monthly" {
+		t.Errorf("expected entry %d to be updated in place, got %+v", report.ID, updated)
+	}
+	if cron.EntryByName("cleanup").Valid() {
+		t.Error("expected cleanup to be removed")
+	}
+	if !cron.Entry(manual).Valid() {
+		t.Error("expected the entry not added by Reload to be kept")
+	}
+
+	src.set(nil, errors.New("database down"))
+	if err := cron.Reload(); err == nil {
+		t.Error("expected the source's error")
+	}
+	if !cron.EntryByName("report").Valid() {
+		t.Error("expected the entries to be kept when the source fails")
+	}
+}
+
+func TestReloadInvalidEntries(t *testing.T) {
+	job := FuncJob(func() {})
+	src := &testSource{specs: []JobSpec{
+		{Name: "bad", Spec: "not a spec", Job: job},
+		{Spec: "@daily", Job: job},
+		{Name: "good", Spec: "@daily", Job: job},
+	}}
+	cron := New(WithReloader(src, 0))
+	if err := cron.Reload(); err == nil {
+		t.Error("expected an error")
+	}
+	if entries := cron.Entries(); len(entries) != 1 || entries[0].Name != "good" {
+		t.Errorf("expected only the valid entry to be added, got %v", entries)
+	}
+}
+
+func TestReloadWithoutReloader(t *testing.T) {
+	if err := New().Reload(); err != ErrNoReloader {
+		t.Errorf("expected ErrNoReloader, got %v", err)
+	}
+}
+
+func TestReloaderWhileRunning(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var once sync.Once
+	job := FuncJob(func() { once.Do(wg.Done) })
+	src := &testSource{specs: []JobSpec{{Name: "hourly", Spec: "0 0 * * * *", Job: job}}}
+	cron := New(WithParser(secondParser), WithChain(), WithReloader(src, 50*time.Millisecond))
+	cron.Start()
+	defer cron.Stop()
+
+	// The schedule is changed to run every second by a later reload.
+	src.set([]JobSpec{{Name: "hourly", Spec: "* * * * * ?", Job: job}}, nil)
+	select {
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the reloaded schedule to run")
+	case <-wait(&wg):
+	}
+}
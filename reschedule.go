@@ -0,0 +1,26 @@
+package cron
+
+import "time"
+
+// ReschedulingJob is a Job that may override the next run time of its entry,
+// e.g. an incremental sync that knows more data arrives in 30 seconds. When a
+// job submitted to Cron implements it, RunAndReschedule is called instead of
+// Run.
+type ReschedulingJob interface {
+	Job
+
+	// RunAndReschedule runs the job. If it returns a non-zero time, the entry's
+	// next run is moved to that time; later runs follow the schedule again.
+	// Times that are not in the future are logged and ignored.
+	RunAndReschedule() time.Time
+}
+
+// RescheduleFunc is a wrapper that turns a func() time.Time into a
+// ReschedulingJob.
+type RescheduleFunc func() time.Time
+
+// Run runs the function, ignoring the requested time.
+func (f RescheduleFunc) Run() { f() }
+
+// RunAndReschedule runs the function and returns the requested time.
+func (f RescheduleFunc) RunAndReschedule() time.Time { return f() }
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRescheduleFunc(t *testing.T) {
+	runs := make(chan time.Time, 10)
+	var count int32
+	cron := New(WithChain())
+	id := cron.Schedule(EveryImmediate(time.Hour), RescheduleFunc(func() time.Time {
+		now := time.Now()
+		runs <- now
+		if atomic.AddInt32(&count, 1) == 1 {
+			return now.Add(300 * time.Millisecond)
+		}
+		return time.Time{}
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	var first, second time.Time
+	for _, run := range []*time.Time{&first, &second} {
+		select {
+		case *run = <-runs:
+		case <-time.After(OneSecond):
+			t.Fatal("expected the job to run again at the requested time")
+		}
+	}
+	if gap := second.Sub(first); gap < 300*time.Millisecond || gap > 600*time.Millisecond {
+		t.Errorf("expected the second run about 300ms after the first, got %v", gap)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if next := cron.Entry(id).Next; next.Sub(second) < 59*time.Minute {
+		t.Errorf("expected the schedule to resume after the override, got next %v", next)
+	}
+}
+
+func TestRescheduleIntoPast(t *testing.T) {
+	var buf syncWriter
+	cron := New(WithLogger(newBufLogger(&buf)))
+	id := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	next := time.Now().Add(time.Hour)
+	cron.store.Get(id).Next = next
+
+	if cron.applyOutcome(runOutcome{id: id, next: time.Now().Add(-time.Second)}, time.Now()) {
+		t.Error("expected an override in the past to be ignored")
+	}
+	if !cron.store.Get(id).Next.Equal(next) {
+		t.Errorf("expected next to be unchanged")
+	}
+	if !strings.Contains(buf.String(), "not in the future") {
+		t.Errorf("expected the invalid override to be logged, got %q", buf.String())
+	}
+}
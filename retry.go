@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryConfig configures RetryWithBackoff. Zero fields take their defaults.
+type RetryConfig struct {
+	// MaxAttempts is the number of times a run is attempted, including the
+	// first one. It defaults to 3.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry. It defaults to one
+	// second.
+	InitialDelay time.Duration
+	// Multiplier scales the delay after each retry. It defaults to 2.
+	Multiplier float64
+	// MaxDelay caps the delay between attempts, if positive.
+	MaxDelay time.Duration
+	// Jitter extends each delay by a random fraction of it, up to Jitter
+	// (e.g. 0.2 for up to 20%), so that jobs failing together do not retry
+	// together.
+	Jitter float64
+}
+
+// withDefaults returns the config with its zero fields set to their defaults.
+func (rc RetryConfig) withDefaults() RetryConfig {
+	if rc.MaxAttempts <= 0 {
+		rc.MaxAttempts = 3
+	}
+	if rc.InitialDelay <= 0 {
+		rc.InitialDelay = time.Second
+	}
+	if rc.Multiplier <= 0 {
+		rc.Multiplier = 2
+	}
+	return rc
+}
+
+// delay returns the delay before the given retry, counting from 1.
+func (rc RetryConfig) delay(retry int, r *lockedRand) time.Duration {
+	d := float64(rc.InitialDelay)
+	for i := 1; i < retry && (rc.MaxDelay <= 0 || d < float64(rc.MaxDelay)); i++ {
+		d *= rc.Multiplier
+	}
+	if rc.MaxDelay > 0 && d > float64(rc.MaxDelay) {
+		d = float64(rc.MaxDelay)
+	}
+	if rc.Jitter > 0 {
+		d += d * rc.Jitter * r.Float64()
+	}
+	return time.Duration(d)
+}
+
+// RetryWithBackoff retries runs of the wrapped job that return an error (see
+// ErrorJob) or panic, waiting between attempts for a delay that grows
+// exponentially, and logging each retry to the given logger at Info level. If
+// the last attempt fails, its error is returned, or its panic resumed, to the
+// wrappers outside. Retries stop early if the run's context is done.
+func RetryWithBackoff(config RetryConfig, logger Logger) JobWrapper {
+	config = config.withDefaults()
+	r := newLockedRand(nil)
+	return func(j Job) Job {
+		return ContextErrFuncJob(func(ctx context.Context) error {
+			for attempt := 1; ; attempt++ {
+				err, panicked := attemptRun(ctx, j)
+				if err == nil {
+					return nil
+				}
+				if attempt >= config.MaxAttempts {
+					if panicked != nil {
+						panic(panicked)
+					}
+					return err
+				}
+				delay := config.delay(attempt, r)
+				logger.Info("retry", "attempt", attempt, "delay", delay, "error", err)
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					if panicked != nil {
+						panic(panicked)
+					}
+					return err
+				}
+			}
+		})
+	}
+}
+
+// attemptRun runs the job once, returning its error, or the value it panicked
+// with along with an error describing it.
+func attemptRun(ctx context.Context, j Job) (err error, panicked interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = r
+			if err, _ = r.(error); err == nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}
+	}()
+	return RunJobE(ctx, j), nil
+}
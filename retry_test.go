@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	var (
+		errFlaky = errors.New("flaky")
+		attempts int
+	)
+	config := RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond}
+	job := NewChain(RetryWithBackoff(config, DiscardLogger)).Then(ErrFuncJob(func() error {
+		attempts++
+		if attempts < 3 {
+			return errFlaky
+		}
+		return nil
+	}))
+	if err := RunJobE(context.Background(), job); err != nil || attempts != 3 {
+		t.Errorf("expected success on the third attempt, got %v after %d attempts", err, attempts)
+	}
+
+	attempts = 0
+	job = NewChain(RetryWithBackoff(config, DiscardLogger)).Then(ErrFuncJob(func() error {
+		attempts++
+		return errFlaky
+	}))
+	if err := RunJobE(context.Background(), job); err != errFlaky || attempts != 3 {
+		t.Errorf("expected the last error after 3 attempts, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRetryWithBackoffPanics(t *testing.T) {
+	var attempts int
+	job := NewChain(RetryWithBackoff(RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}, DiscardLogger)).
+		Then(FuncJob(func() {
+			attempts++
+			panic("boom")
+		}))
+	defer func() {
+		if r := recover(); r != "boom" || attempts != 2 {
+			t.Errorf("expected the last panic after 2 attempts, got %v after %d attempts", r, attempts)
+		}
+	}()
+	job.Run()
+}
+
+func TestRetryWithBackoffContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var attempts int
+	job := NewChain(RetryWithBackoff(RetryConfig{MaxAttempts: 5, InitialDelay: time.Hour}, DiscardLogger)).
+		Then(ErrFuncJob(func() error {
+			attempts++
+			return errors.New("down")
+		}))
+	if err := RunJobE(ctx, job); err == nil || attempts != 1 {
+		t.Errorf("expected retries to stop with the context, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRetryConfigDelay(t *testing.T) {
+	r := newLockedRand(nil)
+	rc := RetryConfig{InitialDelay: time.Second, MaxDelay: 5 * time.Second}.withDefaults()
+	for retry, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 60: 5 * time.Second} {
+		if d := rc.delay(retry, r); d != expected {
+			t.Errorf("retry %d: expected %v, got %v", retry, expected, d)
+		}
+	}
+	rc.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		if d := rc.delay(1, r); d < time.Second || d > 1500*time.Millisecond {
+			t.Errorf("expected a jittered delay within 50%%, got %v", d)
+		}
+	}
+}
+
+func TestRetryFromConfig(t *testing.T) {
+	wrappers, err := WrappersFromConfig([]WrapperConfig{{Name: "retry", Config: map[string]interface{}{
+		"attempts": 2.0, "initialDelay": "1ms", "multiplier": 3.0, "jitter": 0.1,
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attempts int
+	NewChain(wrappers...).Then(ErrFuncJob(func() error {
+		attempts++
+		return errors.New("down")
+	})).Run()
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
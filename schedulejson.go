@@ -0,0 +1,471 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scheduleJSON is the JSON form of the bundled schedules. Type discriminates
+// between them, and selects which of the other fields are set.
+type scheduleJSON struct {
+	Type string `json:"type"`
+
+	// Spec is the spec of "spec", "quartz", "hashed" and "iso8601"
+	// schedules.
+	Spec string `json:"spec,omitempty"`
+	// Fields and Key are the normalized fields and the key of a "hashed"
+	// schedule.
+	Fields []string `json:"fields,omitempty"`
+	Key    string   `json:"key,omitempty"`
+
+	// Delay, Immediate and Anchor describe an "every" schedule.
+	Delay     string     `json:"delay,omitempty"`
+	Immediate bool       `json:"immediate,omitempty"`
+	Anchor    *time.Time `json:"anchor,omitempty"`
+
+	// At is the time of an "at" schedule.
+	At *time.Time `json:"at,omitempty"`
+
+	// Schedule is the schedule wrapped by "shift", "window",
+	// "shift_to_business_day" and "skip_non_business_days" schedules.
+	Schedule json.RawMessage `json:"schedule,omitempty"`
+	// Offset is the offset of a "shift" schedule.
+	Offset string `json:"offset,omitempty"`
+
+	// Start, End and Days bound a "window" schedule; Days is a day of week
+	// field, such as "1-5".
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	Days  string `json:"days,omitempty"`
+
+	// TimeOfDay and Policy describe a "daily" schedule, and TimeOfDay and N
+	// a "business_day_of_month" one.
+	TimeOfDay string    `json:"time_of_day,omitempty"`
+	Policy    DSTPolicy `json:"policy,omitempty"`
+	N         int       `json:"n,omitempty"`
+
+	// Calendar and Direction are used by the schedules working on business
+	// days.
+	Calendar  *calendarJSON  `json:"calendar,omitempty"`
+	Direction ShiftDirection `json:"direction,omitempty"`
+
+	// Location is the name of the location of the schedule, if it has one.
+	Location string `json:"location,omitempty"`
+}
+
+// calendarJSON is the JSON form of the bundled calendars: "business" with
+// Dates as holidays, "dates" with Dates excluded, or "weekdays" with Days as
+// a day of week field.
+type calendarJSON struct {
+	Type  string   `json:"type"`
+	Dates []string `json:"dates,omitempty"`
+	Days  string   `json:"days,omitempty"`
+}
+
+// MarshalSchedule returns the JSON encoding of a schedule bundled with this
+// package, such as {"type": "spec", "spec": "0 5 * * *"}, which
+// UnmarshalSchedule decodes. Schedules of other types, or wrapping them, are
+// an error.
+func MarshalSchedule(s Schedule) ([]byte, error) {
+	v, err := scheduleToJSON(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalSchedule decodes a schedule encoded by MarshalSchedule.
+func UnmarshalSchedule(data []byte) (Schedule, error) {
+	var v scheduleJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v.schedule()
+}
+
+func scheduleToJSON(s Schedule) (scheduleJSON, error) {
+	switch s := s.(type) {
+	case *SpecSchedule:
+		return scheduleJSON{Type: "spec", Spec: s.Spec()}, nil
+	case *quartzSchedule:
+		return scheduleJSON{Type: "quartz", Spec: s.spec}, nil
+	case *hashedSchedule:
+		return scheduleJSON{Type: "hashed", Spec: s.spec, Fields: s.fields, Key: s.key,
+			Location: locationName(s.loc)}, nil
+	case RepeatingIntervalSchedule:
+		return scheduleJSON{Type: "iso8601", Spec: s.Spec()}, nil
+	case ConstantDelaySchedule:
+		return scheduleJSON{Type: "every", Delay: s.Delay.String()}, nil
+	case ImmediateDelaySchedule:
+		return scheduleJSON{Type: "every", Delay: s.Delay.String(), Immediate: true}, nil
+	case AnchoredDelaySchedule:
+		return scheduleJSON{Type: "every", Delay: s.Delay.String(), Anchor: &s.Anchor}, nil
+	case OneShotSchedule:
+		return scheduleJSON{Type: "at", At: &s.At}, nil
+	case dailySchedule:
+		return scheduleJSON{Type: "daily", TimeOfDay: fmt.Sprintf("%02d:%02d", s.hour, s.minute),
+			Policy: s.policy, Location: locationName(s.loc)}, nil
+	case MonthlyBusinessDaySchedule:
+		v := scheduleJSON{Type: "business_day_of_month", N: s.N, TimeOfDay: timeOfDay(s.TimeOfDay),
+			Location: locationName(s.Location)}
+		if s.Calendar != nil {
+			cal, err := calendarToJSON(s.Calendar)
+			if err != nil {
+				return scheduleJSON{}, err
+			}
+			v.Calendar = &cal
+		}
+		return v, nil
+	}
+
+	var (
+		v     scheduleJSON
+		inner Schedule
+		cal   Calendar
+	)
+	switch s := s.(type) {
+	case ShiftedSchedule:
+		v = scheduleJSON{Type: "shift", Offset: s.Offset.String()}
+		inner = s.Schedule
+	case WindowSchedule:
+		v = scheduleJSON{Type: "window", Start: timeOfDay(s.Start), End: timeOfDay(s.End),
+			Location: locationName(s.Location)}
+		if s.Days != 0 {
+			v.Days = formatField(s.Days, dow, true)
+		}
+		inner = s.Schedule
+	case businessDaySchedule:
+		v = scheduleJSON{Type: "shift_to_business_day", Direction: s.direction}
+		inner, cal = s.schedule, s.calendar
+	case calendarSchedule:
+		v = scheduleJSON{Type: "skip_non_business_days"}
+		inner, cal = s.schedule, s.calendar
+	default:
+		return scheduleJSON{}, fmt.Errorf("cron: cannot encode schedule of type %T", s)
+	}
+	data, err := MarshalSchedule(inner)
+	if err != nil {
+		return scheduleJSON{}, err
+	}
+	v.Schedule = data
+	if cal != nil {
+		c, err := calendarToJSON(cal)
+		if err != nil {
+			return scheduleJSON{}, err
+		}
+		v.Calendar = &c
+	}
+	return v, nil
+}
+
+// jsonParser parses the specs of encoded "spec" schedules, which have an
+// optional seconds field.
+var jsonParser = NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor)
+
+func (v scheduleJSON) schedule() (Schedule, error) {
+	loc, err := time.LoadLocation(v.Location)
+	if err != nil {
+		return nil, err
+	}
+	var inner Schedule
+	if len(v.Schedule) > 0 {
+		if inner, err = UnmarshalSchedule(v.Schedule); err != nil {
+			return nil, err
+		}
+	}
+	var cal Calendar
+	if v.Calendar != nil {
+		if cal, err = v.Calendar.calendar(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch v.Type {
+	case "spec":
+		return jsonParser.Parse(v.Spec)
+	case "quartz":
+		return NewParser(Quartz).Parse(v.Spec)
+	case "hashed":
+		s := &hashedSchedule{spec: v.Spec, fields: v.Fields, loc: loc}
+		if len(v.Fields) != len(places) {
+			return nil, fmt.Errorf("cron: hashed schedule needs %d fields: %v", len(places), v.Fields)
+		}
+		if err := s.resolve(v.Key); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "iso8601":
+		return ParseISO8601(v.Spec)
+	case "every":
+		delay, err := time.ParseDuration(v.Delay)
+		if err != nil {
+			return nil, err
+		}
+		if delay <= 0 {
+			return nil, fmt.Errorf("cron: delay must be positive: %s", v.Delay)
+		}
+		switch {
+		case v.Anchor != nil:
+			return AnchoredDelaySchedule{Delay: delay, Anchor: *v.Anchor}, nil
+		case v.Immediate:
+			return ImmediateDelaySchedule{ConstantDelaySchedule{delay}}, nil
+		}
+		return ConstantDelaySchedule{delay}, nil
+	case "at":
+		if v.At == nil {
+			return nil, fmt.Errorf("cron: at schedule needs a time")
+		}
+		return At(*v.At), nil
+	case "daily":
+		at, err := parseTimeOfDay(v.TimeOfDay)
+		if err != nil {
+			return nil, err
+		}
+		return dailySchedule{int(at / time.Hour), int(at % time.Hour / time.Minute), loc, v.Policy}, nil
+	case "business_day_of_month":
+		at, err := parseTimeOfDay(v.TimeOfDay)
+		if err != nil {
+			return nil, err
+		}
+		if v.N == 0 {
+			return nil, fmt.Errorf("cron: business day of month needs n")
+		}
+		return MonthlyBusinessDaySchedule{N: v.N, Calendar: cal, TimeOfDay: at, Location: loc}, nil
+	}
+
+	switch v.Type {
+	case "shift", "window", "shift_to_business_day", "skip_non_business_days":
+		if inner == nil {
+			return nil, fmt.Errorf("cron: %s schedule needs a schedule", v.Type)
+		}
+	default:
+		return nil, fmt.Errorf("cron: unknown schedule type %q", v.Type)
+	}
+	switch v.Type {
+	case "shift":
+		offset, err := time.ParseDuration(v.Offset)
+		if err != nil {
+			return nil, err
+		}
+		return Shift(inner, offset), nil
+	case "window":
+		s := WindowSchedule{Schedule: inner, Location: loc}
+		if s.Start, err = parseTimeOfDay(v.Start); err != nil {
+			return nil, err
+		}
+		if s.End, err = parseTimeOfDay(v.End); err != nil {
+			return nil, err
+		}
+		for _, expr := range strings.Split(v.Days, ",") {
+			if expr == "" {
+				continue
+			}
+			days, err := getDowRange(expr)
+			if err != nil {
+				return nil, err
+			}
+			s.Days |= days &^ starBit
+		}
+		return s, nil
+	}
+
+	if cal == nil {
+		return nil, fmt.Errorf("cron: %s schedule needs a calendar", v.Type)
+	}
+	if v.Type == "shift_to_business_day" {
+		return ShiftToBusinessDay(inner, cal, v.Direction), nil
+	}
+	return SkipNonBusinessDays(inner, cal), nil
+}
+
+func calendarToJSON(cal Calendar) (calendarJSON, error) {
+	switch cal := cal.(type) {
+	case *BusinessCalendar:
+		return calendarJSON{Type: "business", Dates: formatDates(cal.holidays)}, nil
+	case *DateListCalendar:
+		return calendarJSON{Type: "dates", Dates: formatDates(cal.excluded)}, nil
+	case WeekdayCalendar:
+		var days []string
+		for _, wd := range cal {
+			days = append(days, fmt.Sprint(int(wd)))
+		}
+		return calendarJSON{Type: "weekdays", Days: strings.Join(days, ",")}, nil
+	}
+	return calendarJSON{}, fmt.Errorf("cron: cannot encode calendar of type %T", cal)
+}
+
+func (v calendarJSON) calendar() (Calendar, error) {
+	switch v.Type {
+	case "business":
+		dates, err := ParseDateListCalendar(v.Dates...)
+		if err != nil {
+			return nil, err
+		}
+		return &BusinessCalendar{holidays: dates.excluded}, nil
+	case "dates":
+		return ParseDateListCalendar(v.Dates...)
+	case "weekdays":
+		cal := WeekdayCalendar{}
+		for _, expr := range strings.Split(v.Days, ",") {
+			if expr == "" {
+				continue
+			}
+			bits, err := getDowRange(expr)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range fieldValues(bits, dow) {
+				cal = append(cal, time.Weekday(d))
+			}
+		}
+		return cal, nil
+	}
+	return nil, fmt.Errorf("cron: unknown calendar type %q", v.Type)
+}
+
+// formatDates returns the dates in "2006-01-02" format, in order.
+func formatDates(dates map[civilDate]bool) []string {
+	formatted := make([]string, 0, len(dates))
+	for d := range dates {
+		formatted = append(formatted, fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day))
+	}
+	sort.Strings(formatted)
+	return formatted
+}
+
+// locationName returns the name of the location, or "" if it is nil.
+func locationName(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+	return loc.String()
+}
+
+// timeOfDay formats a time of day measured from midnight as "15:04".
+func timeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", d/time.Hour, d%time.Hour/time.Minute)
+}
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s *SpecSchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s ConstantDelaySchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s ImmediateDelaySchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s AnchoredDelaySchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s ShiftedSchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s WindowSchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s OneShotSchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s RepeatingIntervalSchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// MarshalJSON encodes the schedule as MarshalSchedule does.
+func (s MonthlyBusinessDaySchedule) MarshalJSON() ([]byte, error) { return MarshalSchedule(s) }
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *SpecSchedule) UnmarshalJSON(data []byte) error { return unmarshalScheduleInto(data, s) }
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *ConstantDelaySchedule) UnmarshalJSON(data []byte) error {
+	return unmarshalScheduleInto(data, s)
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *ImmediateDelaySchedule) UnmarshalJSON(data []byte) error {
+	return unmarshalScheduleInto(data, s)
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *AnchoredDelaySchedule) UnmarshalJSON(data []byte) error {
+	return unmarshalScheduleInto(data, s)
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *ShiftedSchedule) UnmarshalJSON(data []byte) error { return unmarshalScheduleInto(data, s) }
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *WindowSchedule) UnmarshalJSON(data []byte) error { return unmarshalScheduleInto(data, s) }
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *OneShotSchedule) UnmarshalJSON(data []byte) error { return unmarshalScheduleInto(data, s) }
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *RepeatingIntervalSchedule) UnmarshalJSON(data []byte) error {
+	return unmarshalScheduleInto(data, s)
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *MonthlyBusinessDaySchedule) UnmarshalJSON(data []byte) error {
+	return unmarshalScheduleInto(data, s)
+}
+
+// unmarshalScheduleInto decodes a schedule into dst, a pointer to a schedule
+// of the same type.
+func unmarshalScheduleInto(data []byte, dst interface{}) error {
+	s, err := UnmarshalSchedule(data)
+	if err != nil {
+		return err
+	}
+	switch dst := dst.(type) {
+	case *SpecSchedule:
+		if src, ok := s.(*SpecSchedule); ok {
+			*dst = *src
+			return nil
+		}
+	case *ConstantDelaySchedule:
+		if src, ok := s.(ConstantDelaySchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *ImmediateDelaySchedule:
+		if src, ok := s.(ImmediateDelaySchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *AnchoredDelaySchedule:
+		if src, ok := s.(AnchoredDelaySchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *ShiftedSchedule:
+		if src, ok := s.(ShiftedSchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *WindowSchedule:
+		if src, ok := s.(WindowSchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *OneShotSchedule:
+		if src, ok := s.(OneShotSchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *RepeatingIntervalSchedule:
+		if src, ok := s.(RepeatingIntervalSchedule); ok {
+			*dst = src
+			return nil
+		}
+	case *MonthlyBusinessDaySchedule:
+		if src, ok := s.(MonthlyBusinessDaySchedule); ok {
+			*dst = src
+			return nil
+		}
+	}
+	return fmt.Errorf("cron: cannot decode %T into %T", s, dst)
+}
@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshalSchedule(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	spec, _ := ParseStandard("CRON_TZ=America/New_York 30 5 * * 1-5")
+	hashed, _ := NewParser(Minute | Hour | Dom | Month | Dow | Hashed).Parse("H H(0-5) * * *")
+	quartz, _ := NewParser(Quartz).Parse("0 15 10 ? * 6L")
+	iso, _ := ParseISO8601("R5/2024-01-01T00:00:00Z/PT1H")
+	holidays := NewBusinessCalendar(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))
+	third := NthBusinessDayOfMonth(3, ny)
+	third.Calendar = holidays
+	third.TimeOfDay = 9*time.Hour + 30*time.Minute
+
+	for _, s := range []Schedule{
+		spec,
+		hashed.(hashKeyed).withHashKey("host-1"),
+		quartz,
+		iso,
+		Every(5 * time.Minute),
+		EveryImmediate(time.Hour),
+		EveryFrom(15*time.Minute, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		At(time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)),
+		DailyAt("02:30", ny, DSTSkipMissing),
+		third,
+		Shift(spec, 20*time.Minute),
+		WindowSchedule{Schedule: Every(5 * time.Minute), Start: 9 * time.Hour, End: 17 * time.Hour,
+			Days: 1<<1 | 1<<3 | 1<<5, Location: ny},
+		ShiftToBusinessDay(spec, holidays, Backward),
+		SkipNonBusinessDays(spec, WeekdayCalendar{time.Sunday, time.Monday}),
+		SkipNonBusinessDays(spec, NewDateListCalendar(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC))),
+	} {
+		data, err := MarshalSchedule(s)
+		if err != nil {
+			t.Errorf("%T: %v", s, err)
+			continue
+		}
+		decoded, err := UnmarshalSchedule(data)
+		if err != nil {
+			t.Errorf("%s: %v", data, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, s) {
+			t.Errorf("%s: expected %+v, got %+v", data, s, decoded)
+		}
+	}
+}
+
+func TestMarshalScheduleErrors(t *testing.T) {
+	if _, err := MarshalSchedule(fixedSchedule{}); err == nil {
+		t.Error("expected an error for a custom schedule")
+	}
+	if _, err := MarshalSchedule(Shift(fixedSchedule{}, time.Hour)); err == nil {
+		t.Error("expected an error for a shifted custom schedule")
+	}
+	for _, data := range []string{
+		`{"type": "sometimes"}`,
+		`{"type": "shift", "offset": "1h"}`,
+		`{"type": "spec", "spec": "61 * * * *"}`,
+		`{"type": "every", "delay": "-1m"}`,
+		`{"type": "skip_non_business_days", "schedule": {"type": "every", "delay": "1m"}}`,
+	} {
+		if _, err := UnmarshalSchedule([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", data)
+		}
+	}
+}
+
+func TestScheduleJSONField(t *testing.T) {
+	var config struct {
+		Poll ConstantDelaySchedule `json:"poll"`
+	}
+	if err := json.Unmarshal([]byte(`{"poll": {"type": "every", "delay": "30s"}}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Poll.Delay != 30*time.Second {
+		t.Errorf("expected a 30s delay, got %v", config.Poll.Delay)
+	}
+	if err := json.Unmarshal([]byte(`{"poll": {"type": "at", "at": "2026-01-01T00:00:00Z"}}`), &config); err == nil {
+		t.Error("expected an error decoding another type of schedule")
+	}
+}
+
+func TestImmediateDelayScheduleJSON(t *testing.T) {
+	data, err := json.Marshal(EveryImmediate(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"type":"every","delay":"1m0s","immediate":true}`; string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+	var decoded ImmediateDelaySchedule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != EveryImmediate(time.Minute) {
+		t.Errorf("expected the schedule to round-trip, got %+v", decoded)
+	}
+}
+
+func TestAnchoredDelayScheduleJSON(t *testing.T) {
+	schedule := EveryFrom(15*time.Minute, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"type":"every","delay":"15m0s","anchor":"2026-01-01T00:00:00Z"}`; string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+	var decoded AnchoredDelaySchedule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != schedule {
+		t.Errorf("expected the schedule to round-trip, got %+v", decoded)
+	}
+	if s, err := UnmarshalSchedule(data); err != nil || s != schedule {
+		t.Errorf("expected UnmarshalSchedule to decode the schedule, got %+v, %v", s, err)
+	}
+}
@@ -0,0 +1,27 @@
+package cron
+
+import "time"
+
+// ShiftedSchedule activates a fixed offset after (or, if Offset is negative,
+// before) each activation of another schedule.
+type ShiftedSchedule struct {
+	Schedule Schedule
+	Offset   time.Duration
+}
+
+// Shift returns a Schedule whose activations are those of s moved by d, e.g.
+// "20 minutes after the nightly batch". The offset is applied to the absolute
+// activation time, so a shifted activation always follows the original one by
+// exactly d, even across daylight saving transitions.
+func Shift(s Schedule, d time.Duration) ShiftedSchedule {
+	return ShiftedSchedule{Schedule: s, Offset: d}
+}
+
+// Next returns the next activation time, later than the given time.
+func (s ShiftedSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t.Add(-s.Offset))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(s.Offset)
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShift(t *testing.T) {
+	nightly, _ := ParseStandard("0 2 * * *")
+	tests := []struct {
+		offset   time.Duration
+		time     string
+		expected string
+	}{
+		{20 * time.Minute, "Mon Jul 9 02:10 2012", "Mon Jul 9 02:20 2012"},
+		{20 * time.Minute, "Mon Jul 9 02:20 2012", "Tue Jul 10 02:20 2012"},
+		{20 * time.Minute, "Mon Jul 9 01:00 2012", "Mon Jul 9 02:20 2012"},
+		{-30 * time.Minute, "Mon Jul 9 01:00 2012", "Mon Jul 9 01:30 2012"},
+		{-30 * time.Minute, "Mon Jul 9 01:45 2012", "Tue Jul 10 01:30 2012"},
+		{25 * time.Hour, "Mon Jul 9 02:10 2012", "Mon Jul 9 03:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := Shift(nightly, c.offset).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, %v: (expected) %v != %v (actual)", c.time, c.offset, expected, actual)
+		}
+	}
+}
+
+func TestShiftDST(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	// 02:30 does not exist on Mar 11 2012, so the schedule runs at 03:00; the
+	// shifted schedule follows that activation by exactly the offset.
+	s, _ := ParseStandard("CRON_TZ=America/New_York 30 2 * * *")
+	from := time.Date(2012, 3, 11, 0, 0, 0, 0, ny)
+	actual := Shift(s, 20*time.Minute).Next(from)
+	expected := s.Next(from).Add(20 * time.Minute)
+	if !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestShiftZero(t *testing.T) {
+	never, _ := ParseStandard("0 0 30 2 *")
+	if next := Shift(never, time.Hour).Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected the zero time, got %v", next)
+	}
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// StoreClock is implemented by shared stores, such as SQLOnceStore, that can
+// report the current time on the store's own clock. WithMaxClockSkew uses it
+// to detect hosts whose clocks have drifted from the store's.
+type StoreClock interface {
+	// StoreTime returns the current time according to the store.
+	StoreTime() (time.Time, error)
+}
+
+// ErrClockSkew is logged when the local clock differs from the clock of a
+// shared store by more than the limit set with WithMaxClockSkew.
+var ErrClockSkew = errors.New("cron: clock skew with the store exceeds the limit")
+
+// skewLimit is the configuration installed by WithMaxClockSkew.
+type skewLimit struct {
+	max    time.Duration
+	refuse bool
+}
+
+// clockSkew measures the difference between the local clock and the store's,
+// positive if the local clock is ahead. It assumes that the store read its
+// clock halfway through the round trip.
+func clockSkew(clock StoreClock, now func() time.Time) (time.Duration, error) {
+	before := now()
+	storeTime, err := clock.StoreTime()
+	if err != nil {
+		return 0, err
+	}
+	after := now()
+	return before.Add(after.Sub(before) / 2).Sub(storeTime), nil
+}
+
+// checkSkew measures the skew with the once store of the given entry, if it
+// can report its clock, and logs it if it exceeds the limit. It returns false
+// if the run must not claim its occurrence. Failures to read the store's clock
+// are logged but do not prevent the claim.
+func (c *Cron) checkSkew(store OnceStore, id EntryID) bool {
+	clock, ok := store.(StoreClock)
+	if c.skew == nil || !ok {
+		return true
+	}
+	skew, err := clockSkew(clock, c.now)
+	if err != nil {
+		c.logger.Error(err, "clock skew", "entry", id)
+		return true
+	}
+	if skew <= c.skew.max && skew >= -c.skew.max {
+		return true
+	}
+	c.logger.Error(ErrClockSkew, "clock skew", "entry", id, "skew", skew, "max", c.skew.max)
+	return !c.skew.refuse
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clockedOnceStore is a memoryOnceStore whose clock is offset from the local
+// one.
+type clockedOnceStore struct {
+	memoryOnceStore
+	offset time.Duration
+}
+
+func (s *clockedOnceStore) StoreTime() (time.Time, error) {
+	return time.Now().Add(s.offset), nil
+}
+
+func TestWithMaxClockSkew(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  time.Duration
+		refuse  bool
+		runs    int64
+		skipped uint64
+		logged  bool
+	}{
+		{"within limit", time.Second, true, 1, 0, false},
+		{"store behind", -time.Minute, false, 1, 0, true},
+		{"refused", time.Minute, true, 0, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				buf   syncWriter
+				calls int64
+				store = &clockedOnceStore{
+					memoryOnceStore: memoryOnceStore{claims: make(map[string]time.Time)},
+					offset:          tt.offset,
+				}
+			)
+			cron := New(WithChain(), WithMaxClockSkew(10*time.Second, tt.refuse),
+				WithLogger(VerbosePrintfLogger(log.New(&buf, "", 0))))
+			id, _ := cron.AddFunc("@daily", func() { atomic.AddInt64(&calls, 1) },
+				WithOnceGuard(store, "job"))
+
+			cron.startJob(cron.store.Get(id), time.Now())
+			cron.jobWaiter.Wait()
+
+			if n := atomic.LoadInt64(&calls); n != tt.runs {
+				t.Errorf("expected %d runs, got %d", tt.runs, n)
+			}
+			if n := cron.StatsSnapshot()[id].Skipped; n != tt.skipped {
+				t.Errorf("expected %d skipped runs, got %d", tt.skipped, n)
+			}
+			if logged := strings.Contains(buf.String(), ErrClockSkew.Error()); logged != tt.logged {
+				t.Errorf("expected skew logged: %v, got log %q", tt.logged, buf.String())
+			}
+		})
+	}
+}
+
+func TestClockSkewIgnoresStoresWithoutClock(t *testing.T) {
+	var buf bytes.Buffer
+	cron := New(WithMaxClockSkew(0, true), WithLogger(PrintfLogger(log.New(&buf, "", 0))))
+	if !cron.checkSkew(&memoryOnceStore{}, 1) {
+		t.Error("expected stores without a clock to be claimed from")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing logged, got %q", buf.String())
+	}
+}
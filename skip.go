@@ -0,0 +1,64 @@
+package cron
+
+import "context"
+
+// SkipReason classifies why an activation of an entry did not result in a
+// run. It is reported in JobSkipped events and counted per entry in
+// EntryStats.SkipReasons, so that a missing run can be explained from
+// telemetry. Wrappers outside this package may report their own skips, with
+// these reasons or others, using ReportSkip.
+type SkipReason string
+
+const (
+	// SkipStillRunning is reported when a previous run of the entry had not
+	// completed, e.g. by SkipIfStillRunning, QueueIfStillRunning and
+	// SkipIfRunPastNext.
+	SkipStillRunning SkipReason = "still running"
+	// SkipBlackout is meant for wrappers that suppress runs during blackout
+	// windows, such as maintenance periods.
+	SkipBlackout SkipReason = "blackout"
+	// SkipCircuitOpen is meant for circuit breakers that suppress runs after
+	// repeated failures.
+	SkipCircuitOpen SkipReason = "circuit open"
+	// SkipPredicate is reported when the admission function skipped the run.
+	// See WithAdmission.
+	SkipPredicate SkipReason = "predicate"
+	// SkipOverloaded is reported when the run was refused by the Quota, or
+	// could not start in time because its group or the worker pool was busy.
+	SkipOverloaded SkipReason = "overloaded"
+	// SkipMisfire is reported when a late run was skipped by its
+	// MisfirePolicy or misfire handler.
+	SkipMisfire SkipReason = "misfire"
+	// SkipStale is reported when a run was later than the stale threshold.
+	// See WithStaleThreshold.
+	SkipStale SkipReason = "stale"
+	// SkipLocked is reported when the distributed lock of the entry was held
+	// elsewhere. See WithDistributedLock.
+	SkipLocked SkipReason = "locked"
+	// SkipUnhealthy is reported when a dependency of the job was unhealthy.
+	// See HealthGate.
+	SkipUnhealthy SkipReason = "unhealthy"
+	// SkipAlreadyRun is reported when the occurrence had already been run, as
+	// recorded by the entry's once guard.
+	SkipAlreadyRun SkipReason = "already run"
+	// SkipClockSkew is reported when the clock was too far off to run safely.
+	// See WithMaxClockSkew.
+	SkipClockSkew SkipReason = "clock skew"
+	// SkipFailed is reported when the run could not be prepared, e.g. because
+	// its payload could not be decrypted.
+	SkipFailed SkipReason = "failed"
+)
+
+// skipReporterKey is the context key of the func that reports a skipped run.
+type skipReporterKey struct{}
+
+// ReportSkip reports that the run of ctx was skipped for the given reason, as
+// its JobSkipped event and in its entry's statistics. Detail may give more
+// context, e.g. "queue full". It is meant for JobWrappers that decide not to
+// run the wrapped job; it does nothing if ctx is not the context of a run of
+// a Cron.
+func ReportSkip(ctx context.Context, reason SkipReason, detail string) {
+	if report, ok := ctx.Value(skipReporterKey{}).(func(SkipReason, string)); ok {
+		report(reason, detail)
+	}
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSkipReasonFromWrapper(t *testing.T) {
+	var (
+		rec     = &eventRecorder{}
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	cron := New(WithLogger(DiscardLogger), WithEventListener(rec))
+	id, _ := cron.AddFunc("@yearly", func() {
+		close(started)
+		<-release
+	}, WithWrappers(SkipIfStillRunning(DiscardLogger)))
+
+	cron.startJob(cron.store.Get(id), time.Now())
+	<-started
+	cron.startJob(cron.store.Get(id), time.Now())
+
+	// The second run is skipped once it reaches the wrapper.
+	var skips []JobSkipped
+	for deadline := time.Now().Add(time.Second); len(skips) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+		for _, ev := range rec.recorded() {
+			if ev, ok := ev.(JobSkipped); ok {
+				skips = append(skips, ev)
+			}
+		}
+	}
+	close(release)
+	cron.jobWaiter.Wait()
+
+	if len(skips) != 1 || skips[0].Entry != id || skips[0].Reason != SkipStillRunning || skips[0].Missed {
+		t.Errorf("expected one skip of entry %d because it was still running, got %+v", id, skips)
+	}
+	stats := cron.StatsSnapshot()[id]
+	if stats.Skipped != 1 || stats.SkipReasons[SkipStillRunning] != 1 {
+		t.Errorf("expected the skip to be counted by reason, got %+v", stats)
+	}
+}
+
+func TestSkipReasonStats(t *testing.T) {
+	cron := New(WithLogger(DiscardLogger), WithStaleThreshold(time.Minute),
+		WithAdmission(func(Entry, time.Time) Decision { return SkipRun("maintenance") }))
+	id, _ := cron.AddFunc("@hourly", func() {})
+	e := cron.store.Get(id)
+
+	e.Next = time.Now().Add(-time.Hour)
+	cron.fire(e, time.Now())
+	e.Next = time.Now()
+	cron.admit(e, time.Now())
+
+	stats := cron.StatsSnapshot()[id]
+	if stats.Skipped != 2 || stats.Missed != 1 ||
+		stats.SkipReasons[SkipStale] != 1 || stats.SkipReasons[SkipPredicate] != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// The snapshot is a copy.
+	stats.SkipReasons[SkipStale] = 0
+	if n := cron.StatsSnapshot()[id].SkipReasons[SkipStale]; n != 1 {
+		t.Errorf("expected the snapshot not to share its counters, got %d", n)
+	}
+}
+
+func TestReportSkipOutsideCron(t *testing.T) {
+	// Without a Cron, there is nothing to report to.
+	ReportSkip(context.Background(), SkipBlackout, "")
+}
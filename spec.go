@@ -1,6 +1,10 @@
 package cron
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // SpecSchedule specifies a duty cycle (to the second granularity), based on a
 // traditional crontab specification. It is computed initially and stored as bit sets.
@@ -23,6 +27,7 @@ var (
 	minutes = bounds{0, 59, nil}
 	hours   = bounds{0, 23, nil}
 	dom     = bounds{1, 31, nil}
+	lastDom = bounds{0, 30, nil} // days before the last day of the month
 	months  = bounds{1, 12, map[string]uint{
 		"jan": 1,
 		"feb": 2,
@@ -51,11 +56,21 @@ var (
 const (
 	// Set the top bit if a star was included in the expression.
 	starBit = 1 << 63
+
+	// Days of the month counted back from the last one are set in the Dom
+	// field from this bit on: bit lastDomShift+n selects the day n days
+	// before the last day of the month, as given by "L-n" ("L" for n = 0).
+	lastDomShift = 32
 )
 
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	return s.next(t, nil)
+}
+
+// next implements Next, recording the steps taken into e if it is not nil.
+func (s *SpecSchedule) next(t time.Time, e *Explanation) time.Time {
 	// General approach
 	//
 	// For Month, Day, Hour, Minute, Second:
@@ -76,6 +91,9 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 	}
 	if s.Location != time.Local {
 		t = t.In(s.Location)
+		if e != nil {
+			e.add("location", t, "converted to the schedule's time zone %s", s.Location)
+		}
 	}
 
 	// Start at the earliest possible time (the upcoming second).
@@ -89,11 +107,15 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 
 WRAP:
 	if t.Year() > yearLimit {
+		if e != nil {
+			e.add("year", t, "no activation found within five years")
+		}
 		return time.Time{}
 	}
 
 	// Find the first applicable month.
 	// If it's this month, then do nothing.
+	before := t
 	for 1<<uint(t.Month())&s.Month == 0 {
 		// If we have to add a month, reset the other parts to 0.
 		if !added {
@@ -105,15 +127,26 @@ WRAP:
 
 		// Wrapped around.
 		if t.Month() == time.January {
+			if e != nil {
+				e.add("year", t, "no matching month left in %d", before.Year())
+			}
 			goto WRAP
 		}
 	}
+	if e != nil && !t.Equal(before) {
+		e.add("month", t, "advanced from %s to %s: month not in schedule", before.Month(), t.Month())
+	}
 
 	// Now get a day in that month.
 	//
 	// NOTE: This causes issues for daylight savings regimes where midnight does
 	// not exist.  For example: Sao Paulo has DST that transforms midnight on
 	// 11/3 into 1am. Handle that by noticing when the Hour ends up != 0.
+	before = t
+	var reason string
+	if e != nil && !dayMatches(s, t) {
+		reason = dayMismatch(s, t)
+	}
 	for !dayMatches(s, t) {
 		if !added {
 			added = true
@@ -123,30 +156,52 @@ WRAP:
 		// Notice if the hour is no longer midnight due to DST.
 		// Add an hour if it's 23, subtract an hour if it's 1.
 		if t.Hour() != 0 {
+			hour := t.Hour()
 			if t.Hour() > 12 {
 				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
 			} else {
 				t = t.Add(time.Duration(-t.Hour()) * time.Hour)
 			}
+			if e != nil {
+				e.add("dst", t, "midnight fell at %02d:00 due to daylight saving time; adjusted to %s", hour, t.Format("15:04"))
+			}
 		}
 
 		if t.Day() == 1 {
+			if e != nil {
+				e.add("month", t, "no matching day left in %s", before.Month())
+			}
 			goto WRAP
 		}
 	}
+	if e != nil && !t.Equal(before) {
+		e.add("day", t, "advanced from %s to %s: %s", before.Format("Jan 2"), t.Format("Jan 2"), reason)
+	}
 
+	before = t
 	for 1<<uint(t.Hour())&s.Hour == 0 {
 		if !added {
 			added = true
 			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
 		}
+		hour := t.Hour()
 		t = t.Add(1 * time.Hour)
+		if e != nil && t.Hour() != (hour+1)%24 {
+			e.add("dst", t, "clock changed from %02d:00 to %02d:00 due to daylight saving time", hour+1, t.Hour())
+		}
 
 		if t.Hour() == 0 {
+			if e != nil {
+				e.add("day", t, "no matching hour left on %s", before.Format("Jan 2"))
+			}
 			goto WRAP
 		}
 	}
+	if e != nil && !t.Equal(before) {
+		e.add("hour", t, "advanced from %02d to %02d: hour not in schedule", before.Hour(), t.Hour())
+	}
 
+	before = t
 	for 1<<uint(t.Minute())&s.Minute == 0 {
 		if !added {
 			added = true
@@ -155,10 +210,17 @@ WRAP:
 		t = t.Add(1 * time.Minute)
 
 		if t.Minute() == 0 {
+			if e != nil {
+				e.add("hour", t, "no matching minute left in hour %02d", before.Hour())
+			}
 			goto WRAP
 		}
 	}
+	if e != nil && !t.Equal(before) {
+		e.add("minute", t, "advanced from %02d to %02d: minute not in schedule", before.Minute(), t.Minute())
+	}
 
+	before = t
 	for 1<<uint(t.Second())&s.Second == 0 {
 		if !added {
 			added = true
@@ -167,18 +229,36 @@ WRAP:
 		t = t.Add(1 * time.Second)
 
 		if t.Second() == 0 {
+			if e != nil {
+				e.add("minute", t, "no matching second left in minute %02d", before.Minute())
+			}
 			goto WRAP
 		}
 	}
+	if e != nil && !t.Equal(before) {
+		e.add("second", t, "advanced from %02d to %02d: second not in schedule", before.Second(), t.Second())
+	}
 
 	return t.In(origLocation)
 }
 
+// dayMismatch describes why the given day does not satisfy the schedule's
+// day-of-month and day-of-week restrictions.
+func dayMismatch(s *SpecSchedule, t time.Time) string {
+	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+		if !domMatches(s, t) {
+			return fmt.Sprintf("day of month %d not in schedule", t.Day())
+		}
+		return fmt.Sprintf("%s not in schedule", t.Weekday())
+	}
+	return fmt.Sprintf("neither day of month %d nor %s in schedule (either one suffices)", t.Day(), t.Weekday())
+}
+
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 func dayMatches(s *SpecSchedule, t time.Time) bool {
 	var (
-		domMatch bool = 1<<uint(t.Day())&s.Dom > 0
+		domMatch bool = domMatches(s, t)
 		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0
 	)
 	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
@@ -186,3 +266,95 @@ func dayMatches(s *SpecSchedule, t time.Time) bool {
 	}
 	return domMatch || dowMatch
 }
+
+// domMatches returns true if the schedule's day-of-month restriction, including
+// days counted back from the last day of the month, is satisfied by the given
+// time.
+func domMatches(s *SpecSchedule, t time.Time) bool {
+	if 1<<uint(t.Day())&s.Dom > 0 {
+		return true
+	}
+	return 1<<uint(lastDomShift+daysIn(t.Year(), t.Month())-t.Day())&s.Dom > 0
+}
+
+// specer is implemented by schedules that can be written as a spec.
+type specer interface {
+	Spec() string
+}
+
+// Spec returns a spec that parses back to the schedule, e.g. "30 5 * * 1-5".
+// The seconds field is omitted if it is always 0; the spec then has the five
+// fields accepted by ParseStandard. If the schedule has its own location, the
+// spec is prefixed with CRON_TZ.
+func (s *SpecSchedule) Spec() string {
+	fields := []string{
+		formatField(s.Minute, minutes, false),
+		formatField(s.Hour, hours, false),
+		formatDom(s.Dom),
+		formatField(s.Month, months, false),
+		formatField(s.Dow, dow, true),
+	}
+	if s.Second&^starBit != 1<<seconds.min {
+		fields = append([]string{formatField(s.Second, seconds, false)}, fields...)
+	}
+	return specLocation(s.Location) + strings.Join(fields, " ")
+}
+
+// specLocation returns the CRON_TZ prefix of a spec in the given location, or
+// "" if it is local.
+func specLocation(loc *time.Location) string {
+	if loc == nil || loc == time.Local {
+		return ""
+	}
+	return "CRON_TZ=" + loc.String() + " "
+}
+
+// formatDom formats the day of month field, including days counted back from
+// the last one.
+func formatDom(bits uint64) string {
+	field := formatField(bits&(1<<lastDomShift-1)|bits&starBit, dom, true)
+	if bits&starBit > 0 {
+		return field
+	}
+	var items []string
+	if field != "" {
+		items = append(items, field)
+	}
+	for _, offset := range fieldValues(bits>>lastDomShift, lastDom) {
+		if offset == 0 {
+			items = append(items, "L")
+		} else {
+			items = append(items, fmt.Sprintf("L-%d", offset))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// formatField formats the values of a field as a list of values, ranges and
+// steps. For the day fields, "*" means more than every value, since it changes
+// how the two fields combine, so it is only written if it was parsed.
+func formatField(bits uint64, r bounds, day bool) string {
+	if bits&starBit > 0 || !day && isAll(bits, r) {
+		return "*"
+	}
+	values := fieldValues(bits, r)
+	if step, ok := everyStep(values, r); ok && !day {
+		return fmt.Sprintf("*/%d", step)
+	}
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, fmt.Sprintf("%d-%d", values[i], values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, fmt.Sprint(values[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(items, ",")
+}
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -110,6 +111,16 @@ func TestNext(t *testing.T) {
 		// Leap year
 		{"Mon Jul 9 23:35 2012", "0 0 0 29 Feb ?", "Mon Feb 29 00:00 2016"},
 
+		// Last day of the month, and days before it
+		{"Mon Jul 9 23:35 2012", "0 0 0 L * ?", "Tue Jul 31 00:00 2012"},
+		{"Wed Feb 1 00:00 2012", "0 0 0 L Feb ?", "Wed Feb 29 00:00 2012"},
+		{"Wed Feb 29 00:00 2012", "0 0 0 L Feb ?", "Thu Feb 28 00:00 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 L-2 * ?", "Sun Jul 29 00:00 2012"},
+		{"Sun Jul 29 00:00 2012", "0 0 0 L-2 * ?", "Wed Aug 29 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 1,L * ?", "Tue Jul 31 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 L-30 * ?", "Wed Aug 1 00:00 2012"},
+		{"2012-03-10T00:00:00-0500", "TZ=America/New_York 0 0 12 L-2 * ?", "2012-03-29T12:00:00-0400"},
+
 		// Daylight savings time 2am EST (-5) -> 3am EDT (-4)
 		{"2012-03-11T00:00:00-0500", "TZ=America/New_York 0 30 2 11 Mar ?", "2013-03-11T02:30:00-0400"},
 
@@ -298,3 +309,56 @@ func TestSlash0NoHang(t *testing.T) {
 		t.Error("expected an error on 0 increment")
 	}
 }
+
+func TestScheduleSpec(t *testing.T) {
+	p := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor | ISO8601)
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"30 5 * * 1-5", "30 5 * * 1-5"},
+		{"*/15 * * * *", "*/15 * * * *"},
+		{"0,10,20,21,22 9-17/2 1,15 jan-mar *", "0,10,20-22 9,11,13,15,17 1,15 1-3 *"},
+		{"0 0 1-31 * mon", "0 0 1-31 * 1"},
+		{"0 0 1,L-2 * *", "0 0 1,L-2 * *"},
+		{"0 0 L * *", "0 0 L * *"},
+		{"*/10 * * * * *", "*/10 * * * * *"},
+		{"CRON_TZ=America/New_York 0 6 * * ?", "CRON_TZ=America/New_York 0 6 * * *"},
+		{"@daily", "0 0 * * *"},
+		{"@every 1h30m", "@every 1h30m0s"},
+		{"@every 1m immediately", "@every 1m0s immediately"},
+		{"@every 15m from 2026-01-01T00:00:00Z", "@every 15m0s from 2026-01-01T00:00:00Z"},
+		{"@every 5m between 09:00 and 17:00 on mon-fri", "@every 5m0s between 09:00 and 17:00 on 1-5"},
+		{"R5/2024-01-01T00:00:00Z/PT1H", "R5/2024-01-01T00:00:00Z/PT1H"},
+	}
+	for _, test := range tests {
+		schedule, err := p.Parse(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+		actual := schedule.(specer).Spec()
+		if actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.spec, test.expected, actual)
+			continue
+		}
+		reparsed, err := p.Parse(actual)
+		if err != nil {
+			t.Errorf("%s: %v", actual, err)
+		} else if !reflect.DeepEqual(reparsed, schedule) {
+			t.Errorf("%s: expected %+v, got %+v", actual, schedule, reparsed)
+		}
+	}
+}
+
+func TestEntryScheduleSpec(t *testing.T) {
+	c := New()
+	added, _ := c.AddFunc("@hourly", func() {})
+	scheduled := c.Schedule(Every(time.Minute), FuncJob(func() {}))
+	custom := c.Schedule(fixedSchedule{}, FuncJob(func() {}))
+	for id, expected := range map[EntryID]string{added: "@hourly", scheduled: "@every 1m0s", custom: ""} {
+		if spec := c.Entry(id).ScheduleSpec(); spec != expected {
+			t.Errorf("entry %d: expected %q, got %q", id, expected, spec)
+		}
+	}
+}
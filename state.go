@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// StateVersion is the version of the persisted state format written by this
+// version of the package. It is incremented whenever the format of any kind of
+// persisted state changes, and a Migration is provided for the change.
+const StateVersion = 1
+
+// Kinds of persisted state written by this package.
+const (
+	StateKindOnce    = "once"
+	StateKindJournal = "journal"
+	// StateKindEntry is an entry saved by RedisStore or SQLStore.
+	StateKindEntry = "entry"
+	// StateKindEntries is the export of the entries of a Cron by ExportJSON.
+	StateKindEntries = "entries"
+)
+
+// stateEnvelope wraps persisted state with its kind and format version.
+type stateEnvelope struct {
+	Version int             `json:"version"`
+	Kind    string          `json:"kind"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Migration upgrades persisted state of some kind from one format version to
+// the next. See RegisterMigration.
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// StateVersionError is returned when loading state written by a newer version
+// of the package, which this version cannot interpret.
+type StateVersionError struct {
+	Kind    string
+	Version int
+}
+
+func (e *StateVersionError) Error() string {
+	return fmt.Sprintf("cron: %s state has version %d, newer than supported version %d",
+		e.Kind, e.Version, StateVersion)
+}
+
+// migrationKey identifies the migration of a kind of state from a version.
+type migrationKey struct {
+	kind string
+	from int
+}
+
+var (
+	migrationsMu sync.Mutex
+	// Version 0 is the unversioned format, whose data is the same as that of
+	// version 1 without the envelope.
+	migrations = map[migrationKey]Migration{
+		{StateKindOnce, 0}:    identityMigration,
+		{StateKindJournal, 0}: identityMigration,
+		{StateKindEntry, 0}:   identityMigration,
+		{StateKindEntries, 0}: identityMigration,
+	}
+)
+
+func identityMigration(data json.RawMessage) (json.RawMessage, error) { return data, nil }
+
+// RegisterMigration registers the migration of the given kind of state from
+// version from to version from+1. Store implementations that persist their own
+// kinds of state with MarshalState use it to upgrade state written by their
+// older versions. Registering a migration for the same kind and version again
+// replaces it.
+func RegisterMigration(kind string, from int, m Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migrations[migrationKey{kind, from}] = m
+}
+
+// MarshalState encodes v as JSON, wrapped with the given kind and the current
+// StateVersion.
+func MarshalState(kind string, v interface{}) ([]byte, error) {
+	return MarshalStateCodec(JSONCodec, kind, v)
+}
+
+// MarshalStateCodec is like MarshalState, but encodes v and its wrapping with
+// the given codec, which must be registered (see RegisterCodec) to unmarshal
+// the state. A nil codec is JSONCodec.
+func MarshalStateCodec(codec Codec, kind string, v interface{}) ([]byte, error) {
+	if codec == nil {
+		codec = JSONCodec
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if codec.Name() == JSONCodec.Name() {
+		return json.Marshal(stateEnvelope{Version: StateVersion, Kind: kind, Data: data})
+	}
+	return encodeWith(codec, codecEnvelope{Version: StateVersion, Kind: kind, Data: data})
+}
+
+// codecEnvelope is the stateEnvelope of state encoded with a codec other than
+// JSON, whose data need not be valid JSON.
+type codecEnvelope struct {
+	Version int
+	Kind    string
+	Data    []byte
+}
+
+// UnmarshalState decodes state written by MarshalState or MarshalStateCodec
+// into v, applying the registered migrations if it was written with an older
+// StateVersion. Data without an envelope is treated as version 0 JSON. It
+// returns a *StateVersionError if the state was written by a newer version.
+//
+// Migrations receive the data as encoded by its codec, i.e. JSON unless it was
+// written with MarshalStateCodec and another codec.
+func UnmarshalState(kind string, data []byte, v interface{}) error {
+	codec, data, err := codecOf(data)
+	if err != nil {
+		return err
+	}
+	var env codecEnvelope
+	if codec.Name() == JSONCodec.Name() {
+		var jenv stateEnvelope
+		if err := json.Unmarshal(data, &jenv); err != nil || jenv.Kind == "" || jenv.Data == nil {
+			jenv = stateEnvelope{Version: 0, Kind: kind, Data: bytes.TrimSpace(data)}
+		}
+		env = codecEnvelope{Version: jenv.Version, Kind: jenv.Kind, Data: jenv.Data}
+	} else if err := codec.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("cron: decoding %s state: %v", kind, err)
+	}
+	if env.Kind != kind {
+		return fmt.Errorf("cron: expected %s state, found %s state", kind, env.Kind)
+	}
+	if env.Version > StateVersion {
+		return &StateVersionError{Kind: kind, Version: env.Version}
+	}
+	for env.Version < StateVersion {
+		migrationsMu.Lock()
+		m := migrations[migrationKey{kind, env.Version}]
+		migrationsMu.Unlock()
+		if m == nil {
+			return fmt.Errorf("cron: no migration for %s state from version %d", kind, env.Version)
+		}
+		migrated, err := m(env.Data)
+		if err != nil {
+			return fmt.Errorf("cron: migrating %s state from version %d: %v", kind, env.Version, err)
+		}
+		env.Data = migrated
+		env.Version++
+	}
+	return codec.Unmarshal(env.Data, v)
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	data, err := MarshalState("test", map[string]int{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version":1`) {
+		t.Errorf("expected the version to be recorded, got %s", data)
+	}
+	var v map[string]int
+	if err := UnmarshalState("test", data, &v); err != nil || v["a"] != 1 {
+		t.Errorf("unexpected result %v, %v", v, err)
+	}
+	if err := UnmarshalState("other", data, &v); err == nil {
+		t.Error("expected an error for state of another kind")
+	}
+}
+
+func TestStateNewerVersion(t *testing.T) {
+	data := []byte(`{"version":99,"kind":"once","data":{}}`)
+	var v map[string]time.Time
+	err := UnmarshalState(StateKindOnce, data, &v)
+	if verr, ok := err.(*StateVersionError); !ok || verr.Version != 99 {
+		t.Errorf("expected a *StateVersionError, got %v", err)
+	}
+}
+
+func TestStateMigration(t *testing.T) {
+	// Version 0 of the "counter" kind stored a bare number; version 1 wraps it.
+	RegisterMigration("counter", 0, func(data json.RawMessage) (json.RawMessage, error) {
+		return json.RawMessage(`{"count":` + string(data) + `}`), nil
+	})
+	var v struct{ Count int }
+	if err := UnmarshalState("counter", []byte(" 42\n"), &v); err != nil || v.Count != 42 {
+		t.Errorf("unexpected result %+v, %v", v, err)
+	}
+	if err := UnmarshalState("unknown", []byte("42"), &v); err == nil {
+		t.Error("expected an error for unversioned state without a migration")
+	}
+}
+
+func TestFileOnceStoreLegacyFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "once.json")
+	daily := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	legacy, _ := json.Marshal(map[string]time.Time{"report": daily})
+	if err := ioutil.WriteFile(path, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileOnceStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed, _ := store.Claim("report", daily); claimed {
+		t.Error("expected the claim from the legacy file to be kept")
+	}
+	if claimed, err := store.Claim("report", daily.AddDate(0, 0, 1)); !claimed || err != nil {
+		t.Fatalf("expected a new claim, got %v, %v", claimed, err)
+	}
+	data, _ := ioutil.ReadFile(path)
+	if !strings.Contains(string(data), `"kind":"once"`) {
+		t.Errorf("expected the file to be upgraded, got %s", data)
+	}
+}
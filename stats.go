@@ -0,0 +1,185 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// EntryStats holds cumulative counters about the runs of an entry since it was
+// added or its statistics were last reset. Its JSON encoding is stable, so it
+// may be scraped by monitoring agents.
+type EntryStats struct {
+	// Runs is the number of completed runs.
+	Runs uint64 `json:"runs"`
+	// Failures is the number of runs that failed.
+	Failures uint64 `json:"failures"`
+	// Skipped is the number of activations that did not result in a run.
+	Skipped uint64 `json:"skipped"`
+	// Missed is the number of skipped activations that were dropped because
+	// they could not start in time. See MaxQueueDelay.
+	Missed uint64 `json:"missed"`
+	// SkipReasons counts the skipped activations, including missed ones, by
+	// reason. It is nil if none were skipped.
+	SkipReasons map[SkipReason]uint64 `json:"skip_reasons,omitempty"`
+	// Unacked is the number of times a run was not acknowledged in time. See
+	// WithManualAck.
+	Unacked uint64 `json:"unacked,omitempty"`
+	// TotalDuration is the cumulative duration of all completed runs.
+	TotalDuration time.Duration `json:"total_duration_ns"`
+	// LastStart is the start time of the latest completed run.
+	LastStart time.Time `json:"last_start"`
+	// LastDuration is the duration of the latest completed run.
+	LastDuration time.Duration `json:"last_duration_ns"`
+	// LastFailed is true if the latest completed run failed.
+	LastFailed bool `json:"last_failed"`
+	// Running is the number of runs in progress, including runs abandoned by
+	// WithHardDeadline that have not returned yet. A count that keeps growing
+	// points to a job whose runs never exit.
+	Running int64 `json:"running"`
+	// AllocSamples is the number of runs whose allocations were sampled. See
+	// WithAllocSampling.
+	AllocSamples uint64 `json:"alloc_samples"`
+	// AllocBytes is the number of heap bytes allocated during the sampled
+	// runs, by the whole process.
+	AllocBytes uint64 `json:"alloc_bytes"`
+	// Since is the time at which counting started.
+	Since time.Time `json:"since"`
+}
+
+// statsRegistry holds the statistics of every entry of a Cron.
+type statsRegistry struct {
+	mu    sync.Mutex
+	stats map[EntryID]*EntryStats
+}
+
+func newStatsRegistry() *statsRegistry {
+	return &statsRegistry{stats: make(map[EntryID]*EntryStats)}
+}
+
+// add starts counting for the given entry.
+func (r *statsRegistry) add(id EntryID, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats[id] = &EntryStats{Since: now}
+}
+
+// remove stops counting for the given entry.
+func (r *statsRegistry) remove(id EntryID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.stats, id)
+}
+
+// reset zeroes the counters of the given entry. It returns false if the entry
+// is unknown.
+func (r *statsRegistry) reset(id EntryID, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.stats[id]
+	if !ok {
+		return false
+	}
+	// Runs in progress are not counters, and will still finish.
+	r.stats[id] = &EntryStats{Since: now, Running: old.Running}
+	return true
+}
+
+// update applies fn to the statistics of the given entry, if it is known.
+func (r *statsRegistry) update(id EntryID, fn func(*EntryStats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.stats[id]; ok {
+		fn(s)
+	}
+}
+
+// recordRun counts a completed run of the given entry.
+func (r *statsRegistry) recordRun(id EntryID, start time.Time, duration time.Duration, failed bool) {
+	r.update(id, func(s *EntryStats) {
+		s.Runs++
+		if failed {
+			s.Failures++
+		}
+		s.TotalDuration += duration
+		s.LastStart = start
+		s.LastDuration = duration
+		s.LastFailed = failed
+	})
+}
+
+// recordStart counts a run of the given entry as in progress, until the
+// returned func is called.
+func (r *statsRegistry) recordStart(id EntryID) func() {
+	r.update(id, func(s *EntryStats) { s.Running++ })
+	return func() {
+		r.update(id, func(s *EntryStats) { s.Running-- })
+	}
+}
+
+// recordAlloc counts the bytes allocated during a sampled run.
+func (r *statsRegistry) recordAlloc(id EntryID, bytes uint64) {
+	r.update(id, func(s *EntryStats) {
+		s.AllocSamples++
+		s.AllocBytes += bytes
+	})
+}
+
+// recordSkip counts an activation of the given entry that was not run.
+func (r *statsRegistry) recordSkip(id EntryID, reason SkipReason) {
+	r.update(id, func(s *EntryStats) {
+		s.Skipped++
+		s.countSkip(reason)
+	})
+}
+
+// countSkip counts a skip for the given reason.
+func (s *EntryStats) countSkip(reason SkipReason) {
+	if s.SkipReasons == nil {
+		s.SkipReasons = make(map[SkipReason]uint64)
+	}
+	s.SkipReasons[reason]++
+}
+
+// recordMiss counts an activation of the given entry that was dropped because
+// it could not start in time.
+func (r *statsRegistry) recordMiss(id EntryID, reason SkipReason) {
+	r.update(id, func(s *EntryStats) {
+		s.Skipped++
+		s.Missed++
+		s.countSkip(reason)
+	})
+}
+
+// copy returns a copy of the statistics that shares no map with them.
+func (s *EntryStats) copy() EntryStats {
+	c := *s
+	if s.SkipReasons != nil {
+		c.SkipReasons = make(map[SkipReason]uint64, len(s.SkipReasons))
+		for reason, n := range s.SkipReasons {
+			c.SkipReasons[reason] = n
+		}
+	}
+	return c
+}
+
+// snapshot returns a copy of all statistics.
+func (r *statsRegistry) snapshot() map[EntryID]EntryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot := make(map[EntryID]EntryStats, len(r.stats))
+	for id, s := range r.stats {
+		snapshot[id] = s.copy()
+	}
+	return snapshot
+}
+
+// StatsSnapshot returns the statistics of every entry, keyed by entry ID.
+func (c *Cron) StatsSnapshot() map[EntryID]EntryStats {
+	return c.stats.snapshot()
+}
+
+// ResetStats zeroes the statistics of the given entry, e.g. after
+// maintenance. It returns false if no such entry exists.
+func (c *Cron) ResetStats(id EntryID) bool {
+	return c.stats.reset(id, c.now())
+}
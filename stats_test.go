@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsSnapshot(t *testing.T) {
+	cron := New(WithParser(secondParser), WithChain(Recover(DiscardLogger)))
+	ok := cron.Schedule(Every(time.Second), FuncJob(func() {}))
+	failing := cron.Schedule(Every(time.Second), FuncJob(func() { panic("fail") }))
+	idle := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	cron.Start()
+	time.Sleep(OneSecond + 100*time.Millisecond)
+	<-cron.Stop().Done()
+
+	stats := cron.StatsSnapshot()
+	if len(stats) != 3 {
+		t.Fatalf("expected stats for 3 entries, got %v", stats)
+	}
+	if s := stats[ok]; s.Runs == 0 || s.Failures != 0 || s.LastStart.IsZero() {
+		t.Errorf("unexpected stats for successful entry: %+v", s)
+	}
+	if s := stats[failing]; s.Runs == 0 || s.Failures != s.Runs {
+		t.Errorf("unexpected stats for failing entry: %+v", s)
+	}
+	if s := stats[idle]; s.Runs != 0 || s.Since.IsZero() {
+		t.Errorf("unexpected stats for idle entry: %+v", s)
+	}
+
+	if !cron.ResetStats(failing) {
+		t.Fatal("expected ResetStats to find the entry")
+	}
+	if s := cron.StatsSnapshot()[failing]; s.Runs != 0 || s.Failures != 0 {
+		t.Errorf("expected reset stats, got %+v", s)
+	}
+	if cron.ResetStats(100) {
+		t.Error("expected ResetStats to report a missing entry")
+	}
+
+	cron.Remove(idle)
+	if _, ok := cron.StatsSnapshot()[idle]; ok {
+		t.Error("expected stats of a removed entry to be dropped")
+	}
+}
+
+func TestEntryStatsJSON(t *testing.T) {
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := map[EntryID]EntryStats{
+		2: {Runs: 3, Failures: 1, TotalDuration: 3 * time.Second, LastDuration: time.Second, Since: since},
+		1: {Since: since},
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"1":{"runs":0,"failures":0,"skipped":0,"missed":0,"total_duration_ns":0,` +
+		`"last_start":"0001-01-01T00:00:00Z","last_duration_ns":0,"last_failed":false,"running":0,"alloc_samples":0,"alloc_bytes":0,"since":"2020-01-01T00:00:00Z"},` +
+		`"2":{"runs":3,"failures":1,"skipped":0,"missed":0,"total_duration_ns":3000000000,` +
+		`"last_start":"0001-01-01T00:00:00Z","last_duration_ns":1000000000,"last_failed":false,"running":0,"alloc_samples":0,"alloc_bytes":0,"since":"2020-01-01T00:00:00Z"}}`
+	if string(data) != expected {
+		t.Errorf("unexpected encoding:\n%s\nexpected:\n%s", data, expected)
+	}
+
+	var decoded map[EntryID]EntryStats
+	if err := json.NewDecoder(strings.NewReader(string(data))).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[2].TotalDuration != 3*time.Second {
+		t.Errorf("expected round trip, got %+v", decoded)
+	}
+}
@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Status is the state of a Cron at a point in time. See Cron.Status.
+type Status struct {
+	// Time is the time the status was taken.
+	Time time.Time
+	// Running is true if the scheduler is running.
+	Running bool
+	// Entries is the number of entries, excluding archived ones.
+	Entries int
+	// Executing lists the runs in progress, earliest started first.
+	Executing []ExecutingJob
+	// Overdue lists the entries whose next activation has passed without
+	// being run, earliest due first. A running scheduler has none unless it
+	// is falling behind; a stopped one, those due since it stopped.
+	Overdue []Entry
+}
+
+// ExecutingJob is a run in progress.
+type ExecutingJob struct {
+	// Entry is the ID of the entry being run.
+	Entry EntryID
+	// Name is the name under which the entry is reported, as in StatusReport.
+	Name string
+	// Scheduled is the time the run was scheduled for, or the zero time for
+	// runs started otherwise, e.g. by RunNow.
+	Scheduled time.Time
+	// Start is the time the run started.
+	Start time.Time
+}
+
+// Status returns the state of the scheduler: whether it is running, its number
+// of entries, the runs in progress with their start times and the entries
+// that are overdue. Unlike StatusReport, it is meant to be inspected by
+// programs, e.g. by health checks.
+func (c *Cron) Status() Status {
+	c.runningMu.Lock()
+	running := c.running
+	c.runningMu.Unlock()
+
+	entries := c.Entries()
+	st := Status{Time: c.now(), Running: running, Entries: len(entries)}
+	names := make(map[EntryID]string, len(entries))
+	for _, e := range entries {
+		names[e.ID] = entryName(e)
+		if !e.Paused && !e.Next.IsZero() && e.Next.Before(st.Time) {
+			st.Overdue = append(st.Overdue, e)
+		}
+	}
+	sort.Slice(st.Overdue, func(i, j int) bool { return st.Overdue[i].Next.Before(st.Overdue[j].Next) })
+	st.Executing = c.inflight.snapshot()
+	for i := range st.Executing {
+		st.Executing[i].Name = names[st.Executing[i].Entry]
+	}
+	return st
+}
+
+// inflightRuns tracks the runs in progress. Its zero value tracks none.
+type inflightRuns struct {
+	mu     sync.Mutex
+	nextID uint64
+	runs   map[uint64]ExecutingJob
+}
+
+// begin records the run as in progress, until the returned func is called.
+func (r *inflightRuns) begin(run ExecutingJob) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[uint64]ExecutingJob)
+	}
+	r.nextID++
+	id := r.nextID
+	r.runs[id] = run
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.runs, id)
+	}
+}
+
+// snapshot returns the runs in progress, earliest started first.
+func (r *inflightRuns) snapshot() []ExecutingJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	runs := make([]ExecutingJob, 0, len(r.runs))
+	for _, run := range r.runs {
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Start.Before(runs[j].Start) })
+	return runs
+}
+
+// statusTimeFormat is the format of times in a StatusReport.
+const statusTimeFormat = "2006-01-02 15:04:05 MST"
+
+// StatusReport writes a human-readable table of the entries, excluding
+// archived ones, with their name (the description or job name), spec, time
+// zone, last run, its duration and outcome, next run, and whether they are
+// paused. It is meant for operators, e.g. from a debug endpoint or a SIGUSR1
+// handler during an incident; its format may change.
+func (c *Cron) StatusReport(w io.Writer) error {
+	stats := c.StatsSnapshot()
+	entries := c.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSPEC\tTZ\tLAST RUN\tDURATION\tOUTCOME\tNEXT RUN\tPAUSED")
+	for _, e := range entries {
+		s := stats[e.ID]
+		lastRun, duration, outcome := "-", "-", "-"
+		if s.Runs > 0 {
+			lastRun = s.LastStart.Format(statusTimeFormat)
+			duration = s.LastDuration.String()
+			outcome = "ok"
+			if s.LastFailed {
+				outcome = "failed"
+			}
+		}
+		paused := "no"
+		if e.Paused {
+			paused = "yes"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.ID,
+			orDash(entryName(e)), orDash(e.Spec), c.scheduleLocation(e.Schedule),
+			lastRun, duration, outcome, formatStatusTime(e.Next), paused)
+	}
+	return tw.Flush()
+}
+
+// entryName returns the name under which an entry is reported.
+func entryName(e Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	if e.Description != "" {
+		return e.Description
+	}
+	return e.JobName
+}
+
+// scheduleLocation returns the time zone in which the schedule is evaluated.
+// Specs without a time zone of their own are evaluated in the cron's.
+func (c *Cron) scheduleLocation(s Schedule) *time.Location {
+	if spec, ok := s.(*SpecSchedule); ok && spec.Location != nil && spec.Location != time.Local {
+		return spec.Location
+	}
+	return c.location
+}
+
+// formatStatusTime formats t for a StatusReport, or returns "-" if it is zero.
+func formatStatusTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(statusTimeFormat)
+}
+
+// orDash returns s, or "-" if it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatusReport(t *testing.T) {
+	cron := New(WithLocation(time.UTC), WithChain())
+	ok, _ := cron.AddFunc("CRON_TZ=Asia/Tokyo 30 4 * * *", func() {}, WithDescription("nightly export"))
+	failing, _ := cron.AddFunc("@hourly", func() { panic("boom") }, WithJobName("sync"))
+	paused, _ := cron.AddFunc("@daily", func() {})
+	cron.PauseMany([]EntryID{paused})
+	for _, id := range []EntryID{ok, failing} {
+		func() {
+			defer func() { recover() }()
+			cron.store.Get(id).WrappedJob.Run()
+		}()
+	}
+
+	var buf bytes.Buffer
+	if err := cron.StatusReport(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a header and 3 lines, got:\n%s", buf.String())
+	}
+	expected := [][]string{
+		{"ID", "NAME", "SPEC", "TZ", "LAST RUN", "DURATION", "OUTCOME", "NEXT RUN", "PAUSED"},
+		{"1", "nightly export", "CRON_TZ=Asia/Tokyo 30 4 * * *", "Asia/Tokyo", " ok ", "no"},
+		{"2", "sync", "@hourly", "UTC", " failed ", "no"},
+		{"3", "-", "@daily", "UTC", " - ", "yes"},
+	}
+	for i, fields := range expected {
+		for _, f := range fields {
+			if !strings.Contains(lines[i], f) {
+				t.Errorf("expected line %d to contain %q, got %q", i, f, lines[i])
+			}
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		now = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	release := make(chan struct{})
+	cron := New(WithNowFunc(clock), WithChain())
+	blocking, _ := cron.AddFunc("@daily", func() { <-release }, WithName("blocking"))
+	hourly, _ := cron.AddFunc("@hourly", func() {})
+	cron.Start()
+
+	if err := cron.RunNow(blocking); err != nil {
+		t.Fatal(err)
+	}
+	var st Status
+	for i := 0; i < 100; i++ {
+		if st = cron.Status(); len(st.Executing) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !st.Running || st.Entries != 2 || len(st.Overdue) != 0 {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if len(st.Executing) != 1 || st.Executing[0].Entry != blocking ||
+		st.Executing[0].Name != "blocking" || !st.Executing[0].Start.Equal(clock()) {
+		t.Errorf("expected the blocking run to be executing, got %+v", st.Executing)
+	}
+	close(release)
+	<-cron.Stop().Done()
+
+	mu.Lock()
+	now = now.Add(2 * time.Hour)
+	mu.Unlock()
+	st = cron.Status()
+	if st.Running || len(st.Executing) != 0 {
+		t.Errorf("expected a stopped scheduler with no runs, got %+v", st)
+	}
+	if len(st.Overdue) != 1 || st.Overdue[0].ID != hourly {
+		t.Errorf("expected the hourly entry to be overdue, got %v", st.Overdue)
+	}
+}
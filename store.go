@@ -0,0 +1,254 @@
+package cron
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store holds the entries of a Cron.
+//
+// The scheduler owns the entries it hands to a Store: it modifies an entry's
+// fields (e.g. Next and Prev) in place and then calls Update so that the store
+// may re-index or persist it. No method is required to return entries in any
+// particular order; the scheduler sorts and prioritizes them itself.
+//
+// Implementations must be safe for concurrent use. The storetest package
+// provides a conformance test suite for them.
+type Store interface {
+	// Add inserts a new entry into the store.
+	Add(e *Entry)
+
+	// Update records changes made to an entry already in the store.
+	Update(e *Entry)
+
+	// Remove deletes the entry with the given ID. It returns false if no such
+	// entry exists.
+	Remove(id EntryID) bool
+
+	// Get returns the entry with the given ID, or nil if it does not exist.
+	Get(id EntryID) *Entry
+
+	// Entries returns all entries in the store, in any order. The returned
+	// slice is not affected by later modifications to the store.
+	Entries() []*Entry
+
+	// Len returns the number of entries in the store.
+	Len() int
+
+	// Next returns the earliest non-zero Next time of all entries, or the zero
+	// time if no entry is scheduled.
+	Next() time.Time
+
+	// Ready returns all entries whose Next time is non-zero and not after the
+	// given time, in any order.
+	Ready(now time.Time) []*Entry
+}
+
+// ReadyLimiter is implemented by stores that can return a limited number of
+// the entries due at a given time without collecting all of them, such as
+// HeapStore. WithMaxDispatchPerWake uses it when many entries are due at once.
+type ReadyLimiter interface {
+	// ReadyLimit returns at most n of the entries Ready would return, the
+	// earliest first.
+	ReadyLimit(now time.Time, n int) []*Entry
+}
+
+// EntryRanger is implemented by stores that can return the entries following
+// a given ID, in order of ID, without copying all of them, such as HeapStore
+// and InMemoryStore. EachEntry uses it to visit the entries a page at a time.
+type EntryRanger interface {
+	// EntriesAfter returns at most n of the entries whose ID is greater than
+	// after, in increasing order of ID.
+	EntriesAfter(after EntryID, n int) []*Entry
+}
+
+// idHeap is a max-heap of entries by ID, which entriesAfter uses to keep the n
+// lowest IDs it has seen.
+type idHeap []*Entry
+
+func (h idHeap) Len() int            { return len(h) }
+func (h idHeap) Less(i, j int) bool  { return h[i].ID > h[j].ID }
+func (h idHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *idHeap) Push(x interface{}) { *h = append(*h, x.(*Entry)) }
+
+func (h *idHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// entriesAfter returns at most n of the entries visited by each whose ID is
+// greater than after, in increasing order of ID. It holds no more than n
+// entries at a time.
+func entriesAfter(each func(visit func(*Entry)), after EntryID, n int) []*Entry {
+	if n <= 0 {
+		return nil
+	}
+	h := make(idHeap, 0, n)
+	each(func(e *Entry) {
+		switch {
+		case e.ID <= after:
+		case len(h) < n:
+			heap.Push(&h, e)
+		case e.ID < h[0].ID:
+			h[0] = e
+			heap.Fix(&h, 0)
+		}
+	})
+	sort.Slice(h, func(i, j int) bool { return h[i].ID < h[j].ID })
+	return h
+}
+
+// InMemoryStore is a Store that keeps entries in an unordered slice, scanning
+// all of them in Next and Ready. It suits small numbers of entries; the
+// default Store used by Cron is a HeapStore.
+type InMemoryStore struct {
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+// NewInMemoryStore returns an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{}
+}
+
+// Add inserts a new entry into the store.
+func (s *InMemoryStore) Add(e *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+}
+
+// Update records changes made to an entry. Entries are held by reference, so
+// there is nothing to do.
+func (s *InMemoryStore) Update(e *Entry) {}
+
+// Remove deletes the entry with the given ID.
+func (s *InMemoryStore) Remove(id EntryID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.entries {
+		if e.ID == id {
+			s.entries = append(s.entries[:i:i], s.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the entry with the given ID, or nil.
+func (s *InMemoryStore) Get(id EntryID) *Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Entries returns all entries in the store.
+func (s *InMemoryStore) Entries() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Entry(nil), s.entries...)
+}
+
+// EntriesAfter returns at most n of the entries whose ID is greater than
+// after, in increasing order of ID.
+func (s *InMemoryStore) EntriesAfter(after EntryID, n int) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return entriesAfter(func(visit func(*Entry)) {
+		for _, e := range s.entries {
+			visit(e)
+		}
+	}, after, n)
+}
+
+// Len returns the number of entries in the store.
+func (s *InMemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// Next returns the earliest non-zero Next time of all entries.
+func (s *InMemoryStore) Next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, e := range s.entries {
+		if e.Next.IsZero() {
+			continue
+		}
+		if next.IsZero() || e.Next.Before(next) {
+			next = e.Next
+		}
+	}
+	return next
+}
+
+// Ready returns all entries that are due at the given time.
+func (s *InMemoryStore) Ready(now time.Time) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ready []*Entry
+	for _, e := range s.entries {
+		if !e.Next.IsZero() && !e.Next.After(now) {
+			ready = append(ready, e)
+		}
+	}
+	return ready
+}
+
+// savedEntry is the form in which RedisStore and SQLStore save an entry,
+// wrapped as StateKindEntry state.
+type savedEntry struct {
+	Spec       string    `json:"spec"`
+	JobName    string    `json:"job_name"`
+	Next       time.Time `json:"next"`
+	Prev       time.Time `json:"prev"`
+	Payload    []byte    `json:"payload,omitempty"`
+	PayloadKey string    `json:"payload_key,omitempty"`
+}
+
+// saveEntry returns the saved form of the entry, encoded with the codec.
+func saveEntry(codec Codec, e *Entry) ([]byte, error) {
+	return MarshalStateCodec(codec, StateKindEntry,
+		savedEntry{e.Spec, e.JobName, e.Next, e.Prev, e.Payload, e.PayloadKey})
+}
+
+// loadEntry decodes an entry saved by saveEntry.
+func loadEntry(data []byte) (savedEntry, error) {
+	var saved savedEntry
+	err := UnmarshalState(StateKindEntry, data, &saved)
+	return saved, err
+}
+
+// resolveEntry rebuilds an entry saved by a persistent store from its spec and
+// job name.
+func resolveEntry(id EntryID, spec, jobName string, parser ScheduleParser, jobs map[string]Job) (*Entry, error) {
+	job, ok := jobs[jobName]
+	if !ok {
+		return nil, fmt.Errorf("cron: entry %d: unknown job %q", id, jobName)
+	}
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("cron: entry %d: %v", id, err)
+	}
+	e := &Entry{
+		ID:       id,
+		Schedule: schedule,
+		Job:      job,
+		Spec:     spec,
+		JobName:  jobName,
+	}
+	bindHashKey(e)
+	return e, nil
+}
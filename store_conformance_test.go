@@ -0,0 +1,298 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/robfig/cron/v3/storetest"
+)
+
+var storetestJobs = map[string]cron.Job{storetest.JobName: cron.FuncJob(func() {})}
+
+// conformanceCodecs are the codecs persistent stores are tested with.
+var conformanceCodecs = []cron.Codec{cron.JSONCodec, cron.GobCodec}
+
+func TestSQLStoreConformance(t *testing.T) {
+	for _, codec := range conformanceCodecs {
+		codec := codec
+		t.Run(codec.Name(), func(t *testing.T) {
+			storetest.TestStore(t, func() cron.Store {
+				store, _ := newSQLStore(t, codec)
+				return store
+			})
+			storetest.TestPersistentStore(t, func() (cron.Store, func() cron.Store) {
+				return newSQLStore(t, codec)
+			})
+		})
+	}
+
+	// The state of the entries is encoded with the codec of the store.
+	store, _ := newSQLStore(t, cron.GobCodec)
+	store.Add(&cron.Entry{ID: 1, Spec: "@every 1m", JobName: storetest.JobName})
+	db := fakeSQL.dbs[fmt.Sprint(atomic.LoadInt64(&fakeDBs))]
+	if state := db.rows[int64(1)]["state"].([]byte); !strings.HasPrefix(string(state), "\x00gob\x00") {
+		t.Errorf("expected a gob-encoded entry, got %q", state)
+	}
+}
+
+// newSQLStore returns a SQLStore using the codec, backed by a new fakesql
+// database, and a function opening another one on the same database.
+func newSQLStore(t *testing.T, codec cron.Codec) (cron.Store, func() cron.Store) {
+	db, err := sql.Open("fakesql", fmt.Sprint(atomic.AddInt64(&fakeDBs, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	open := func() cron.Store {
+		store, err := cron.NewSQLStore(db, "cron_entries", nil, storetestJobs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.Codec = codec
+		return store
+	}
+	return open(), open
+}
+
+func TestRedisStoreConformance(t *testing.T) {
+	for _, codec := range conformanceCodecs {
+		codec := codec
+		t.Run(codec.Name(), func(t *testing.T) {
+			storetest.TestStore(t, func() cron.Store {
+				store, _ := newRedisStore(t, codec)
+				return store
+			})
+			storetest.TestPersistentStore(t, func() (cron.Store, func() cron.Store) {
+				return newRedisStore(t, codec)
+			})
+		})
+	}
+}
+
+// newRedisStore returns a RedisStore using the codec, backed by a new fake
+// Redis, and a function opening another one on the same Redis.
+func newRedisStore(t *testing.T, codec cron.Codec) (cron.Store, func() cron.Store) {
+	client := cron.NewFakeRedis()
+	open := func() cron.Store {
+		store, err := cron.NewRedisStore(client, "cron", nil, storetestJobs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.Codec = codec
+		return store
+	}
+	return open(), open
+}
+
+// fakeDBs numbers the databases of the fakesql driver, so that each store of
+// the conformance tests starts empty.
+var fakeDBs int64
+
+// fakeSQL is the fakesql driver.
+var fakeSQL = &fakeDriver{dbs: make(map[string]*fakeTable)}
+
+func init() {
+	sql.Register("fakesql", fakeSQL)
+}
+
+// fakeDriver is a database/sql driver serving the queries of SQLStore and
+// SQLOnceStore from memory. Each data source name is a database with a single
+// table, whose first column is its primary key.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeTable
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	table := d.dbs[name]
+	if table == nil {
+		table = &fakeTable{rows: make(map[driver.Value]fakeRow)}
+		d.dbs[name] = table
+	}
+	return fakeConn{table}, nil
+}
+
+// fakeTable holds the rows of a table by primary key.
+type fakeTable struct {
+	mu   sync.Mutex
+	rows map[driver.Value]fakeRow
+}
+
+// fakeRow holds the values of a row by column name.
+type fakeRow map[string]driver.Value
+
+// match reports whether the row satisfies the conditions of a WHERE clause,
+// "column = ?" or "column < ?" joined by AND, given their arguments.
+func (r fakeRow) match(where string, args []driver.Value) bool {
+	for i, cond := range strings.Split(where, " AND ") {
+		f := strings.Fields(cond)
+		switch f[1] {
+		case "=":
+			if r[f[0]] != args[i] {
+				return false
+			}
+		case "<":
+			if r[f[0]].(int64) >= args[i].(int64) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var (
+	insertQuery = regexp.MustCompile(`^INSERT INTO \w+ \((.*)\) VALUES`)
+	// insertIfQuery inserts a row unless one matches the WHERE clause.
+	insertIfQuery = regexp.MustCompile(
+		`^INSERT INTO \w+ \((.*)\) SELECT .* WHERE NOT EXISTS \(SELECT 1 FROM \w+ WHERE (.*)\)$`)
+	updateQuery = regexp.MustCompile(`^UPDATE \w+ SET (.*) WHERE (.*)$`)
+	deleteQuery = regexp.MustCompile(`^DELETE FROM \w+ WHERE (.*)$`)
+	selectQuery = regexp.MustCompile(`^SELECT (.*) FROM \w+$`)
+)
+
+// columns returns the column names of a list such as "a, b" or "a = ?, b = ?".
+func columns(list string) []string {
+	var names []string
+	for _, c := range strings.Split(list, ",") {
+		names = append(names, strings.Fields(c)[0])
+	}
+	return names
+}
+
+type fakeConn struct {
+	table *fakeTable
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.table, query}, nil
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakesql: transactions are not supported")
+}
+
+type fakeStmt struct {
+	table *fakeTable
+	query string
+}
+
+func (s fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	t := s.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	insert := insertQuery.FindStringSubmatch(s.query)
+	if m := insertIfQuery.FindStringSubmatch(s.query); m != nil {
+		cols := columns(m[1])
+		for _, row := range t.rows {
+			if row.match(m[2], args[len(cols):]) {
+				return driver.RowsAffected(0), nil
+			}
+		}
+		insert = m
+	}
+	if insert != nil {
+		if _, ok := t.rows[args[0]]; ok {
+			return nil, fmt.Errorf("fakesql: duplicate key %v", args[0])
+		}
+		row := make(fakeRow)
+		for i, c := range columns(insert[1]) {
+			row[c] = args[i]
+		}
+		t.rows[args[0]] = row
+		return driver.RowsAffected(1), nil
+	}
+	if m := updateQuery.FindStringSubmatch(s.query); m != nil {
+		set := columns(m[1])
+		var n int64
+		for _, row := range t.rows {
+			if !row.match(m[2], args[len(set):]) {
+				continue
+			}
+			for i, c := range set {
+				row[c] = args[i]
+			}
+			n++
+		}
+		return driver.RowsAffected(n), nil
+	}
+	if m := deleteQuery.FindStringSubmatch(s.query); m != nil {
+		var n int64
+		for key, row := range t.rows {
+			if row.match(m[1], args) {
+				delete(t.rows, key)
+				n++
+			}
+		}
+		return driver.RowsAffected(n), nil
+	}
+	return nil, fmt.Errorf("fakesql: unsupported statement %q", s.query)
+}
+
+// fakeNow is the current time of the fakesql databases.
+var fakeNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query == "SELECT CURRENT_TIMESTAMP" {
+		return &fakeRows{columns: []string{"now"}, rows: [][]driver.Value{{fakeNow}}}, nil
+	}
+	m := selectQuery.FindStringSubmatch(s.query)
+	if m == nil {
+		return nil, fmt.Errorf("fakesql: unsupported query %q", s.query)
+	}
+	t := s.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rows := &fakeRows{columns: columns(m[1])}
+	var keys []driver.Value
+	for key := range t.rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	for _, key := range keys {
+		var values []driver.Value
+		for _, c := range rows.columns {
+			values = append(values, t.rows[key][c])
+		}
+		rows.rows = append(rows.rows, values)
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,226 @@
+package cron
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// HeapStore is a Store that indexes entries by their Next time in a min-heap,
+// so that Next is O(1), Add, Update and Remove are O(log n), and Ready is
+// proportional to the number of ready entries rather than to the size of the
+// store. It is the default Store used by Cron.
+//
+// The heap is ordered by the Next time an entry had when it was last added or
+// updated, so changes to Next take effect when Update is called, as the Store
+// contract requires.
+type HeapStore struct {
+	mu    sync.Mutex
+	items entryHeap
+	byID  map[EntryID]*heapItem
+}
+
+// heapItem is an entry in the heap, along with the Next time it is ordered by.
+type heapItem struct {
+	entry *Entry
+	next  time.Time
+	index int
+}
+
+// entryHeap implements heap.Interface. Entries that are not scheduled (with a
+// zero Next time) sort after all others.
+type entryHeap []*heapItem
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].next.IsZero() {
+		return false
+	}
+	if h[j].next.IsZero() {
+		return true
+	}
+	return h[i].next.Before(h[j].next)
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	item := x.(*heapItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
+
+// NewHeapStore returns an empty HeapStore.
+func NewHeapStore() *HeapStore {
+	return &HeapStore{byID: make(map[EntryID]*heapItem)}
+}
+
+// Add inserts a new entry into the store.
+func (s *HeapStore) Add(e *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := &heapItem{entry: e, next: e.Next}
+	s.byID[e.ID] = item
+	heap.Push(&s.items, item)
+}
+
+// Update moves the entry to its place for its current Next time.
+func (s *HeapStore) Update(e *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.byID[e.ID]
+	if !ok {
+		return
+	}
+	item.entry, item.next = e, e.Next
+	heap.Fix(&s.items, item.index)
+}
+
+// Remove deletes the entry with the given ID.
+func (s *HeapStore) Remove(id EntryID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.byID[id]
+	if !ok {
+		return false
+	}
+	delete(s.byID, id)
+	heap.Remove(&s.items, item.index)
+	return true
+}
+
+// Get returns the entry with the given ID, or nil.
+func (s *HeapStore) Get(id EntryID) *Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.byID[id]; ok {
+		return item.entry
+	}
+	return nil
+}
+
+// Entries returns all entries in the store.
+func (s *HeapStore) Entries() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]*Entry, len(s.items))
+	for i, item := range s.items {
+		entries[i] = item.entry
+	}
+	return entries
+}
+
+// EntriesAfter returns at most n of the entries whose ID is greater than
+// after, in increasing order of ID. It visits every entry, but copies only
+// those it returns.
+func (s *HeapStore) EntriesAfter(after EntryID, n int) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return entriesAfter(func(visit func(*Entry)) {
+		for _, item := range s.items {
+			visit(item.entry)
+		}
+	}, after, n)
+}
+
+// Len returns the number of entries in the store.
+func (s *HeapStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Next returns the earliest non-zero Next time of all entries.
+func (s *HeapStore) Next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.items) == 0 {
+		return time.Time{}
+	}
+	return s.items[0].next
+}
+
+// Ready returns all entries that are due at the given time. It visits only
+// the ready entries and their immediate children in the heap.
+func (s *HeapStore) Ready(now time.Time) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ready []*Entry
+	var visit func(i int)
+	visit = func(i int) {
+		if i >= len(s.items) {
+			return
+		}
+		item := s.items[i]
+		if item.next.IsZero() || item.next.After(now) {
+			return
+		}
+		ready = append(ready, item.entry)
+		visit(2*i + 1)
+		visit(2*i + 2)
+	}
+	visit(0)
+	return ready
+}
+
+// ReadyLimit returns at most n of the entries due at the given time, the
+// earliest first. It visits only the returned entries and their immediate
+// children in the heap.
+func (s *HeapStore) ReadyLimit(now time.Time, n int) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		ready    []*Entry
+		frontier = &heapFrontier{items: s.items}
+	)
+	frontier.push(0)
+	for len(ready) < n && len(frontier.indexes) > 0 {
+		i := heap.Pop(frontier).(int)
+		item := s.items[i]
+		if item.next.IsZero() || item.next.After(now) {
+			break
+		}
+		ready = append(ready, item.entry)
+		frontier.push(2*i + 1)
+		frontier.push(2*i + 2)
+	}
+	return ready
+}
+
+// heapFrontier is a min-heap of the indexes of items in an entryHeap, ordered
+// as the items are. ReadyLimit uses it to visit the items in order.
+type heapFrontier struct {
+	items   entryHeap
+	indexes []int
+}
+
+// push adds the index to the frontier, if it is that of an item.
+func (f *heapFrontier) push(i int) {
+	if i < len(f.items) {
+		heap.Push(f, i)
+	}
+}
+
+func (f *heapFrontier) Len() int           { return len(f.indexes) }
+func (f *heapFrontier) Less(i, j int) bool { return f.items.Less(f.indexes[i], f.indexes[j]) }
+func (f *heapFrontier) Swap(i, j int)      { f.indexes[i], f.indexes[j] = f.indexes[j], f.indexes[i] }
+func (f *heapFrontier) Push(x interface{}) { f.indexes = append(f.indexes, x.(int)) }
+
+func (f *heapFrontier) Pop() interface{} {
+	i := f.indexes[len(f.indexes)-1]
+	f.indexes = f.indexes[:len(f.indexes)-1]
+	return i
+}
@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHeapStoreOrdersByUpdatedNext(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewHeapStore()
+	entries := make([]*Entry, 10)
+	for i := range entries {
+		entries[i] = &Entry{ID: EntryID(i + 1), Next: base.Add(time.Duration(i) * time.Minute)}
+		s.Add(entries[i])
+	}
+	unscheduled := &Entry{ID: 11}
+	s.Add(unscheduled)
+
+	// Changes to Next take effect on Update.
+	entries[0].Next = base.Add(time.Hour)
+	if next := s.Next(); !next.Equal(base) {
+		t.Errorf("expected next %v before the update, got %v", base, next)
+	}
+	s.Update(entries[0])
+	if next := s.Next(); !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected next %v, got %v", base.Add(time.Minute), next)
+	}
+
+	s.Remove(2)
+	if ready := s.Ready(base.Add(4 * time.Minute)); len(ready) != 3 {
+		t.Errorf("expected 3 ready entries, got %d", len(ready))
+	}
+	if ready := s.Ready(base.Add(24 * time.Hour)); len(ready) != 9 {
+		t.Errorf("expected all scheduled entries to be ready, got %d", len(ready))
+	}
+	for _, e := range entries[2:] {
+		e.Next = time.Time{}
+		s.Update(e)
+	}
+	if next := s.Next(); !next.Equal(base.Add(time.Hour)) {
+		t.Errorf("expected next %v, got %v", base.Add(time.Hour), next)
+	}
+}
+
+func TestHeapStoreReadyLimit(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewHeapStore()
+	for i := 0; i < 50; i++ {
+		// Add the entries out of order, so that the heap is not sorted.
+		minutes := (i * 37) % 50
+		s.Add(&Entry{ID: EntryID(i + 1), Next: base.Add(time.Duration(minutes) * time.Minute)})
+	}
+	s.Add(&Entry{ID: 51})
+
+	for _, n := range []int{0, 1, 7, 30, 100} {
+		ready := s.ReadyLimit(base.Add(29*time.Minute), n)
+		expected := n
+		if expected > 30 {
+			expected = 30
+		}
+		if len(ready) != expected {
+			t.Errorf("n=%d: expected %d entries, got %d", n, expected, len(ready))
+			continue
+		}
+		for i, e := range ready {
+			if next := base.Add(time.Duration(i) * time.Minute); !e.Next.Equal(next) {
+				t.Errorf("n=%d: expected entry %d due at %v, got %v", n, i, next, e.Next)
+			}
+		}
+	}
+}
+
+func benchmarkStore(b *testing.B, newStore func() Store, n int) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newStore()
+	for i := 0; i < n; i++ {
+		s.Add(&Entry{ID: EntryID(i + 1), Next: base.Add(time.Duration(i) * time.Second)})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// One scheduler tick: find the next time, take the ready entry and
+		// reschedule it after all others.
+		now := s.Next()
+		for _, e := range s.Ready(now) {
+			e.Next = now.Add(time.Duration(n) * time.Second)
+			s.Update(e)
+		}
+	}
+}
+
+func BenchmarkStoreTick(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprintf("InMemory/%d", n), func(b *testing.B) {
+			benchmarkStore(b, func() Store { return NewInMemoryStore() }, n)
+		})
+		b.Run(fmt.Sprintf("Heap/%d", n), func(b *testing.B) {
+			benchmarkStore(b, func() Store { return NewHeapStore() }, n)
+		})
+	}
+}
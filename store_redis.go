@@ -0,0 +1,321 @@
+package cron
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisClient is the subset of Redis commands used by RedisStore. The cron
+// package does not depend on a Redis client library; adapt the client of your
+// choice (e.g. go-redis) to this interface.
+type RedisClient interface {
+	// HSet sets field in the hash stored at key to value.
+	HSet(key, field, value string) error
+	// HGet returns the value of field in the hash stored at key, and false if
+	// there is no such field.
+	HGet(key, field string) (string, bool, error)
+	// HDel removes field from the hash stored at key.
+	HDel(key, field string) error
+	// HGetAll returns all fields and values of the hash stored at key.
+	HGetAll(key string) (map[string]string, error)
+	// ZAdd adds member to the sorted set stored at key with the given score,
+	// or updates its score.
+	ZAdd(key string, score float64, member string) error
+	// ZRem removes member from the sorted set stored at key.
+	ZRem(key, member string) error
+	// ZRangeByScore returns the members of the sorted set stored at key with
+	// scores between min and max (as in ZRANGEBYSCORE, e.g. "-inf"), lowest
+	// score first. If count is positive, at most count members are returned.
+	ZRangeByScore(key, min, max string, count int64) ([]string, error)
+}
+
+// RedisStore is a Store that keeps entries in Redis, so that several
+// processes may share a single schedule source. Entries are saved in the
+// hash "<prefix>:entries", keyed by ID, and indexed in the sorted set
+// "<prefix>:next" by their Next time in Unix milliseconds, which serves Next
+// and Ready with ZRANGEBYSCORE. Saved entries are versioned StateKindEntry
+// state (see MarshalState), so that entries saved by older versions of the
+// package are migrated when loaded.
+//
+// As with SQLStore, only entries with a spec and a job name (see WithJobName)
+// are saved, along with their payloads as held in memory (i.e. encrypted if
+// the cron has a Cipher); other entries are kept in memory only. Saved jobs are resolved
+// through a map of registered jobs, including those of entries added by other
+// processes. Processes sharing a store do not coordinate entry IDs or runs, so
+// entries should be added by a single process.
+//
+// Saved entries are loaded from Redis each time they are returned, as new
+// copies, so changes to them are only kept once passed to Update.
+//
+// Since Store methods cannot return errors, failed Redis commands are logged.
+type RedisStore struct {
+	client RedisClient
+	prefix string
+	parser ScheduleParser
+	jobs   map[string]Job
+	local  *InMemoryStore
+
+	// entries holds a copy of each saved entry, as last added, updated or
+	// resolved, from which the entries returned are restored.
+	mu      sync.Mutex
+	entries map[EntryID]*Entry
+
+	// Logger receives errors from Redis. It defaults to DefaultLogger.
+	Logger Logger
+
+	// Codec encodes saved entries. It defaults to JSONCodec. Entries encoded
+	// with any registered codec are loaded, so that the codec of a store may
+	// be changed without migrating it.
+	Codec Codec
+}
+
+// NewRedisStore returns a RedisStore keeping entries under the given key
+// prefix, loaded with the entries already saved there. Their specs are parsed
+// with the given parser (the standard one if nil) and their jobs looked up by
+// name in jobs; an unknown job name or invalid spec is an error.
+func NewRedisStore(client RedisClient, prefix string, parser ScheduleParser, jobs map[string]Job) (*RedisStore, error) {
+	if parser == nil {
+		parser = standardParser
+	}
+	s := &RedisStore{
+		client:  client,
+		prefix:  prefix,
+		parser:  parser,
+		jobs:    jobs,
+		local:   NewInMemoryStore(),
+		entries: make(map[EntryID]*Entry),
+		Logger:  DefaultLogger,
+		Codec:   JSONCodec,
+	}
+	saved, err := client.HGetAll(s.hashKey())
+	if err != nil {
+		return nil, err
+	}
+	for field, value := range saved {
+		if _, err := s.restore(field, value); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *RedisStore) hashKey() string { return s.prefix + ":entries" }
+func (s *RedisStore) zsetKey() string { return s.prefix + ":next" }
+
+// redisScore returns the sorted set score of the given time.
+func redisScore(t time.Time) float64 {
+	return float64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// restore decodes the saved entry with the given ID into a new copy of the
+// entry, resolving it if it is not known yet. The copy is handed to the caller,
+// usually the scheduler's run loop, so that entries it already holds are not
+// changed under it.
+func (s *RedisStore) restore(field, value string) (*Entry, error) {
+	id, err := strconv.Atoi(field)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := loadEntry([]byte(value))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	known, ok := s.entries[EntryID(id)]
+	if !ok {
+		known, err = resolveEntry(EntryID(id), saved.Spec, saved.JobName, s.parser, s.jobs)
+		if err != nil {
+			return nil, err
+		}
+		s.entries[known.ID] = known
+	}
+	e := *known
+	e.Next = saved.Next
+	e.Prev = saved.Prev
+	e.Payload = saved.Payload
+	e.PayloadKey = saved.PayloadKey
+	return &e, nil
+}
+
+// keep records a copy of the entry, which later restores start from.
+func (s *RedisStore) keep(e *Entry) {
+	kept := *e
+	s.mu.Lock()
+	s.entries[e.ID] = &kept
+	s.mu.Unlock()
+}
+
+// load returns the saved entry with the given ID, or nil if there is none,
+// e.g. because another process removed it.
+func (s *RedisStore) load(id EntryID) *Entry {
+	field := strconv.Itoa(int(id))
+	value, ok, err := s.client.HGet(s.hashKey(), field)
+	if err != nil {
+		s.Logger.Error(err, "load entry", "entry", id)
+		return nil
+	}
+	if !ok {
+		s.mu.Lock()
+		delete(s.entries, id)
+		s.mu.Unlock()
+		return nil
+	}
+	e, err := s.restore(field, value)
+	if err != nil {
+		s.Logger.Error(err, "load entry", "entry", id)
+		return nil
+	}
+	return e
+}
+
+// save writes the entry and its index to Redis.
+func (s *RedisStore) save(e *Entry) {
+	field := strconv.Itoa(int(e.ID))
+	value, err := saveEntry(s.Codec, e)
+	if err == nil {
+		err = s.client.HSet(s.hashKey(), field, string(value))
+	}
+	if err == nil {
+		if e.Next.IsZero() {
+			err = s.client.ZRem(s.zsetKey(), field)
+		} else {
+			err = s.client.ZAdd(s.zsetKey(), redisScore(e.Next), field)
+		}
+	}
+	if err != nil {
+		s.Logger.Error(err, "save entry", "entry", e.ID)
+	}
+}
+
+// isShared reports whether the entry with the given ID is saved in Redis.
+func (s *RedisStore) isShared(id EntryID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entries[id]
+	return ok
+}
+
+// Add inserts a new entry, saving it if it has a spec and a job name.
+func (s *RedisStore) Add(e *Entry) {
+	if e.Spec == "" || e.JobName == "" {
+		s.local.Add(e)
+		return
+	}
+	s.keep(e)
+	s.save(e)
+}
+
+// Update saves the entry, e.g. its Next and Prev times or a new spec.
+func (s *RedisStore) Update(e *Entry) {
+	if s.isShared(e.ID) {
+		s.keep(e)
+		s.save(e)
+	}
+}
+
+// Remove deletes the entry with the given ID.
+func (s *RedisStore) Remove(id EntryID) bool {
+	if s.local.Remove(id) {
+		return true
+	}
+	if s.load(id) == nil {
+		return false
+	}
+	s.mu.Lock()
+	delete(s.entries, id)
+	s.mu.Unlock()
+	field := strconv.Itoa(int(id))
+	if err := s.client.HDel(s.hashKey(), field); err != nil {
+		s.Logger.Error(err, "delete entry", "entry", id)
+	}
+	if err := s.client.ZRem(s.zsetKey(), field); err != nil {
+		s.Logger.Error(err, "delete entry", "entry", id)
+	}
+	return true
+}
+
+// Get returns the entry with the given ID, or nil if it does not exist.
+func (s *RedisStore) Get(id EntryID) *Entry {
+	if e := s.local.Get(id); e != nil {
+		return e
+	}
+	return s.load(id)
+}
+
+// Entries returns all entries in the store, including those added by other
+// processes.
+func (s *RedisStore) Entries() []*Entry {
+	entries := s.local.Entries()
+	saved, err := s.client.HGetAll(s.hashKey())
+	if err != nil {
+		s.Logger.Error(err, "load entries")
+		return entries
+	}
+	for field, value := range saved {
+		e, err := s.restore(field, value)
+		if err != nil {
+			s.Logger.Error(err, "load entry", "entry", field)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// Len returns the number of entries in the store.
+func (s *RedisStore) Len() int {
+	return len(s.Entries())
+}
+
+// Next returns the earliest non-zero Next time of all entries.
+func (s *RedisStore) Next() time.Time {
+	next := s.local.Next()
+	ids, err := s.client.ZRangeByScore(s.zsetKey(), "-inf", "+inf", 1)
+	if err != nil {
+		s.Logger.Error(err, "load entries")
+		return next
+	}
+	for _, e := range s.loadAll(ids) {
+		if !e.Next.IsZero() && (next.IsZero() || e.Next.Before(next)) {
+			next = e.Next
+		}
+	}
+	return next
+}
+
+// Ready returns all entries that are due at the given time.
+func (s *RedisStore) Ready(now time.Time) []*Entry {
+	ready := s.local.Ready(now)
+	max := strconv.FormatFloat(redisScore(now), 'f', 0, 64)
+	ids, err := s.client.ZRangeByScore(s.zsetKey(), "-inf", max, 0)
+	if err != nil {
+		s.Logger.Error(err, "load entries")
+		return ready
+	}
+	for _, e := range s.loadAll(ids) {
+		// Scores are truncated to milliseconds.
+		if !e.Next.IsZero() && !e.Next.After(now) {
+			ready = append(ready, e)
+		}
+	}
+	return ready
+}
+
+// loadAll loads the saved entries with the given IDs, skipping those that
+// cannot be loaded.
+func (s *RedisStore) loadAll(fields []string) []*Entry {
+	var entries []*Entry
+	for _, field := range fields {
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			s.Logger.Error(err, "load entry", "entry", field)
+			continue
+		}
+		if e := s.load(EntryID(id)); e != nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,242 @@
+package cron
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis implements the RedisClient commands in memory.
+type fakeRedis struct {
+	mu     sync.Mutex
+	hashes map[string]map[string]string
+	zsets  map[string]map[string]float64
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		hashes: make(map[string]map[string]string),
+		zsets:  make(map[string]map[string]float64),
+	}
+}
+
+func (r *fakeRedis) HSet(key, field, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes[key] == nil {
+		r.hashes[key] = make(map[string]string)
+	}
+	r.hashes[key][field] = value
+	return nil
+}
+
+func (r *fakeRedis) HGet(key, field string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.hashes[key][field]
+	return value, ok, nil
+}
+
+func (r *fakeRedis) HDel(key, field string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.hashes[key], field)
+	return nil
+}
+
+func (r *fakeRedis) HGetAll(key string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := make(map[string]string)
+	for field, value := range r.hashes[key] {
+		all[field] = value
+	}
+	return all, nil
+}
+
+func (r *fakeRedis) ZAdd(key string, score float64, member string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.zsets[key] == nil {
+		r.zsets[key] = make(map[string]float64)
+	}
+	r.zsets[key][member] = score
+	return nil
+}
+
+func (r *fakeRedis) ZRem(key, member string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.zsets[key], member)
+	return nil
+}
+
+func (r *fakeRedis) ZRangeByScore(key, min, max string, count int64) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parse := func(s string) float64 {
+		switch s {
+		case "-inf":
+			return math.Inf(-1)
+		case "+inf":
+			return math.Inf(1)
+		}
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	}
+	lo, hi := parse(min), parse(max)
+	var members []string
+	for member, score := range r.zsets[key] {
+		if score >= lo && score <= hi {
+			members = append(members, member)
+		}
+	}
+	set := r.zsets[key]
+	sort.Slice(members, func(i, j int) bool { return set[members[i]] < set[members[j]] })
+	if count > 0 && int64(len(members)) > count {
+		members = members[:count]
+	}
+	return members, nil
+}
+
+// NewFakeRedis returns an in-memory RedisClient for the store conformance
+// tests, which are in package cron_test.
+func NewFakeRedis() RedisClient {
+	return newFakeRedis()
+}
+
+func TestRedisStore(t *testing.T) {
+	var (
+		client = newFakeRedis()
+		jobs   = map[string]Job{"report": FuncJob(func() {})}
+		now    = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	)
+	store, err := NewRedisStore(client, "cron", nil, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared := &Entry{ID: 1, Spec: "@hourly", JobName: "report", Schedule: Every(time.Hour), Job: jobs["report"]}
+	local := &Entry{ID: 2, Schedule: Every(time.Hour), Job: FuncJob(func() {})}
+	store.Add(shared)
+	store.Add(local)
+	if len(client.hashes["cron:entries"]) != 1 {
+		t.Fatalf("expected only the named entry to be saved, got %v", client.hashes)
+	}
+
+	shared.Next = now.Add(time.Minute)
+	store.Update(shared)
+	local.Next = now.Add(time.Hour)
+	store.Update(local)
+	if next := store.Next(); !next.Equal(shared.Next) {
+		t.Errorf("expected next %v, got %v", shared.Next, next)
+	}
+	if ready := store.Ready(now); len(ready) != 0 {
+		t.Errorf("expected no ready entries, got %d", len(ready))
+	}
+	if ready := store.Ready(now.Add(time.Minute)); len(ready) != 1 || ready[0].ID != 1 {
+		t.Errorf("expected entry 1 to be ready, got %v", ready)
+	}
+
+	// Another process sees the saved entry, with its job resolved by name.
+	other, err := NewRedisStore(client, "cron", nil, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := other.Get(1)
+	if e == nil || e.Spec != "@hourly" || e.Job == nil || !e.Next.Equal(shared.Next) {
+		t.Fatalf("expected the saved entry to be loaded, got %+v", e)
+	}
+	if other.Get(2) != nil {
+		t.Error("expected the unnamed entry not to be shared")
+	}
+
+	if !other.Remove(1) {
+		t.Error("expected the saved entry to be removed")
+	}
+	if store.Get(1) != nil || store.Len() != 1 {
+		t.Errorf("expected only the local entry to remain, got %d entries", store.Len())
+	}
+	if store.Remove(1) {
+		t.Error("expected removing a missing entry to fail")
+	}
+}
+
+func TestRedisStoreUnknownJob(t *testing.T) {
+	client := newFakeRedis()
+	client.HSet("cron:entries", "1", `{"spec":"@daily","job_name":"missing"}`)
+	if _, err := NewRedisStore(client, "cron", nil, map[string]Job{}); err == nil {
+		t.Error("expected an error for an unknown job name")
+	}
+}
+
+func TestRedisStoreVersion(t *testing.T) {
+	client := newFakeRedis()
+	client.HSet("cron:entries", "1", `{"version":99,"kind":"entry","data":{}}`)
+	_, err := NewRedisStore(client, "cron", nil, map[string]Job{})
+	if verr, ok := err.(*StateVersionError); !ok || verr.Version != 99 {
+		t.Errorf("expected a *StateVersionError, got %v", err)
+	}
+}
+
+func TestRedisStoreRestoresCopies(t *testing.T) {
+	var (
+		client = newFakeRedis()
+		jobs   = map[string]Job{"report": FuncJob(func() {})}
+		now    = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	)
+	store, _ := NewRedisStore(client, "cron", nil, jobs)
+	other, _ := NewRedisStore(client, "cron", nil, jobs)
+	held := &Entry{ID: 1, Spec: "@hourly", JobName: "report", Schedule: Every(time.Hour), Job: jobs["report"],
+		Next: now}
+	store.Add(held)
+
+	// Another process moves the entry on; loading it again does not change
+	// the entry already handed out.
+	e := other.Get(1)
+	e.Prev, e.Next = now, now.Add(time.Hour)
+	other.Update(e)
+	loaded := store.Get(1)
+	if loaded == held || !loaded.Next.Equal(now.Add(time.Hour)) || !loaded.Prev.Equal(now) {
+		t.Fatalf("expected a new copy with the saved times, got %+v", loaded)
+	}
+	if !held.Next.Equal(now) || !held.Prev.IsZero() {
+		t.Errorf("expected the held entry to be left alone, got next %v and prev %v", held.Next, held.Prev)
+	}
+
+	// Changes to a copy are kept once updated.
+	loaded.Paused = true
+	store.Update(loaded)
+	if e := store.Get(1); !e.Paused {
+		t.Error("expected the update to be kept")
+	}
+}
+
+func TestRedisStoreRunsSharedEntries(t *testing.T) {
+	var ran bool
+	client := newFakeRedis()
+	jobs := map[string]Job{"ping": FuncJob(func() { ran = true })}
+	store, _ := NewRedisStore(client, "cron", nil, jobs)
+	cron := New(WithStore(store), WithChain())
+
+	// An entry added by another process is picked up, wrapped and run.
+	now := time.Now()
+	client.HSet("cron:entries", "9", `{"spec":"@daily","job_name":"ping","next":"`+
+		now.Format(time.RFC3339Nano)+`"}`)
+	client.ZAdd("cron:next", redisScore(now), "9")
+	ready := store.Ready(now)
+	if len(ready) != 1 {
+		t.Fatalf("expected the shared entry to be ready, got %d entries", len(ready))
+	}
+	cron.startJob(ready[0], now)
+	cron.jobWaiter.Wait()
+	if !ran {
+		t.Error("expected the shared entry to run")
+	}
+	if _, ok := cron.StatsSnapshot()[9]; !ok {
+		t.Error("expected statistics for the shared entry")
+	}
+}
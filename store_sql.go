@@ -0,0 +1,206 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SQLStore is a Store that persists entries to a SQL database, so that they
+// survive restarts. It requires a table of the following shape:
+//
+//	CREATE TABLE cron_entries (
+//		id       BIGINT PRIMARY KEY,
+//		job_name VARCHAR(255) NOT NULL,
+//		state    BLOB NOT NULL
+//	)
+//
+// The state of an entry (its spec, job name, Next and Prev times and payload)
+// is saved as versioned StateKindEntry state (see MarshalState), so that
+// entries saved by older versions of the package are migrated when loaded.
+// The job name is also saved in its own column, e.g. to find the jobs to
+// register before opening the store.
+//
+// Only entries added with a spec (e.g. with AddJob) and a job name (see
+// WithJobName) are persisted; others are kept in memory only. Jobs cannot be
+// stored, so NewSQLStore resolves each saved job name through a map of
+// registered jobs. Entry options other than the name and payload (see
+// WithPayload) are not persisted and must be applied again by the caller if
+// needed. Payloads are saved as they are held in memory, i.e. encrypted if the
+// cron has a Cipher.
+//
+// Entries are served from memory and written through to the database. Since
+// Store methods cannot return errors, failed writes are logged.
+type SQLStore struct {
+	mem   *InMemoryStore
+	db    *sql.DB
+	table string
+
+	mu        sync.Mutex
+	persisted map[EntryID]bool
+
+	// Placeholder returns the bind parameter for the n-th (1-based) argument
+	// of a query. It defaults to "?"; use e.g. "$1" for PostgreSQL.
+	Placeholder func(n int) string
+
+	// Logger receives errors writing to the database. It defaults to
+	// DefaultLogger.
+	Logger Logger
+
+	// Codec encodes the state of saved entries. It defaults to JSONCodec.
+	// Entries encoded with any registered codec are loaded, so that the codec
+	// of a store may be changed without migrating it.
+	Codec Codec
+}
+
+// sqlEntryRow is a row of the entries table.
+type sqlEntryRow struct {
+	id    int64
+	state []byte
+}
+
+// NewSQLStore returns a SQLStore backed by the given table, loaded with the
+// entries already saved there. Their specs are parsed with the given parser
+// (the standard one if nil) and their jobs looked up by name in jobs; an
+// unknown job name or invalid spec is an error. Pass the store to New with
+// WithStore, along with a parser matching the specs.
+func NewSQLStore(db *sql.DB, table string, parser ScheduleParser, jobs map[string]Job) (*SQLStore, error) {
+	if parser == nil {
+		parser = standardParser
+	}
+	s := &SQLStore{
+		mem:         NewInMemoryStore(),
+		db:          db,
+		table:       table,
+		persisted:   make(map[EntryID]bool),
+		Placeholder: func(int) string { return "?" },
+		Logger:      DefaultLogger,
+		Codec:       JSONCodec,
+	}
+	rows, err := db.Query(fmt.Sprintf("SELECT id, state FROM %s", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row sqlEntryRow
+		if err := rows.Scan(&row.id, &row.state); err != nil {
+			return nil, err
+		}
+		e, err := restoreEntry(row, parser, jobs)
+		if err != nil {
+			return nil, err
+		}
+		s.mem.Add(e)
+		s.persisted[e.ID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// restoreEntry rebuilds an entry from its saved row.
+func restoreEntry(row sqlEntryRow, parser ScheduleParser, jobs map[string]Job) (*Entry, error) {
+	saved, err := loadEntry(row.state)
+	if err != nil {
+		return nil, err
+	}
+	e, err := resolveEntry(EntryID(row.id), saved.Spec, saved.JobName, parser, jobs)
+	if err != nil {
+		return nil, err
+	}
+	e.Next = saved.Next
+	e.Prev = saved.Prev
+	e.Payload = saved.Payload
+	e.PayloadKey = saved.PayloadKey
+	return e, nil
+}
+
+// Add inserts a new entry, persisting it if it has a spec and a job name.
+func (s *SQLStore) Add(e *Entry) {
+	s.mem.Add(e)
+	if e.Spec == "" || e.JobName == "" {
+		return
+	}
+	state, err := saveEntry(s.Codec, e)
+	if err == nil {
+		p := s.Placeholder
+		_, err = s.db.Exec(fmt.Sprintf("INSERT INTO %s (id, job_name, state) VALUES (%s, %s, %s)",
+			s.table, p(1), p(2), p(3)), int64(e.ID), e.JobName, state)
+	}
+	if err != nil {
+		s.Logger.Error(err, "persist entry", "entry", e.ID)
+		return
+	}
+	s.mu.Lock()
+	s.persisted[e.ID] = true
+	s.mu.Unlock()
+}
+
+// Update persists the entry: its spec and job name, which change with
+// UpdateSchedule, its Next and Prev times, and its payload, which changes
+// when its key is rotated.
+func (s *SQLStore) Update(e *Entry) {
+	if !s.isPersisted(e.ID) {
+		return
+	}
+	state, err := saveEntry(s.Codec, e)
+	if err == nil {
+		p := s.Placeholder
+		_, err = s.db.Exec(fmt.Sprintf("UPDATE %s SET job_name = %s, state = %s WHERE id = %s",
+			s.table, p(1), p(2), p(3)), e.JobName, state, int64(e.ID))
+	}
+	if err != nil {
+		s.Logger.Error(err, "persist entry", "entry", e.ID)
+	}
+}
+
+// Remove deletes the entry with the given ID.
+func (s *SQLStore) Remove(id EntryID) bool {
+	if !s.mem.Remove(id) {
+		return false
+	}
+	if !s.isPersisted(id) {
+		return true
+	}
+	_, err := s.db.Exec(fmt.Sprintf(
+		"DELETE FROM %s WHERE id = %s", s.table, s.Placeholder(1)), int64(id))
+	if err != nil {
+		s.Logger.Error(err, "delete entry", "entry", id)
+	}
+	s.mu.Lock()
+	delete(s.persisted, id)
+	s.mu.Unlock()
+	return true
+}
+
+// isPersisted reports whether the entry is saved in the database.
+func (s *SQLStore) isPersisted(id EntryID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.persisted[id]
+}
+
+// Get returns the entry with the given ID, or nil if it does not exist.
+func (s *SQLStore) Get(id EntryID) *Entry { return s.mem.Get(id) }
+
+// Entries returns all entries in the store.
+func (s *SQLStore) Entries() []*Entry { return s.mem.Entries() }
+
+// EntriesAfter returns at most n of the entries whose ID is greater than
+// after, in increasing order of ID.
+func (s *SQLStore) EntriesAfter(after EntryID, n int) []*Entry { return s.mem.EntriesAfter(after, n) }
+
+// Len returns the number of entries in the store.
+func (s *SQLStore) Len() int { return s.mem.Len() }
+
+// Next returns the earliest non-zero Next time of all entries.
+func (s *SQLStore) Next() time.Time { return s.mem.Next() }
+
+// Ready returns all entries that are due at the given time.
+func (s *SQLStore) Ready(now time.Time) []*Entry { return s.mem.Ready(now) }
@@ -0,0 +1,59 @@
+//go:build !cronminimal
+// +build !cronminimal
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestoreEntry(t *testing.T) {
+	job := FuncJob(func() {})
+	jobs := map[string]Job{"report": job}
+	next := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	row := func(spec, jobName string) sqlEntryRow {
+		state, err := saveEntry(JSONCodec, &Entry{Spec: spec, JobName: jobName, Next: next})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sqlEntryRow{id: 7, state: state}
+	}
+
+	e, err := restoreEntry(row("4 3 * * *", "report"), standardParser, jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != 7 || e.Spec != "4 3 * * *" || e.JobName != "report" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if !e.Next.Equal(next) || !e.Prev.IsZero() {
+		t.Errorf("expected next %v and zero prev, got %v and %v", next, e.Next, e.Prev)
+	}
+	if e.Schedule == nil || e.Job == nil {
+		t.Error("expected schedule and job to be restored")
+	}
+
+	if _, err := restoreEntry(row("@daily", "missing"), standardParser, jobs); err == nil {
+		t.Error("expected an error for an unknown job name")
+	}
+	if _, err := restoreEntry(row("bogus", "report"), standardParser, jobs); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+}
+
+func TestRestoreEntryVersion(t *testing.T) {
+	jobs := map[string]Job{"report": FuncJob(func() {})}
+
+	// Unversioned state is migrated from version 0.
+	legacy := sqlEntryRow{id: 1, state: []byte(`{"spec":"@daily","job_name":"report"}`)}
+	if e, err := restoreEntry(legacy, standardParser, jobs); err != nil || e.Spec != "@daily" {
+		t.Errorf("expected the unversioned entry to be restored, got %+v, %v", e, err)
+	}
+
+	newer := sqlEntryRow{id: 1, state: []byte(`{"version":99,"kind":"entry","data":{}}`)}
+	_, err := restoreEntry(newer, standardParser, jobs)
+	if verr, ok := err.(*StateVersionError); !ok || verr.Version != 99 {
+		t.Errorf("expected a *StateVersionError, got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+// Package storetest provides a conformance test suite for implementations of
+// cron.Store.
+//
+// Store implementations outside of the cron package (e.g. backed by Redis or
+// SQL) can verify their compatibility with the scheduler as follows:
+//
+//	func TestMyStore(t *testing.T) {
+//		storetest.TestStore(t, func() cron.Store { return newMyStore(t) })
+//	}
+//
+// Stores that persist entries can further verify that they are saved with
+// TestPersistentStore.
+package storetest
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// JobName is the job name (see cron.WithJobName) of the entries added by
+// TestStore, whose spec is "@every 1m". Stores that persist entries must
+// resolve it to a job.
+const JobName = "storetest"
+
+// TestStore verifies that the stores returned by newStore satisfy the
+// cron.Store contract. Each call to newStore must return a new, empty store.
+func TestStore(t *testing.T, newStore func() cron.Store) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEntry := func(id cron.EntryID, next time.Time) *cron.Entry {
+		return &cron.Entry{ID: id, Spec: "@every 1m", JobName: JobName, Schedule: cron.Every(time.Minute),
+			Next: next, Job: cron.FuncJob(func() {})}
+	}
+	ids := func(entries []*cron.Entry) []cron.EntryID {
+		var ids []cron.EntryID
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+	equal := func(a, b []cron.EntryID) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		s := newStore()
+		if s.Len() != 0 || len(s.Entries()) != 0 {
+			t.Error("expected an empty store")
+		}
+		if !s.Next().IsZero() {
+			t.Error("expected zero Next for an empty store")
+		}
+		if len(s.Ready(base)) != 0 {
+			t.Error("expected no ready entries")
+		}
+		if s.Get(1) != nil {
+			t.Error("expected Get to return nil")
+		}
+		if s.Remove(1) {
+			t.Error("expected Remove of a missing entry to return false")
+		}
+	})
+
+	t.Run("add and get", func(t *testing.T) {
+		s := newStore()
+		s.Add(newEntry(1, base))
+		s.Add(newEntry(2, base.Add(time.Minute)))
+		if s.Len() != 2 {
+			t.Errorf("expected 2 entries, got %d", s.Len())
+		}
+		if e := s.Get(2); e == nil || e.ID != 2 {
+			t.Errorf("expected entry 2, got %v", e)
+		}
+		if actual := ids(s.Entries()); !equal(actual, []cron.EntryID{1, 2}) {
+			t.Errorf("expected entries [1 2], got %v", actual)
+		}
+	})
+
+	t.Run("ready is order independent", func(t *testing.T) {
+		s := newStore()
+		s.Add(newEntry(1, base.Add(2*time.Minute)))
+		s.Add(newEntry(2, base.Add(time.Hour)))
+		s.Add(newEntry(3, base))
+		s.Add(newEntry(4, base.Add(time.Minute)))
+		if next := s.Next(); !next.Equal(base) {
+			t.Errorf("expected Next %v, got %v", base, next)
+		}
+		if actual := ids(s.Ready(base.Add(2 * time.Minute))); !equal(actual, []cron.EntryID{1, 3, 4}) {
+			t.Errorf("expected ready entries [1 3 4], got %v", actual)
+		}
+	})
+
+	t.Run("zero times are never ready", func(t *testing.T) {
+		s := newStore()
+		s.Add(newEntry(1, time.Time{}))
+		s.Add(newEntry(2, base.Add(time.Minute)))
+		if next := s.Next(); !next.Equal(base.Add(time.Minute)) {
+			t.Errorf("expected Next to skip zero times, got %v", next)
+		}
+		if actual := ids(s.Ready(base.Add(time.Hour))); !equal(actual, []cron.EntryID{2}) {
+			t.Errorf("expected ready entries [2], got %v", actual)
+		}
+
+		s = newStore()
+		s.Add(newEntry(1, time.Time{}))
+		if !s.Next().IsZero() {
+			t.Error("expected zero Next when no entry is scheduled")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s := newStore()
+		s.Add(newEntry(1, base))
+		s.Add(newEntry(2, base.Add(time.Minute)))
+
+		e := s.Get(1)
+		e.Prev = e.Next
+		e.Next = base.Add(time.Hour)
+		s.Update(e)
+
+		if next := s.Next(); !next.Equal(base.Add(time.Minute)) {
+			t.Errorf("expected Next %v, got %v", base.Add(time.Minute), next)
+		}
+		if actual := ids(s.Ready(base.Add(time.Minute))); !equal(actual, []cron.EntryID{2}) {
+			t.Errorf("expected ready entries [2], got %v", actual)
+		}
+		if e := s.Get(1); !e.Prev.Equal(base) || !e.Next.Equal(base.Add(time.Hour)) {
+			t.Errorf("expected update to be retained, got prev %v next %v", e.Prev, e.Next)
+		}
+	})
+
+	t.Run("entries after", func(t *testing.T) {
+		s := newStore()
+		r, ok := s.(cron.EntryRanger)
+		if !ok {
+			t.Skip("the store does not implement cron.EntryRanger")
+		}
+		for _, id := range []cron.EntryID{4, 2, 5, 1, 3} {
+			s.Add(newEntry(id, base))
+		}
+		if actual := r.EntriesAfter(1, 3); len(actual) != 3 ||
+			actual[0].ID != 2 || actual[1].ID != 3 || actual[2].ID != 4 {
+			t.Errorf("expected entries [2 3 4] in order, got %v", actual)
+		}
+		if actual := r.EntriesAfter(4, 3); len(actual) != 1 || actual[0].ID != 5 {
+			t.Errorf("expected entry 5, got %v", actual)
+		}
+		if actual := r.EntriesAfter(5, 3); len(actual) != 0 {
+			t.Errorf("expected no entries, got %v", actual)
+		}
+	})
+
+	t.Run("remove during iteration", func(t *testing.T) {
+		s := newStore()
+		for id := cron.EntryID(1); id <= 5; id++ {
+			s.Add(newEntry(id, base))
+		}
+		entries := s.Entries()
+		for _, e := range entries {
+			if !s.Remove(e.ID) {
+				t.Errorf("expected entry %d to be removed", e.ID)
+			}
+		}
+		if len(entries) != 5 {
+			t.Errorf("expected the snapshot to be unaffected, got %d entries", len(entries))
+		}
+		if s.Len() != 0 || s.Get(3) != nil || len(s.Ready(base)) != 0 {
+			t.Error("expected an empty store")
+		}
+	})
+
+	t.Run("concurrent snapshot", func(t *testing.T) {
+		s := newStore()
+		for id := cron.EntryID(1); id <= 50; id++ {
+			s.Add(newEntry(id, base))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for id := cron.EntryID(51); id <= 100; id++ {
+				s.Add(newEntry(id, base))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for id := cron.EntryID(1); id <= 50; id++ {
+				s.Remove(id)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				snapshot := s.Entries()
+				n := len(snapshot)
+				s.Ready(base)
+				s.Next()
+				if len(snapshot) != n {
+					t.Error("snapshot changed while reading it")
+				}
+			}
+		}()
+		wg.Wait()
+
+		if actual := ids(s.Entries()); len(actual) != 50 || actual[0] != 51 {
+			t.Errorf("expected entries 51-100, got %v", actual)
+		}
+	})
+}
+
+// TestPersistentStore verifies that the stores returned by newStore persist
+// the entries added to them, as a process restarting with the same backing
+// storage would find them. Each call to newStore must return a new, empty
+// store, and a function returning another store loaded from its storage.
+func TestPersistentStore(t *testing.T, newStore func() (store cron.Store, reopen func() cron.Store)) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEntry := func(id cron.EntryID, next time.Time) *cron.Entry {
+		return &cron.Entry{ID: id, Spec: "@every 1m", JobName: JobName, Schedule: cron.Every(time.Minute),
+			Next: next, Job: cron.FuncJob(func() {})}
+	}
+
+	t.Run("add", func(t *testing.T) {
+		s, reopen := newStore()
+		e := newEntry(1, base)
+		e.Prev = base.Add(-time.Minute)
+		e.Payload, e.PayloadKey = []byte("payload"), "key"
+		s.Add(e)
+
+		e = reopen().Get(1)
+		if e == nil || e.Spec != "@every 1m" || e.JobName != JobName || e.Job == nil {
+			t.Fatalf("expected entry 1 to be saved, got %+v", e)
+		}
+		if !e.Next.Equal(base) || !e.Prev.Equal(base.Add(-time.Minute)) {
+			t.Errorf("expected prev %v next %v, got prev %v next %v", base.Add(-time.Minute), base, e.Prev, e.Next)
+		}
+		if string(e.Payload) != "payload" || e.PayloadKey != "key" {
+			t.Errorf("expected the payload to be saved, got %q with key %q", e.Payload, e.PayloadKey)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s, reopen := newStore()
+		s.Add(newEntry(1, base))
+
+		e := s.Get(1)
+		e.Spec, e.Schedule = "@every 2m", cron.Every(2*time.Minute)
+		e.Prev, e.Next = base, base.Add(2*time.Minute)
+		s.Update(e)
+
+		e = reopen().Get(1)
+		if e == nil || e.Spec != "@every 2m" {
+			t.Fatalf("expected the new spec to be saved, got %+v", e)
+		}
+		if !e.Prev.Equal(base) || !e.Next.Equal(base.Add(2*time.Minute)) {
+			t.Errorf("expected prev %v next %v, got prev %v next %v", base, base.Add(2*time.Minute), e.Prev, e.Next)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		s, reopen := newStore()
+		s.Add(newEntry(1, base))
+		s.Add(newEntry(2, base))
+		s.Remove(1)
+
+		if s := reopen(); s.Get(1) != nil || s.Get(2) == nil || s.Len() != 1 {
+			t.Errorf("expected only entry 2 to remain, got %d entries", s.Len())
+		}
+	})
+}
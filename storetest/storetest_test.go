@@ -0,0 +1,15 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	TestStore(t, func() cron.Store { return cron.NewInMemoryStore() })
+}
+
+func TestHeapStore(t *testing.T) {
+	TestStore(t, func() cron.Store { return cron.NewHeapStore() })
+}
@@ -0,0 +1,53 @@
+package cron
+
+import "time"
+
+// PauseAll stops firing all entries, e.g. during a maintenance window, without
+// stopping the scheduler: entries may still be added, removed and triggered
+// (see RunNow), and runs in progress are not affected. Unlike PauseMany, it
+// does not change the entries' Paused state.
+func (c *Cron) PauseAll() {
+	c.suspendAll(true)
+}
+
+// ResumeAll resumes firing entries after PauseAll. Each entry that is not
+// paused or archived is rescheduled from its next activation after now, so
+// that activations missed meanwhile are not run.
+func (c *Cron) ResumeAll() {
+	c.suspendAll(false)
+}
+
+// suspendAll applies PauseAll or ResumeAll, in the run loop if the cron is
+// running.
+func (c *Cron) suspendAll(suspend bool) {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		c.suspend <- suspend
+		return
+	}
+	c.setSuspended(suspend, c.now())
+}
+
+// setSuspended records whether firing is suspended, rescheduling the entries
+// on resume.
+func (c *Cron) setSuspended(suspend bool, now time.Time) {
+	if suspend == c.suspended {
+		return
+	}
+	c.suspended = suspend
+	if suspend {
+		c.logger.Info("pause all", "now", now)
+		return
+	}
+	if c.running {
+		for _, e := range c.store.Entries() {
+			if e.Paused || e.Archived {
+				continue
+			}
+			e.Next = c.nextActivation(e, now)
+			c.store.Update(e)
+		}
+	}
+	c.logger.Info("resume all", "now", now)
+}
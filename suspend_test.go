@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPauseAll(t *testing.T) {
+	var runs int32
+	cron := New(WithParser(secondParser), WithChain())
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	cron.PauseAll()
+	added, err := cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(OneSecond + 500*time.Millisecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Fatalf("expected no runs while paused, got %d", n)
+	}
+	if len(cron.Entries()) != 2 {
+		t.Errorf("expected the entry added while paused to be kept, got %d entries", len(cron.Entries()))
+	}
+
+	before := time.Now()
+	cron.ResumeAll()
+	for _, e := range cron.Entries() {
+		if !e.Next.After(before) || e.Next.After(before.Add(OneSecond)) {
+			t.Errorf("expected entry %d to be rescheduled from now, got %v", e.ID, e.Next)
+		}
+	}
+	if e := cron.Entry(added); e.Paused {
+		t.Error("expected entries not to be individually paused")
+	}
+}
+
+func TestPauseAllBeforeStart(t *testing.T) {
+	var runs int32
+	cron := New(WithParser(secondParser), WithChain())
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.PauseAll()
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(OneSecond + 500*time.Millisecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Errorf("expected no runs while paused, got %d", n)
+	}
+}
@@ -0,0 +1,57 @@
+package cron
+
+import "time"
+
+// entryUpdate replaces the schedule of an entry, its job, or both.
+type entryUpdate struct {
+	spec     string
+	schedule Schedule
+	job      Job
+}
+
+// UpdateSchedule gives the entry with the given ID the schedule parsed from
+// spec, in a single step of the run loop if the cron is running. Unlike
+// removing the entry and adding it again, this keeps its ID, Prev time,
+// wrappers and statistics. The entry's next activation follows from the new
+// schedule, unless it is paused or archived. It returns the parse error, or
+// ErrNoSuchEntry for an unknown ID.
+func (c *Cron) UpdateSchedule(id EntryID, spec string) error {
+	schedule, err := c.parser.Parse(spec)
+	if err != nil {
+		return err
+	}
+	return c.updateEntry(id, entryUpdate{spec: spec, schedule: schedule})
+}
+
+// UpdateJob replaces the job of the entry with the given ID, wrapping it as
+// the previous one was. Runs in progress complete with the previous job. It
+// returns ErrNoSuchEntry for an unknown ID.
+func (c *Cron) UpdateJob(id EntryID, cmd Job) error {
+	return c.updateEntry(id, entryUpdate{job: cmd})
+}
+
+// updateEntry applies the update to the entry with the given ID.
+func (c *Cron) updateEntry(id EntryID, u entryUpdate) error {
+	errs := make(map[EntryID]error)
+	if len(c.sendBatch(batchRequest{op: opUpdate, ids: []EntryID{id}, errs: errs, update: u})) == 0 {
+		return errs[id]
+	}
+	return nil
+}
+
+// applyUpdate applies the update to the entry, keeping its ID, Prev time,
+// wrappers and statistics.
+func (c *Cron) applyUpdate(e *Entry, u entryUpdate, now time.Time) {
+	if u.job != nil {
+		e.Job = u.job
+		c.wrapJob(e)
+	}
+	if u.schedule == nil {
+		return
+	}
+	e.Spec, e.Schedule = u.spec, u.schedule
+	bindHashKey(e)
+	if c.running && !e.Paused && !e.Archived {
+		e.Next = firstActivation(e.activeSchedule(), now)
+	}
+}
@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateSchedule(t *testing.T) {
+	var events []Event
+	cron := New(WithEventHandler(func(ev Event) { events = append(events, ev) }))
+	id, _ := cron.AddFunc("@daily", func() {}, WithName("report"))
+	if err := cron.UpdateSchedule(id, "@hourly"); err != nil {
+		t.Fatal(err)
+	}
+	e := cron.Entry(id)
+	if e.Spec != "@hourly" || e.Name != "report" {
+		t.Errorf("expected entry %d to have the new spec, got %+v", id, e)
+	}
+	last := events[len(events)-1]
+	if last.Type != EventUpdated || last.Spec != "@hourly" || last.OldSpec != "@daily" {
+		t.Errorf("unexpected event %+v", last)
+	}
+
+	if err := cron.UpdateSchedule(id, "bogus"); err == nil {
+		t.Error("expected a parse error")
+	}
+	if err := cron.UpdateSchedule(id+1, "@hourly"); err != ErrNoSuchEntry {
+		t.Errorf("expected ErrNoSuchEntry, got %v", err)
+	}
+	if err := cron.UpdateJob(id+1, FuncJob(func() {})); err != ErrNoSuchEntry {
+		t.Errorf("expected ErrNoSuchEntry, got %v", err)
+	}
+}
+
+func TestUpdateScheduleWhileRunning(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var once sync.Once
+	cron := newWithSeconds()
+	id, _ := cron.AddFunc("0 0 0 1 1 ?", func() { once.Do(wg.Done) })
+	cron.Start()
+	defer cron.Stop()
+
+	if err := cron.UpdateSchedule(id, "* * * * * ?"); err != nil {
+		t.Fatal(err)
+	}
+	if next := cron.Entry(id).Next; next.After(time.Now().Add(OneSecond)) {
+		t.Errorf("expected the next activation within a second, got %v", next)
+	}
+	select {
+	case <-time.After(2 * OneSecond):
+		t.Fatal("expected the job to run on its new schedule")
+	case <-wait(&wg):
+	}
+	if cron.Entry(id).ID != id {
+		t.Error("expected the entry to keep its ID")
+	}
+}
+
+func TestUpdateJobKeepsWrappers(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		wrapped int
+		ran     string
+	)
+	counter := func(j Job) Job {
+		return FuncJob(func() {
+			mu.Lock()
+			wrapped++
+			mu.Unlock()
+			j.Run()
+		})
+	}
+	cron := New(WithChain(counter))
+	id, _ := cron.AddFunc("@daily", func() { ran = "old" })
+	if err := cron.UpdateJob(id, FuncJob(func() { ran = "new" })); err != nil {
+		t.Fatal(err)
+	}
+	cron.Entry(id).WrappedJob.Run()
+	if ran != "new" || wrapped != 1 {
+		t.Errorf("expected the new job to run wrapped, got %q with %d wrapper calls", ran, wrapped)
+	}
+}
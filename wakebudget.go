@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// lateness returns how late a run of the entry due at e.Next starts at now.
+// Delays imposed on purpose by coalescing the entry into a later wake-up (see
+// WithPrecision and WithWakeBudget) do not count.
+func (c *Cron) lateness(e *Entry, now time.Time) time.Duration {
+	if c.plannedWake.After(e.Next) && !now.Before(c.plannedWake) {
+		return now.Sub(c.plannedWake)
+	}
+	return now.Sub(e.Next)
+}
+
+// coalesced logs and reports the entries among ready that were due before the
+// wake-up the scheduler had planned, and were delayed to share it.
+func (c *Cron) coalesced(ready []*Entry, now time.Time) {
+	if c.plannedWake.IsZero() {
+		return
+	}
+	var (
+		ids     []EntryID
+		shifted map[EntryID]time.Duration
+	)
+	for _, e := range ready {
+		if !e.Next.Before(c.plannedWake) {
+			continue
+		}
+		if shifted == nil {
+			shifted = make(map[EntryID]time.Duration)
+		}
+		ids = append(ids, e.ID)
+		shifted[e.ID] = c.plannedWake.Sub(e.Next)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	c.logger.Info("coalesced", "now", now, "wake", c.plannedWake, "entries", ids)
+	c.notify(WakeCoalesced{Time: now, Shifted: shifted})
+}
+
+// ready returns the entries due at now, earliest first, and whether more are
+// due than the limit set with WithMaxDispatchPerWake allows.
+func (c *Cron) ready(now time.Time) ([]*Entry, bool) {
+	var ready []*Entry
+	if limiter, ok := c.store.(ReadyLimiter); ok && c.maxDispatch > 0 {
+		ready = limiter.ReadyLimit(now, c.maxDispatch+1)
+	} else {
+		ready = c.store.Ready(now)
+	}
+	sort.Sort(byTime(ready))
+	if c.maxDispatch <= 0 || len(ready) <= c.maxDispatch {
+		return ready, false
+	}
+	c.logger.Info("backlog", "now", now, "dispatched", c.maxDispatch)
+	return ready[:c.maxDispatch], true
+}
@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWakeBudgetWakeAt(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cron := New(WithWakeBudget(6))
+	if wake := cron.wakeAt(t0.Add(time.Minute)); !wake.Equal(t0.Add(time.Minute)) {
+		t.Errorf("expected the first wake-up on time, got %v", wake)
+	}
+
+	cron.lastWake = t0
+	tests := []struct {
+		next, wake time.Time
+	}{
+		{t0.Add(time.Minute), t0.Add(10 * time.Minute)},
+		{t0.Add(10 * time.Minute), t0.Add(10 * time.Minute)},
+		{t0.Add(11 * time.Minute), t0.Add(11 * time.Minute)},
+	}
+	for _, tt := range tests {
+		if wake := cron.wakeAt(tt.next); !wake.Equal(tt.wake) {
+			t.Errorf("next %v: expected to wake at %v, got %v", tt.next, tt.wake, wake)
+		}
+	}
+}
+
+func TestWakeBudgetCoalesced(t *testing.T) {
+	var (
+		t0   = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		rec  = &eventRecorder{}
+		runs int64
+	)
+	cron := New(WithWakeBudget(6), WithEventListener(rec), WithLogger(DiscardLogger))
+	early := cron.Schedule(Every(time.Hour), FuncJob(func() { atomic.AddInt64(&runs, 1) }),
+		WithMisfirePolicy(MisfireSkip))
+	onTime := cron.Schedule(Every(time.Hour), FuncJob(func() { atomic.AddInt64(&runs, 1) }),
+		WithMisfirePolicy(MisfireSkip))
+	cron.store.Get(early).Next = t0.Add(time.Minute)
+	cron.store.Get(onTime).Next = t0.Add(10 * time.Minute)
+	cron.plannedWake = t0.Add(10 * time.Minute)
+	now := cron.plannedWake.Add(100 * time.Millisecond)
+
+	ready := []*Entry{cron.store.Get(early), cron.store.Get(onTime)}
+	cron.coalesced(ready, now)
+	for _, e := range ready {
+		cron.fire(e, now)
+	}
+	cron.jobWaiter.Wait()
+
+	// The delayed run is not a misfire.
+	if n := atomic.LoadInt64(&runs); n != 2 {
+		t.Errorf("expected both entries to run, got %d runs", n)
+	}
+	var events []WakeCoalesced
+	for _, ev := range rec.recorded() {
+		if ev, ok := ev.(WakeCoalesced); ok {
+			events = append(events, ev)
+		}
+	}
+	if len(events) != 1 || len(events[0].Shifted) != 1 || events[0].Shifted[early] != 9*time.Minute {
+		t.Errorf("expected the early entry to be reported shifted by 9m, got %+v", events)
+	}
+}
+
+func TestMaxDispatchPerWake(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, store := range []Store{NewHeapStore(), NewInMemoryStore()} {
+		cron := New(WithMaxDispatchPerWake(3), WithStore(store), WithLogger(DiscardLogger))
+		for i := 0; i < 5; i++ {
+			id := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+			cron.store.Get(id).Next = t0.Add(time.Duration(5-i) * time.Second)
+			cron.store.Update(cron.store.Get(id))
+		}
+		ready, backlog := cron.ready(t0.Add(time.Minute))
+		if len(ready) != 3 || !backlog {
+			t.Fatalf("%T: expected 3 entries and a backlog, got %d and %v", store, len(ready), backlog)
+		}
+		for i, e := range ready {
+			if next := t0.Add(time.Duration(i+1) * time.Second); !e.Next.Equal(next) {
+				t.Errorf("%T: expected the earliest entries first, got %v at %d", store, e.Next, i)
+			}
+		}
+		if ready, backlog := cron.ready(t0.Add(3 * time.Second)); len(ready) != 3 || backlog {
+			t.Errorf("%T: expected 3 entries and no backlog, got %d and %v", store, len(ready), backlog)
+		}
+	}
+}
+
+func TestMaxDispatchPerWakeRunsBacklog(t *testing.T) {
+	var runs int64
+	cron := New(WithMaxDispatchPerWake(2), WithLogger(DiscardLogger))
+	at := time.Now().Add(200 * time.Millisecond)
+	for i := 0; i < 7; i++ {
+		cron.Schedule(onceSchedule{at}, FuncJob(func() { atomic.AddInt64(&runs, 1) }))
+	}
+	cron.Start()
+	time.Sleep(500 * time.Millisecond)
+	cron.Stop()
+	if n := atomic.LoadInt64(&runs); n != 7 {
+		t.Errorf("expected every entry to run once, got %d runs", n)
+	}
+}
@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WindowSchedule restricts the activations of another schedule to a window of
+// wall clock time, e.g. business hours.
+type WindowSchedule struct {
+	Schedule Schedule
+
+	// Start and End bound the window, as times of day measured from
+	// midnight, both inclusive. If End is before Start, the window spans
+	// midnight.
+	Start, End time.Duration
+
+	// Days, if not zero, restricts the window to the days of the week whose
+	// bits are set, as in SpecSchedule.Dow. A window spanning midnight
+	// belongs to the day it opens on.
+	Days uint64
+
+	Location *time.Location
+}
+
+// Between returns a Schedule whose activations are those of s that fall
+// between the given times of day ("15:04" format) in the given location, or
+// time.Local if nil. For example, polling every 5 minutes during business
+// hours:
+//
+//	Between(Every(5*time.Minute), "09:00", "17:00", nil)
+//
+// The same schedule may be written "@every 5m between 09:00 and 17:00", and
+// restricted to some days of the week with e.g. "on mon-fri".
+//
+// Since the activations of Every are relative to the previous one, an interval
+// schedule starts over at each opening of the window: its first activation in
+// the window is the opening itself.
+//
+// It panics if start or end is not a valid time of day.
+func Between(s Schedule, start, end string, loc *time.Location) WindowSchedule {
+	from, err := parseTimeOfDay(start)
+	if err != nil {
+		panic(err.Error())
+	}
+	to, err := parseTimeOfDay(end)
+	if err != nil {
+		panic(err.Error())
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return WindowSchedule{Schedule: s, Start: from, End: to, Location: loc}
+}
+
+// parseTimeOfDay parses a time of day in "15:04" format into its offset from
+// midnight.
+func parseTimeOfDay(hhmm string) (time.Duration, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("cron: invalid time of day %q: %v", hhmm, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// maxWindowSearch bounds how far ahead WindowSchedule looks for an activation
+// within its window, as SpecSchedule does.
+const maxWindowSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the next activation time, later than the given time.
+func (s WindowSchedule) Next(t time.Time) time.Time {
+	interval := isInterval(s.Schedule)
+	from := t
+	for {
+		next := s.Schedule.Next(from)
+		if next.IsZero() || next.Sub(t) > maxWindowSearch {
+			return time.Time{}
+		}
+		if s.contains(next) {
+			return next
+		}
+		open := s.opening(next)
+		if open.IsZero() {
+			return open
+		}
+		if interval {
+			return open
+		}
+		from = open.Add(-time.Nanosecond)
+	}
+}
+
+// contains reports whether t falls within the window.
+func (s WindowSchedule) contains(t time.Time) bool {
+	local := t.In(s.Location)
+	clock := sinceMidnight(local)
+	if s.Start <= s.End {
+		return clock >= s.Start && clock <= s.End && s.onDay(local.Weekday())
+	}
+	return clock >= s.Start && s.onDay(local.Weekday()) ||
+		clock <= s.End && s.onDay((local.Weekday()+6)%7)
+}
+
+// opening returns the first opening of the window after t.
+func (s WindowSchedule) opening(t time.Time) time.Time {
+	local := t.In(s.Location)
+	y, m, d := local.Date()
+	for i := 0; i <= 7; i++ {
+		open := time.Date(y, m, d+i, int(s.Start/time.Hour), int(s.Start%time.Hour/time.Minute), 0, 0, s.Location)
+		if open.After(t) && s.onDay(open.Weekday()) {
+			return open.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// onDay reports whether the window opens on the given day of the week.
+func (s WindowSchedule) onDay(day time.Weekday) bool {
+	return s.Days == 0 || s.Days&(1<<uint(day)) > 0
+}
+
+// sinceMidnight returns the wall clock time of day of t.
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// Spec returns the descriptor of the schedule, such as "@every 5m between
+// 09:00 and 17:00 on 1-5", or "" if its schedule has no descriptor.
+func (s WindowSchedule) Spec() string {
+	base, ok := s.Schedule.(specer)
+	if !ok {
+		return ""
+	}
+	spec := base.Spec()
+	i := strings.Index(spec, "@")
+	if i < 0 {
+		return ""
+	}
+	spec = spec[i:] + fmt.Sprintf(" between %02d:%02d and %02d:%02d",
+		s.Start/time.Hour, s.Start%time.Hour/time.Minute, s.End/time.Hour, s.End%time.Hour/time.Minute)
+	if s.Days != 0 {
+		spec += " on " + formatField(s.Days, dow, true)
+	}
+	return specLocation(s.Location) + spec
+}
+
+// parseWindow parses a descriptor restricted to a window, as in
+// "@every 5m between 09:00 and 17:00 on mon-fri".
+func parseWindow(descriptor string, loc *time.Location) (Schedule, error) {
+	i := strings.Index(descriptor, " between ")
+	base, err := parseDescriptor(strings.TrimSpace(descriptor[:i]), loc)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Fields(descriptor[i:])
+	if len(words) != 4 && len(words) != 6 || words[2] != "and" || len(words) == 6 && words[4] != "on" {
+		return nil, fmt.Errorf("failed to parse window %s: expected \"between hh:mm and hh:mm [on days]\"", descriptor)
+	}
+	s := WindowSchedule{Schedule: base, Location: loc}
+	if s.Start, err = parseTimeOfDay(words[1]); err != nil {
+		return nil, err
+	}
+	if s.End, err = parseTimeOfDay(words[3]); err != nil {
+		return nil, err
+	}
+	if len(words) == 6 {
+		for _, expr := range strings.Split(words[5], ",") {
+			days, err := getDowRange(expr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse days of window %s: %s", descriptor, err)
+			}
+			s.Days |= days
+		}
+		s.Days &^= starBit
+	}
+	return s, nil
+}
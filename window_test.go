@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		spec     string
+		time     string
+		expected string
+	}{
+		{"@every 5m between 09:00 and 17:00", "Mon Jul 9 10:00 2012", "Mon Jul 9 10:05 2012"},
+		{"@every 5m between 09:00 and 17:00", "Mon Jul 9 16:55 2012", "Mon Jul 9 17:00 2012"},
+		{"@every 5m between 09:00 and 17:00", "Mon Jul 9 16:58 2012", "Tue Jul 10 09:00 2012"},
+		{"@every 5m between 09:00 and 17:00", "Mon Jul 9 03:00 2012", "Mon Jul 9 09:00 2012"},
+		{"@every 5m between 09:00 and 17:00 on mon-fri", "Fri Jul 13 17:00 2012", "Mon Jul 16 09:00 2012"},
+		{"@every 5m between 09:00 and 17:00 on sat,sun", "Mon Jul 9 10:00 2012", "Sat Jul 14 09:00 2012"},
+		{"@hourly between 09:30 and 17:00", "Mon Jul 9 03:00 2012", "Mon Jul 9 10:00 2012"},
+		{"@hourly between 09:30 and 17:00", "Mon Jul 9 17:00 2012", "Tue Jul 10 10:00 2012"},
+		{"@hourly between 22:00 and 02:00", "Mon Jul 9 12:00 2012", "Mon Jul 9 22:00 2012"},
+		{"@hourly between 22:00 and 02:00", "Mon Jul 9 23:00 2012", "Tue Jul 10 00:00 2012"},
+		{"@hourly between 22:00 and 02:00", "Tue Jul 10 02:00 2012", "Tue Jul 10 22:00 2012"},
+		{"@hourly between 22:00 and 02:00 on fri", "Sat Jul 14 01:00 2012", "Sat Jul 14 02:00 2012"},
+		{"@hourly between 22:00 and 02:00 on fri", "Sat Jul 14 02:00 2012", "Fri Jul 20 22:00 2012"},
+	}
+	for _, c := range tests {
+		schedule, err := ParseStandard(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		actual := schedule.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, %s: (expected) %v != %v (actual)", c.spec, c.time, expected, actual)
+		}
+	}
+}
+
+func TestWindowNever(t *testing.T) {
+	nightly, _ := ParseStandard("0 3 * * *")
+	if next := Between(nightly, "09:00", "17:00", nil).Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected the zero time, got %v", next)
+	}
+}
+
+func TestWindowErrors(t *testing.T) {
+	for _, spec := range []string{
+		"@every 5m between 09:00",
+		"@every 5m between 09:00 to 17:00",
+		"@every 5m between 9am and 5pm",
+		"@every 5m between 09:00 and 17:00 on someday",
+		"@every 5m between 09:00 and 17:00 mon-fri",
+		"@sometimes between 09:00 and 17:00",
+	} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}